@@ -1,13 +1,19 @@
 package main
 
 import (
+	"context"
 	"github.com/joho/godotenv"
 	"github.com/sirupsen/logrus"
 	"os"
+	"strconv"
+	"strings"
 	"stroycity"
 	"stroycity/pkg/handler"
+	"stroycity/pkg/mail"
 	"stroycity/pkg/repository"
 	"stroycity/pkg/service"
+	"stroycity/pkg/storage"
+	"time"
 )
 
 func main() {
@@ -31,10 +37,88 @@ func main() {
 		logrus.Fatalf("failed to init DB: %s", err.Error())
 	}
 
+	urlExpiry, _ := time.ParseDuration(os.Getenv("S3_URL_EXPIRY"))
+	blobStorage, err := storage.NewBlobStorage(storage.Config{
+		Driver:         os.Getenv("STORAGE_DRIVER"),
+		LocalDir:       os.Getenv("UPLOADS_DIR"),
+		LocalPublicURL: os.Getenv("UPLOADS_PUBLIC_URL"),
+		Endpoint:       os.Getenv("S3_ENDPOINT"),
+		AccessKey:      os.Getenv("S3_ACCESS_KEY"),
+		SecretKey:      os.Getenv("S3_SECRET_KEY"),
+		Bucket:         os.Getenv("S3_BUCKET"),
+		Region:         os.Getenv("S3_REGION"),
+		UseSSL:         os.Getenv("S3_USE_SSL") == "true",
+		PublicURL:      os.Getenv("S3_PUBLIC_URL"),
+		URLExpiry:      urlExpiry,
+	})
+
+	if err != nil {
+		logrus.Fatalf("failed to init storage: %s", err.Error())
+	}
+
+	mailer, err := mail.NewSender(mail.Config{
+		Driver:   os.Getenv("MAIL_DRIVER"),
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     os.Getenv("SMTP_PORT"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+	})
+
+	if err != nil {
+		logrus.Fatalf("failed to init mail sender: %s", err.Error())
+	}
+
 	repos := repository.NewRepository(db)
-	services := service.NewService(repos)
+	feedRefreshInterval, _ := time.ParseDuration(os.Getenv("FEED_REFRESH_INTERVAL"))
+	reservationTTL, _ := time.ParseDuration(os.Getenv("RESERVATION_TTL"))
+	reservationSweepInterval, _ := time.ParseDuration(os.Getenv("RESERVATION_SWEEP_INTERVAL"))
+	stockMailInterval, _ := time.ParseDuration(os.Getenv("STOCK_MAIL_INTERVAL"))
+	stockGuestLimit, _ := strconv.Atoi(os.Getenv("STOCK_GUEST_LIMIT"))
+	stockGuestLimitWindow, _ := time.ParseDuration(os.Getenv("STOCK_GUEST_LIMIT_WINDOW"))
+	recommendationRefreshInterval, _ := time.ParseDuration(os.Getenv("RECOMMENDATION_REFRESH_INTERVAL"))
+	recommendationMinOrders, _ := strconv.Atoi(os.Getenv("RECOMMENDATION_MIN_ORDERS"))
+	recommendationPriceBand, _ := strconv.ParseFloat(os.Getenv("RECOMMENDATION_PRICE_BAND"), 64)
+	services := service.NewService(repos, blobStorage, mailer, service.Config{
+		Feed: service.FeedConfig{
+			ShopName:        os.Getenv("SHOP_NAME"),
+			Company:         os.Getenv("SHOP_COMPANY"),
+			SiteURL:         os.Getenv("SITE_URL"),
+			RefreshInterval: feedRefreshInterval,
+		},
+		CommerceML: service.CommerceMLConfig{
+			ExchangeDir:       os.Getenv("EXCHANGE_DIR"),
+			PriceType:         os.Getenv("COMMERCEML_PRICE_TYPE"),
+			DiscountPriceType: os.Getenv("COMMERCEML_DISCOUNT_PRICE_TYPE"),
+		},
+		Reservation: service.ReservationConfig{
+			TTL:           reservationTTL,
+			SweepInterval: reservationSweepInterval,
+		},
+		Moderation: service.ModerationConfig{
+			ForbiddenWords: strings.Split(os.Getenv("MODERATION_FORBIDDEN_WORDS"), ","),
+		},
+		SEO: service.SEOConfig{
+			SiteURL: os.Getenv("SITE_URL"),
+		},
+		StockSubscription: service.StockSubscriptionConfig{
+			MailInterval:     stockMailInterval,
+			GuestLimit:       stockGuestLimit,
+			GuestLimitWindow: stockGuestLimitWindow,
+		},
+		Recommendation: service.RecommendationConfig{
+			RefreshInterval: recommendationRefreshInterval,
+			MinOrders:       recommendationMinOrders,
+			PriceBand:       recommendationPriceBand,
+		},
+	})
 	handlers := handler.NewHandler(services)
 
+	go services.RunFeedRefresher(context.Background())
+	go services.RunReservationSweeper(context.Background())
+	go services.RunStockSubscriptionMailer(context.Background())
+	go services.RunRecommendationRefresher(context.Background())
+
 	srv := new(stroycity.Server)
 	if err := srv.Run(os.Getenv("PORT"), handlers.InitRoutes()); err != nil {
 		logrus.Fatalf("error occured while running server %s", err.Error())
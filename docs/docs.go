@@ -15,110 +15,141 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/brand": {
-            "post": {
-                "description": "Creates a new brand with the provided details. Only accessible by admin.",
+        "/1c/exchange": {
+            "get": {
+                "description": "CommerceML 2 exchange endpoint for the seller's 1C (type=catalog). mode=checkauth authenticates with HTTP Basic seller email/password and returns a session cookie; mode=init starts a session and reports zip=no and file_limit; mode=file uploads import.xml/offers.xml (raw body, large files arrive in parts); mode=import processes the file: import.xml links 1C goods to items by article, offers.xml updates price, price_with_discount and quantity. Responses are plain text: \"success\" or \"failure\" followed by details.",
                 "consumes": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "brands"
+                    "1C"
                 ],
-                "summary": "Create a new brand",
+                "summary": "1C CommerceML exchange",
                 "parameters": [
                     {
+                        "enum": [
+                            "catalog"
+                        ],
                         "type": "string",
-                        "description": "Bearer {JWT}",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "Exchange type",
+                        "name": "type",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Brand data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.BrandInput"
-                        }
+                        "enum": [
+                            "checkauth",
+                            "init",
+                            "file",
+                            "import"
+                        ],
+                        "type": "string",
+                        "description": "Exchange step",
+                        "name": "mode",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File name for file and import steps",
+                        "name": "filename",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Brand created successfully",
+                    "200": {
+                        "description": "success",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid input data",
+                        "description": "failure",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "401": {
-                        "description": "You are not authorized to access this resource",
+                        "description": "failure",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Failed to create brand",
+                        "description": "failure",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Deletes a brand by ID. Only accessible by admin.",
+            "post": {
+                "description": "CommerceML 2 exchange endpoint for the seller's 1C (type=catalog). mode=checkauth authenticates with HTTP Basic seller email/password and returns a session cookie; mode=init starts a session and reports zip=no and file_limit; mode=file uploads import.xml/offers.xml (raw body, large files arrive in parts); mode=import processes the file: import.xml links 1C goods to items by article, offers.xml updates price, price_with_discount and quantity. Responses are plain text: \"success\" or \"failure\" followed by details.",
+                "consumes": [
+                    "application/octet-stream"
+                ],
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "brands"
+                    "1C"
                 ],
-                "summary": "Delete a brand",
+                "summary": "1C CommerceML exchange",
                 "parameters": [
                     {
+                        "enum": [
+                            "catalog"
+                        ],
                         "type": "string",
-                        "description": "Bearer {JWT}",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "Exchange type",
+                        "name": "type",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Brand ID",
-                        "name": "brand_id",
+                        "enum": [
+                            "checkauth",
+                            "init",
+                            "file",
+                            "import"
+                        ],
+                        "type": "string",
+                        "description": "Exchange step",
+                        "name": "mode",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File name for file and import steps",
+                        "name": "filename",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Brand deleted successfully",
+                        "description": "success",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid brand ID",
+                        "description": "failure",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "401": {
-                        "description": "You are not authorized to access this resource",
+                        "description": "failure",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete brand",
+                        "description": "failure",
                         "schema": {
                             "type": "string"
                         }
@@ -126,9 +157,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/category": {
+        "/admin/attribute": {
             "post": {
-                "description": "Create a new category in the system",
+                "description": "Create a typed attribute (number with unit, enum or boolean) for items of a category. Only accessible by admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -136,9 +167,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Attributes"
                 ],
-                "summary": "Create a new category",
+                "summary": "Create a new category attribute",
                 "parameters": [
                     {
                         "type": "string",
@@ -148,18 +179,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Category data",
+                        "description": "Attribute data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CategoryInput"
+                            "$ref": "#/definitions/model.AttributeInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Category created successfully",
+                    "201": {
+                        "description": "AttributeID",
                         "schema": {
                             "type": "string"
                         }
@@ -177,7 +208,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to create category",
+                        "description": "Failed to create attribute",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -185,14 +216,14 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete a category by ID",
+                "description": "Delete an attribute by ID together with item values. Only accessible by admin.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Attributes"
                 ],
-                "summary": "Delete a category",
+                "summary": "Delete a category attribute",
                 "parameters": [
                     {
                         "type": "string",
@@ -202,22 +233,22 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Category ID",
-                        "name": "category_id",
+                        "type": "integer",
+                        "description": "Attribute ID",
+                        "name": "attribute_id",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category deleted successfully",
+                        "description": "Attribute deleted successfully",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid category ID",
+                        "description": "Invalid attribute ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -229,7 +260,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to delete category",
+                        "description": "Failed to delete attribute",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -237,9 +268,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/material": {
-            "post": {
-                "description": "Create a new material in the system",
+        "/admin/brand": {
+            "put": {
+                "description": "Renames a brand and updates its description, meta title and meta description. Renaming generates a new slug; the old one redirects to it. Only accessible by admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -247,9 +278,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Materials"
+                    "brands"
                 ],
-                "summary": "Create a new material",
+                "summary": "Update a brand",
                 "parameters": [
                     {
                         "type": "string",
@@ -259,18 +290,25 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Material data",
+                        "type": "integer",
+                        "description": "Brand ID",
+                        "name": "brand_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Brand data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.MaterialInput"
+                            "$ref": "#/definitions/model.BrandInput"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Material created successfully",
+                    "200": {
+                        "description": "Brand updated successfully",
                         "schema": {
                             "type": "string"
                         }
@@ -278,32 +316,41 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid input data",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     },
-                    "403": {
+                    "401": {
                         "description": "You are not authorized to access this resource",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Brand not found",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Failed to create material",
+                        "description": "Failed to update brand",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Delete a material from the system by its ID",
+            "post": {
+                "description": "Creates a new brand with the provided details. Only accessible by admin.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Materials"
+                    "brands"
                 ],
-                "summary": "Delete a material by ID",
+                "summary": "Create a new brand",
                 "parameters": [
                     {
                         "type": "string",
@@ -313,54 +360,51 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Material ID",
-                        "name": "material_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Brand data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BrandInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Material deleted successfully",
+                    "201": {
+                        "description": "Brand created successfully",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid material ID",
+                        "description": "Invalid input data",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     },
-                    "403": {
+                    "401": {
                         "description": "You are not authorized to access this resource",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete material",
+                        "description": "Failed to create brand",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/admin/sign-up": {
-            "post": {
-                "description": "Registers a new admin user",
-                "consumes": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Deletes a brand by ID. A brand with items is deleted only with reassign_to: its items are moved to that brand first. Only accessible by admin.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "brands"
                 ],
-                "summary": "Sign up as an admin",
+                "summary": "Delete a brand",
                 "parameters": [
                     {
                         "type": "string",
@@ -370,61 +414,106 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Admin signup data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.AdminLoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "Brand ID",
+                        "name": "brand_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Brand ID to move the items to",
+                        "name": "reassign_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Admin signed up successfully",
+                    "200": {
+                        "description": "Brand deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid input data",
+                        "description": "Invalid brand ID",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     },
-                    "403": {
+                    "401": {
                         "description": "You are not authorized to access this resource",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Brand not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Brand has items",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Failed to sign up",
+                        "description": "Failed to delete brand",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/brand": {
-            "get": {
-                "description": "Retrieves a list of all brands. Only accessible by admin.",
+        "/admin/brand/logo": {
+            "post": {
+                "description": "Replaces the brand logo with the image from the \"logo\" multipart field (JPEG, PNG or WebP). Only accessible by admin.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "brands"
                 ],
-                "summary": "Get list of brands",
+                "summary": "Upload a brand logo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Brand ID",
+                        "name": "brand_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Logo image",
+                        "name": "logo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "List of brands",
+                        "description": "Logo URL",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.BrandOutput"
-                            }
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid brand ID, unsupported or too large image",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "401": {
@@ -433,8 +522,14 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Brand not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
-                        "description": "Failed to get brand list",
+                        "description": "Failed to upload logo",
                         "schema": {
                             "type": "string"
                         }
@@ -442,16 +537,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/buyer": {
-            "get": {
-                "description": "Retrieve buyer information by ID",
+        "/admin/brand/merge": {
+            "post": {
+                "description": "Moves all items of the source brands (e.g. \"Knauf\" and \"КНАУФ\") to the target brand and deletes the sources. A missing description or logo of the target is taken from the sources. Only accessible by admin.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Buyers"
+                    "brands"
                 ],
-                "summary": "Get buyer information",
+                "summary": "Merge duplicate brands",
                 "parameters": [
                     {
                         "type": "string",
@@ -459,31 +557,54 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Target and duplicate brand IDs",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MergeInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Buyer data",
+                        "description": "Brands merged successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.BuyerOutput"
+                            "type": "string"
                         }
                     },
-                    "403": {
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
                         "description": "You are not authorized to access this resource",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Buyer not found",
+                        "description": "Brand not found",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to merge brands",
+                        "schema": {
+                            "type": "string"
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update buyer details",
+            }
+        },
+        "/admin/category": {
+            "put": {
+                "description": "Change the name and the meta title and description of a category by ID. Renaming generates a new slug; the old one redirects to it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -491,9 +612,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Buyers"
+                    "Categories"
                 ],
-                "summary": "Update buyer information",
+                "summary": "Update a category",
                 "parameters": [
                     {
                         "type": "string",
@@ -503,18 +624,25 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Buyer data",
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "category_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Category data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.BuyerInput"
+                            "$ref": "#/definitions/model.CategoryInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Buyer updated successfully",
+                        "description": "Category updated successfully",
                         "schema": {
                             "type": "string"
                         }
@@ -525,66 +653,28 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "403": {
+                    "401": {
                         "description": "You are not authorized to access this resource",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Failed to update buyer",
+                    "404": {
+                        "description": "Category not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/buyer/cart": {
-            "get": {
-                "description": "Retrieves the contents of the buyer's cart. Only accessible by buyers.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "cart"
-                ],
-                "summary": "Get cart contents",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {JWT}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Cart items",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.CartOutput"
-                            }
-                        }
-                    },
-                    "403": {
-                        "description": "You are not authorized to access this resource",
-                        "schema": {
-                            "type": "string"
-                        }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to update category",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Adds a specified item with a specified quantity to the buyer's cart. Only accessible by buyers.",
+                "description": "Create a new category in the system",
                 "consumes": [
                     "application/json"
                 ],
@@ -592,9 +682,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cart"
+                    "Categories"
                 ],
-                "summary": "Add an item to cart",
+                "summary": "Create a new category",
                 "parameters": [
                     {
                         "type": "string",
@@ -604,51 +694,51 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Item ID and Quantity",
+                        "description": "Category data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.AddToCartInput"
+                            "$ref": "#/definitions/model.CategoryInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Item added to cart",
+                        "description": "Category created successfully",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid input data",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "403": {
+                    "401": {
                         "description": "You are not authorized to access this resource",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to create category",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
             },
             "delete": {
-                "description": "Removes a specified item from the buyer's cart by cart item ID. Only accessible by buyers.",
+                "description": "Delete a category by ID together with its attributes. A category with items or bundles is deleted only with reassign_to: its items, bundles and attributes are moved to that category first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cart"
+                    "Categories"
                 ],
-                "summary": "Remove an item from the cart",
+                "summary": "Delete a category",
                 "parameters": [
                     {
                         "type": "string",
@@ -658,44 +748,62 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Cart Item ID",
-                        "name": "cart_item_id",
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "category_id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category ID to move the items and bundles to",
+                        "name": "reassign_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Item removed from cart",
+                        "description": "Category deleted successfully",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid cart item ID",
+                        "description": "Invalid category ID",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "403": {
+                    "401": {
                         "description": "You are not authorized to access this resource",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Category has items or bundles",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to delete category",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/buyer/favorites": {
+        "/admin/category/merge": {
             "post": {
-                "description": "Add a specific item to the buyer's favorites",
+                "description": "Move all items and bundles of the source categories to the target category and delete the sources. Attributes with the same name and type are merged, the rest are moved.",
                 "consumes": [
                     "application/json"
                 ],
@@ -703,9 +811,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Favorites"
+                    "Categories"
                 ],
-                "summary": "Add item to favorites",
+                "summary": "Merge duplicate categories",
                 "parameters": [
                     {
                         "type": "string",
@@ -715,48 +823,52 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Item ID",
-                        "name": "item_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Target and duplicate category IDs",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MergeInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Item added to favorites",
+                        "description": "Categories merged successfully",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid item ID",
+                        "description": "Invalid input data",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "403": {
+                    "401": {
                         "description": "You are not authorized to access this resource",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Item not found",
+                        "description": "Category not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to add item to favorites",
+                        "description": "Failed to merge categories",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Remove a specific item from the buyer's favorites",
+            }
+        },
+        "/admin/material": {
+            "put": {
+                "description": "Change the name of a material by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -764,9 +876,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Favorites"
+                    "Materials"
                 ],
-                "summary": "Remove item from favorites",
+                "summary": "Rename a material",
                 "parameters": [
                     {
                         "type": "string",
@@ -776,22 +888,31 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Item ID",
-                        "name": "item_id",
+                        "type": "string",
+                        "description": "Material ID",
+                        "name": "material_id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "description": "Material data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MaterialInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Item removed from favorites",
+                        "description": "Material updated successfully",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid item ID",
+                        "description": "Invalid input data",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -803,30 +924,31 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Item not found in favorites",
+                        "description": "Material not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to remove item from favorites",
+                        "description": "Failed to update material",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/buyer/order": {
-            "get": {
-                "description": "Retrieve an order by its ID for the buyer",
+            },
+            "post": {
+                "description": "Create a new material in the system",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Materials"
                 ],
-                "summary": "Get an order by ID",
+                "summary": "Create a new material",
                 "parameters": [
                     {
                         "type": "string",
@@ -836,22 +958,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "order_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Material data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.MaterialInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Order data",
+                    "201": {
+                        "description": "Material created successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.OrderOutput"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID",
+                        "description": "Invalid input data",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -862,29 +986,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Order not found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to create material",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Create an order from the items in the cart of the current buyer",
+            "delete": {
+                "description": "Delete a material from the system by its ID. A material used by items is deleted only with reassign_to: its items are moved to that material first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Materials"
                 ],
-                "summary": "Create a new order",
+                "summary": "Delete a material by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -892,17 +1010,30 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Material ID",
+                        "name": "material_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Material ID to move the items to",
+                        "name": "reassign_to",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order created successfully",
+                        "description": "Material deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Cart is empty",
+                        "description": "Invalid material ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -913,8 +1044,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Material not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Material is used by items",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to delete material",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -922,9 +1065,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/buyer/review": {
+        "/admin/material/merge": {
             "post": {
-                "description": "Позволяет покупателю создать отзыв для товара.",
+                "description": "Move all items of the source materials to the target material and delete the sources",
                 "consumes": [
                     "application/json"
                 ],
@@ -932,9 +1075,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reviews"
+                    "Materials"
                 ],
-                "summary": "Создание нового отзыва",
+                "summary": "Merge duplicate materials",
                 "parameters": [
                     {
                         "type": "string",
@@ -944,65 +1087,92 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Информация о отзыве",
-                        "name": "review",
+                        "description": "Target and duplicate material IDs",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Review"
+                            "$ref": "#/definitions/model.MergeInput"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Review created successfully",
+                    "200": {
+                        "description": "Materials merged successfully",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid input data",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "You are not authorized to access this resource",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Material not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to merge materials",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/category": {
+        "/admin/moderation": {
             "get": {
-                "description": "Retrieve a list of all categories",
+                "description": "Retrieve item and bundle moderation requests with the given status (pending by default). Pending requests are sorted by auto-check priority: forbidden words, price anomalies and missing images go first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Moderation"
+                ],
+                "summary": "Get moderation queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request status: pending, approved, rejected or withdrawn",
+                        "name": "status",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get category list",
                 "responses": {
                     "200": {
-                        "description": "List of categories",
+                        "description": "Moderation requests",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.CategoryOutput"
+                                "$ref": "#/definitions/model.ModerationRequestOutput"
                             }
                         }
                     },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Failed to retrieve category list",
+                        "description": "Failed to get moderation queue",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1010,20 +1180,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/item": {
-            "get": {
-                "description": "Retrieve an item by its ID",
+        "/admin/moderation/approve": {
+            "post": {
+                "description": "Approve a pending moderation request: the item or bundle is published, or the pending changes of a published one replace its current version, and the seller is notified",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Items"
+                    "Moderation"
                 ],
-                "summary": "Get item by ID",
+                "summary": "Approve moderation request",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Item ID",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Moderation request ID",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -1031,27 +1208,47 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Item details",
+                        "description": "Item approved",
                         "schema": {
-                            "$ref": "#/definitions/model.CurrentItemInfo"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid item ID",
+                        "description": "Invalid request ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Item not found",
+                        "description": "Moderation request not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Moderation request is already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to approve item",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/moderation/reject": {
             "post": {
-                "description": "Retrieve a list of items, optionally filtered",
+                "description": "Reject a pending moderation request: a new item or bundle goes back to drafts, a published one keeps its approved version, and the seller is notified with the reason",
                 "consumes": [
                     "application/json"
                 ],
@@ -1059,61 +1256,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Items"
+                    "Moderation"
                 ],
-                "summary": "Get item list",
+                "summary": "Reject moderation request",
                 "parameters": [
                     {
-                        "description": "Filter criteria",
-                        "name": "filters",
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Moderation request ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Reject reason",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.FilterRequest"
+                            "$ref": "#/definitions/model.ModerationRejectInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of items",
+                        "description": "Item rejected",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.ItemInfo"
-                            }
+                            "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Failed to get items",
+                    "400": {
+                        "description": "Invalid input data",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/material": {
-            "get": {
-                "description": "Retrieve a list of materials from the system",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Materials"
-                ],
-                "summary": "Get material list",
-                "responses": {
-                    "200": {
-                        "description": "List of materials",
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.MaterialOutput"
-                            }
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Moderation request not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Moderation request is already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve material list",
+                        "description": "Failed to reject item",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1121,63 +1324,91 @@ const docTemplate = `{
                 }
             }
         },
-        "/review": {
-            "get": {
-                "description": "Позволяет получить список отзывов для указанного товара по его ID.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/order/refund": {
+            "post": {
+                "description": "Cancel any order that is not cancelled yet. The reservation of an unpaid order is released; the items of a paid order are returned to stock with a cancellation movement and the payment is withdrawn from the sellers in the same transaction. Only accessible by administrators.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reviews"
+                    "Orders"
                 ],
-                "summary": "Получение отзывов для товара",
+                "summary": "Refund an order",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "ID товара",
-                        "name": "item_id",
+                        "description": "Order ID",
+                        "name": "order_id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason recorded in the inventory movements",
+                        "name": "reason",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список отзывов для товара",
+                        "description": "Order refunded successfully",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Review"
-                            }
+                            "$ref": "#/definitions/handler.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid item ID",
+                        "description": "Invalid order ID",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is already cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/seller": {
-            "get": {
-                "description": "Retrieve seller details by ID",
+        "/admin/sign-up": {
+            "post": {
+                "description": "Registers a new admin user",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Sellers"
+                    "admin"
                 ],
-                "summary": "Get seller information",
+                "summary": "Sign up as an admin",
                 "parameters": [
                     {
                         "type": "string",
@@ -1185,13 +1416,28 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Admin signup data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AdminLoginRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Seller data",
+                    "201": {
+                        "description": "Admin signed up successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.SellerOutput"
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "403": {
@@ -1201,64 +1447,51 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve seller",
+                        "description": "Failed to sign up",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update the details of the seller",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/attribute": {
+            "get": {
+                "description": "Retrieve attributes defined for a category, with options for enum attributes",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Sellers"
+                    "Attributes"
                 ],
-                "summary": "Update seller information",
+                "summary": "Get category attributes",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {JWT}",
-                        "name": "Authorization",
-                        "in": "header",
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "category_id",
+                        "in": "query",
                         "required": true
-                    },
-                    {
-                        "description": "Updated seller data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.SellerInput"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Seller updated successfully",
+                        "description": "List of attributes",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AttributeOutput"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid input data",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "You are not authorized to access this resource",
+                        "description": "Invalid category ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update seller",
+                        "description": "Failed to get attribute list",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1266,195 +1499,165 @@ const docTemplate = `{
                 }
             }
         },
-        "/seller/item": {
-            "post": {
-                "description": "Create a new item in the system",
-                "consumes": [
-                    "application/json"
-                ],
+        "/brand": {
+            "get": {
+                "description": "Retrieves a list of all brands. Only accessible by admin.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Items"
-                ],
-                "summary": "Create a new item",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {JWT}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "Item data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.ItemInput"
-                        }
-                    }
+                    "brands"
                 ],
+                "summary": "Get list of brands",
                 "responses": {
-                    "201": {
-                        "description": "ItemID",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid input data",
+                    "200": {
+                        "description": "List of brands",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.BrandOutput"
+                            }
                         }
                     },
                     "401": {
                         "description": "You are not authorized to access this resource",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Failed to create item",
+                        "description": "Failed to get brand list",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update an item by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/brand/slug": {
+            "get": {
+                "description": "Retrieves a brand by its slug. A former slug of a renamed or merged brand responds with 301 and the current slug in the Location header and body.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Items"
+                    "brands"
                 ],
-                "summary": "Update an existing item",
+                "summary": "Get brand by slug",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer {JWT}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Item ID",
-                        "name": "id",
+                        "description": "Brand slug",
+                        "name": "slug",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "description": "Item data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.ItemInput"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Item updated successfully",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid input data",
+                        "description": "Brand",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/model.BrandOutput"
                         }
                     },
-                    "401": {
-                        "description": "You are not authorized to access this resource",
+                    "301": {
+                        "description": "Current slug",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/model.SlugRedirectOutput"
                         }
                     },
                     "404": {
-                        "description": "Item not found",
+                        "description": "Brand not found",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Failed to update item",
+                        "description": "Failed to get brand",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/seller/item/image": {
-            "post": {
-                "description": "Upload an image for a specific item by ID",
+        "/bundle": {
+            "get": {
+                "description": "Get a published bundle with its components, savings and the number of bundles available from component stock",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Items"
+                    "Bundles"
                 ],
-                "summary": "Upload an image for an item",
+                "summary": "Get a bundle",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer {JWT}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Item ID",
-                        "name": "item_id",
+                        "description": "Bundle ID",
+                        "name": "id",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Image file",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "url\": \"url",
+                        "description": "Bundle",
                         "schema": {
-                            "$ref": "#/definitions/handler.SuccessResponse"
+                            "$ref": "#/definitions/model.BundleInfo"
                         }
                     },
                     "400": {
-                        "description": "Invalid item ID or failed to upload image",
+                        "description": "Invalid bundle ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "You are not authorized to access this resource",
+                    "404": {
+                        "description": "Bundle not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/bundle/list": {
+            "get": {
+                "description": "Get published bundles, optionally of a single category",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bundles"
+                ],
+                "summary": "Get bundle list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bundles",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.BundleInfo"
+                            }
+                        }
                     },
-                    "404": {
-                        "description": "Item not found",
+                    "400": {
+                        "description": "Invalid category ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to save image",
+                        "description": "Failed to get bundles",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1462,16 +1665,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/seller/statistic": {
+        "/buyer": {
             "get": {
-                "description": "Retrieve seller earnings for the current and previous week",
+                "description": "Retrieve buyer information by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Sellers"
+                    "Buyers"
                 ],
-                "summary": "Get seller earnings",
+                "summary": "Get buyer information",
                 "parameters": [
                     {
                         "type": "string",
@@ -1483,9 +1686,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Earnings for current and last week",
+                        "description": "Buyer data",
                         "schema": {
-                            "$ref": "#/definitions/model.Statistic"
+                            "$ref": "#/definitions/model.BuyerOutput"
                         }
                     },
                     "403": {
@@ -1494,18 +1697,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Failed to retrieve earnings",
+                    "404": {
+                        "description": "Buyer not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/sign-in/admin": {
-            "post": {
-                "description": "Logs in an admin user",
+            },
+            "patch": {
+                "description": "Update buyer details",
                 "consumes": [
                     "application/json"
                 ],
@@ -1513,91 +1714,100 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "Buyers"
                 ],
-                "summary": "Sign in as an admin",
+                "summary": "Update buyer information",
                 "parameters": [
                     {
-                        "description": "Admin login data",
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Buyer data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.AdminLoginRequest"
+                            "$ref": "#/definitions/model.BuyerInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Authentication token",
+                        "description": "Buyer updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/handler.TokenResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid login data",
+                        "description": "Invalid input data",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Invalid credentials",
-                        "schema": {
+                    "403": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update buyer",
+                        "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/sign-in/buyer": {
-            "post": {
-                "description": "Authenticate a buyer with email and password",
-                "consumes": [
-                    "application/json"
-                ],
+        "/buyer/cart": {
+            "get": {
+                "description": "Retrieves the contents of the buyer's cart. Only accessible by buyers.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Buyers"
+                    "cart"
                 ],
-                "summary": "Buyer login",
+                "summary": "Get cart contents",
                 "parameters": [
                     {
-                        "description": "Login data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Authenticated buyer data",
+                        "description": "Cart items",
                         "schema": {
-                            "$ref": "#/definitions/model.BuyerOutput"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CartOutput"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Invalid login data",
+                    "403": {
+                        "description": "You are not authorized to access this resource",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Invalid credentials",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/sign-in/seller": {
+            },
             "post": {
-                "description": "Authenticate a seller with their email and password",
+                "description": "Adds a specified item with a specified quantity to the buyer's cart. Only accessible by buyers.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1605,91 +1815,110 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Sellers"
+                    "cart"
                 ],
-                "summary": "Seller sign in",
+                "summary": "Add an item to cart",
                 "parameters": [
                     {
-                        "description": "Login credentials",
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Item ID and Quantity",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.SellerSignInResponse"
+                            "$ref": "#/definitions/model.AddToCartInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Seller data",
+                        "description": "Item added to cart",
                         "schema": {
-                            "$ref": "#/definitions/model.Seller"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid input data",
+                        "description": "Invalid input",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "You are not authorized to access this resource",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/sign-up/buyer": {
-            "post": {
-                "description": "Register a new buyer in the system",
-                "consumes": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Removes a specified item from the buyer's cart by cart item ID. Only accessible by buyers.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Buyers"
+                    "cart"
                 ],
-                "summary": "Create a new buyer",
+                "summary": "Remove an item from the cart",
                 "parameters": [
                     {
-                        "description": "Buyer data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.BuyerInput"
-                        }
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cart Item ID",
+                        "name": "cart_item_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Buyer signed up successfully",
+                    "200": {
+                        "description": "Item removed from cart",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid input data",
+                        "description": "Invalid cart item ID",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Failed to sign up",
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/sign-up/seller": {
+        "/buyer/cart/bundle": {
             "post": {
-                "description": "Register a new seller with the provided details",
+                "description": "Adds a bundle with a specified quantity to the buyer's cart; if the bundle is already there, its quantity is replaced. Only accessible by buyers.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1697,83 +1926,5778 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Sellers"
+                    "cart"
                 ],
-                "summary": "Register a new seller",
+                "summary": "Add a bundle to cart",
                 "parameters": [
                     {
-                        "description": "Seller data",
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Bundle ID and Quantity",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.SellerInput"
+                            "$ref": "#/definitions/model.AddBundleToCartInput"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Seller registered successfully",
+                    "200": {
+                        "description": "Bundle added to cart",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid input data",
+                        "description": "Invalid input",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Bundle not found",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Failed to register seller",
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "type": "string"
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "handler.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "handler.SuccessResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
+            },
+            "delete": {
+                "description": "Removes a bundle from the buyer's cart by bundle ID. Only accessible by buyers.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cart"
+                ],
+                "summary": "Remove a bundle from the cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Bundle ID",
+                        "name": "bundle_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bundle removed from cart",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid bundle ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Bundle not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
                 }
             }
         },
-        "handler.TokenResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
-                    "type": "string"
-                }
-            }
+        "/buyer/cart/recommendations": {
+            "get": {
+                "description": "\"Complete your project\" suggestions for the buyer's cart: items frequently bought together with the cart contents and complementary items from related categories (e.g. drywall → profiles, screws, putty). similar is always empty.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cart"
+                ],
+                "summary": "Get cart recommendations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recommendations",
+                        "schema": {
+                            "$ref": "#/definitions/model.RecommendationsOutput"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get recommendations",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyer/favorites": {
+            "post": {
+                "description": "Add a specific item to the buyer's favorites. The buyer is notified when the item gets cheaper or comes back in stock.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Favorites"
+                ],
+                "summary": "Add item to favorites",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "item_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item added to favorites",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to add item to favorites",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a specific item from the buyer's favorites",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Favorites"
+                ],
+                "summary": "Remove item from favorites",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "item_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item removed from favorites",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found in favorites",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to remove item from favorites",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyer/notifications": {
+            "get": {
+                "description": "Retrieve notifications of the current buyer, newest first: price drops and restocks of favourite items",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get buyer notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notifications",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.NotificationOutput"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get notifications",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyer/notifications/read": {
+            "patch": {
+                "description": "Mark the given notifications of the current buyer as read, or all of them when ids are omitted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark buyer notifications as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Notification IDs",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.NotificationReadInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notifications marked as read",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update notifications",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyer/order": {
+            "get": {
+                "description": "Retrieve an order by its ID for the buyer",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get an order by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order data",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create an order from the items in the cart of the current buyer. Items stocked per warehouse are allocated from the chosen warehouse or the warehouses nearest to the buyer's coordinates; set pickup with warehouse_id for self-pickup.\nThe items are reserved until reserved_until; an order that is not paid by then is cancelled and the reservation is released.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Create a new order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Warehouse, pickup and buyer coordinates",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.CheckoutInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order created, awaiting payment",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OrderCreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Cart is empty",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyer/order/cancel": {
+            "post": {
+                "description": "Cancel an unpaid order of the current buyer and release its reservation. A paid order cannot be cancelled by the buyer; it is refunded by an administrator.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Cancel an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order cancelled successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is already cancelled or paid",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyer/order/pay": {
+            "post": {
+                "description": "Confirm payment of an order awaiting payment. The reserved items are written off the stock and the sellers are credited.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Pay for an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order paid successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation expired or order already paid",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyer/recently-viewed": {
+            "get": {
+                "description": "Retrieve published items the current buyer has viewed, most recent first. Up to 50 items are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recently viewed"
+                ],
+                "summary": "Get recently viewed items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recently viewed items",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.RecentlyViewedOutput"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get recently viewed items",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Clear the browsing history of the current buyer. View counts used for popularity are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recently viewed"
+                ],
+                "summary": "Clear recently viewed items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Browsing history cleared",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to clear browsing history",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyer/review": {
+            "post": {
+                "description": "Позволяет покупателю создать отзыв для товара.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Создание нового отзыва",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Информация о отзыве",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Review"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Review created successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyer/subscriptions": {
+            "get": {
+                "description": "Retrieve the current buyer's back-in-stock subscriptions, newest first. notified_at is set once the item has been restocked.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock subscriptions"
+                ],
+                "summary": "Get back-in-stock subscriptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subscriptions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StockSubscriptionOutput"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get subscriptions",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Subscribe the current buyer to an out-of-stock item. When the item is restocked the buyer gets one notification, even if the item is also in favourites. Subscribing again after a notification waits for the next restock.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock subscriptions"
+                ],
+                "summary": "Subscribe to back-in-stock notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "item_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subscribed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Item is in stock",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to subscribe",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Cancel the current buyer's back-in-stock subscription to an item",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock subscriptions"
+                ],
+                "summary": "Unsubscribe from back-in-stock notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "item_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unsubscribed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to unsubscribe",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/category": {
+            "get": {
+                "description": "Retrieve a list of all categories",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get category list",
+                "responses": {
+                    "200": {
+                        "description": "List of categories",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CategoryOutput"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve category list",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/category/slug": {
+            "get": {
+                "description": "Retrieve a category by its slug. A former slug of a renamed or merged category responds with 301 and the current slug in the Location header and body.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get category by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category slug",
+                        "name": "slug",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category",
+                        "schema": {
+                            "$ref": "#/definitions/model.CategoryOutput"
+                        }
+                    },
+                    "301": {
+                        "description": "Current slug",
+                        "schema": {
+                            "$ref": "#/definitions/model.SlugRedirectOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve category",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/comparison": {
+            "get": {
+                "description": "Retrieve the comparison lists of the current buyer, or of a guest identified by the X-Device-Token header, grouped by category",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comparison"
+                ],
+                "summary": "Get comparison lists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest device token",
+                        "name": "X-Device-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comparison lists",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ComparisonCategory"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Device token is required for guests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid authorization header",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get comparison lists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a published item to the comparison list of its category. A category list holds up to 10 items.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comparison"
+                ],
+                "summary": "Add item to comparison",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest device token",
+                        "name": "X-Device-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "item_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item added to comparison",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID or missing device token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid authorization header",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Comparison list of the category is full",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to add item to comparison",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove an item from the comparison list of its category",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comparison"
+                ],
+                "summary": "Remove item from comparison",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest device token",
+                        "name": "X-Device-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "item_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item removed from comparison",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID or missing device token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid authorization header",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item is not in the comparison list",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to remove item from comparison",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/comparison/clear": {
+            "delete": {
+                "description": "Remove all items from the comparison list of a category, or from all lists when category_id is omitted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comparison"
+                ],
+                "summary": "Clear comparison list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest device token",
+                        "name": "X-Device-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comparison cleared",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid category ID or missing device token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid authorization header",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to clear comparison",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/comparison/table": {
+            "get": {
+                "description": "Side-by-side table of the compared items of a category: main card fields, dimensions and category attributes. Values follow the order of items; rows where items differ are marked with differs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comparison"
+                ],
+                "summary": "Get comparison table",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest device token",
+                        "name": "X-Device-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "category_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return only rows where items differ",
+                        "name": "only_differences",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comparison table",
+                        "schema": {
+                            "$ref": "#/definitions/model.ComparisonTable"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid category ID or missing device token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid authorization header",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get comparison table",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feed/csv": {
+            "get": {
+                "description": "Catalog feed in CSV (semicolon separated, UTF-8 with BOM) with the same column names as the catalog import. Without seller_id the whole platform is exported. Supports ETag and Last-Modified like the YML feed.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "Get CSV catalog feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller ID",
+                        "name": "seller_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached feed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of the cached feed",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV feed",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Seller not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get feed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feed/yml": {
+            "get": {
+                "description": "Catalog feed in Yandex Market YML format. Without seller_id the whole platform is exported. The feed is regenerated periodically; ETag and Last-Modified change only when the data changes, conditional requests get 304.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "Get YML catalog feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller ID",
+                        "name": "seller_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached feed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of the cached feed",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "YML feed",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Seller not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get feed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/item": {
+            "get": {
+                "description": "Retrieve a published item by its ID. Drafts, items on moderation and archived items are visible only to their seller and administrators. Viewing a published item adds it to the recently viewed items of the buyer or of the guest identified by X-Device-Token and counts towards popularity. Anonymous views without a buyer or device token are not counted, and guest views are counted up to a limit per client IP.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Get item by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest device token",
+                        "name": "X-Device-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item details",
+                        "schema": {
+                            "$ref": "#/definitions/model.CurrentItemInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Retrieve a list of items, optionally filtered and sorted. sort=popular orders by item page views.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Get item list",
+                "parameters": [
+                    {
+                        "description": "Filter criteria",
+                        "name": "filters",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.FilterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of items",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ItemInfo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid sort",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get items",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/item/price-history": {
+            "get": {
+                "description": "Price chart of an item: every change of price and price with discount in the period. When from is set, the first point is the price in effect at that moment. Items that are not published respond with 404.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Get item price history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period start, RFC 3339 or YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period end (exclusive), RFC 3339 or YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price history",
+                        "schema": {
+                            "$ref": "#/definitions/model.PriceHistoryOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID or period",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get price history",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/item/slug": {
+            "get": {
+                "description": "Retrieve a published item by its slug. A former slug of a renamed item responds with 301 and the current slug in the Location header and body. Views are recorded as for GET /item.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Get item by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest device token",
+                        "name": "X-Device-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item slug",
+                        "name": "slug",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item details",
+                        "schema": {
+                            "$ref": "#/definitions/model.CurrentItemInfo"
+                        }
+                    },
+                    "301": {
+                        "description": "Current slug",
+                        "schema": {
+                            "$ref": "#/definitions/model.SlugRedirectOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/item/{id}/recommendations": {
+            "get": {
+                "description": "Items frequently bought together with the item, similar items of the same category within the price band (same material first) and complementary items from categories usually bought with it. Only in-stock items are recommended; co-purchases are refreshed by a background job.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Get item recommendations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recommendations",
+                        "schema": {
+                            "$ref": "#/definitions/model.RecommendationsOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get recommendations",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/material": {
+            "get": {
+                "description": "Retrieve a list of materials from the system",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Materials"
+                ],
+                "summary": "Get material list",
+                "responses": {
+                    "200": {
+                        "description": "List of materials",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.MaterialOutput"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve material list",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product": {
+            "get": {
+                "description": "Retrieve a product card with its variant matrix",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get product by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product with variants",
+                        "schema": {
+                            "$ref": "#/definitions/model.VariantMatrix"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recently-viewed": {
+            "get": {
+                "description": "Retrieve published items viewed from the guest device identified by X-Device-Token, most recent first. A signed-in buyer gets their own history.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recently viewed"
+                ],
+                "summary": "Get guest recently viewed items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest device token",
+                        "name": "X-Device-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recently viewed items",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.RecentlyViewedOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Device token is required for guests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid authorization header",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get recently viewed items",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Clear the browsing history of the guest device identified by X-Device-Token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recently viewed"
+                ],
+                "summary": "Clear guest recently viewed items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest device token",
+                        "name": "X-Device-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Browsing history cleared",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Device token is required for guests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid authorization header",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to clear browsing history",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/review": {
+            "get": {
+                "description": "Позволяет получить список отзывов для указанного товара по его ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Получение отзывов для товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID товара",
+                        "name": "item_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список отзывов для товара",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Review"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller": {
+            "get": {
+                "description": "Retrieve seller details by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sellers"
+                ],
+                "summary": "Get seller information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Seller data",
+                        "schema": {
+                            "$ref": "#/definitions/model.SellerOutput"
+                        }
+                    },
+                    "403": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve seller",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update the details of the seller",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sellers"
+                ],
+                "summary": "Update seller information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated seller data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SellerInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Seller updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update seller",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/bundle": {
+            "get": {
+                "description": "Retrieve all bundles of the current seller in any status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bundles"
+                ],
+                "summary": "Get seller bundles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bundles",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.BundleInfo"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get bundles",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update name, price, status and components of a seller bundle. Price and components change right away; a new name, description or category of a published bundle is held for moderation while the approved version stays in the catalog. Moving a bundle to drafts or the archive withdraws its pending moderation request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bundles"
+                ],
+                "summary": "Update a bundle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bundle ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Bundle data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BundleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bundle updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Bundle not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a bundle (kit) of the seller's items sold at its own price. Stock is derived from the components. Component quantities must respect the items' minimum order quantity and step. A published bundle is sent to moderation and appears in the catalog once approved; set status to \"draft\" to prepare it without submitting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bundles"
+                ],
+                "summary": "Create a bundle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Bundle data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BundleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "BundleID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a seller bundle and remove it from buyers' carts. Component items are not affected.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bundles"
+                ],
+                "summary": "Delete a bundle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bundle ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bundle deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid bundle ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Bundle not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/import": {
+            "get": {
+                "description": "Poll progress and counters of a catalog import job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Import"
+                ],
+                "summary": "Get import job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Import job ID",
+                        "name": "job_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import job",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImportJobOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Import job not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Upload a CSV or XLSX price list (up to 20 MB) to create or update the seller's items. Rows are matched by article: existing items are updated with filled cells only, new items are created. Columns are recognised by field name (article, name, price, category, ...) or common Russian headers; the optional mapping field overrides it with a JSON object {\"field\": \"column header\"}. Category, brand and material are given by name. Processing runs in background; poll GET /seller/import for progress.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Import"
+                ],
+                "summary": "Start catalog import",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV or XLSX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON object mapping item fields to column headers",
+                        "name": "mapping",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "JobID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file or column mapping",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to start import",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/import/report": {
+            "get": {
+                "description": "Download a CSV report (semicolon separated, UTF-8 with BOM) with the row number, article and error for every rejected row",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Import"
+                ],
+                "summary": "Download import error report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Import job ID",
+                        "name": "job_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Error report",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid job ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Import job not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to build report",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/inventory": {
+            "patch": {
+                "description": "Partially update quantity, price and price_with_discount of up to 1000 items identified by item_id or article. Omitted fields are left unchanged. Pass the item version read earlier to reject the row with status \"conflict\" if the item was changed meanwhile. Every row is applied independently and gets its own result; a row that fails with an internal error gets status \"error\" and the remaining rows are still applied. price_with_discount must not exceed price; changing only the price of an item without a discount keeps it without a discount. Stock changes are recorded in the inventory movements with the row reason.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Bulk update stock and prices",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Inventory rows",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.InventoryUpdate"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-row results",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.InventoryUpdateResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update inventory",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/inventory/low-stock-threshold": {
+            "put": {
+                "description": "Set the low-stock threshold of an item, or the seller's default threshold when item_id is omitted. When stock drops to the threshold the seller gets a notification. An item threshold of 0 falls back to the seller default; a seller default of 0 disables the alerts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Set low-stock threshold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Threshold",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LowStockThresholdInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Threshold updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/inventory/movements": {
+            "get": {
+                "description": "Retrieve the append-only log of stock changes of the seller's items, newest first: sales, cancellations, manual adjustments and imports with who made the change and why",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get inventory movements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "item_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "sale",
+                            "cancellation",
+                            "adjustment",
+                            "import"
+                        ],
+                        "type": "string",
+                        "description": "Movement type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the period, RFC 3339 or YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period (exclusive), RFC 3339 or YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 100 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Movements",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StockMovementOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get movements",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/item": {
+            "post": {
+                "description": "Create a new item in the system. By default the item is sent to moderation and appears in the catalog once approved; set status to \"draft\" to prepare it without submitting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Create a new item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Item data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ItemInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason for the initial stock, recorded in the inventory movements",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ItemID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create item",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft-delete an item: it disappears from the catalog, the seller's items and buyers' carts, but stays in the order history. An item reserved by unpaid orders cannot be deleted until they are paid or cancelled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Delete an item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Item is reserved by unpaid orders",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete item",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update an item by its ID. Changing the name, description, article, category, brand or material of a published item is held for moderation: the approved version stays in the catalog until an admin approves the changes. Unpublishing the item withdraws the pending changes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Update an existing item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Item data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ItemInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reason for the stock change, recorded in the inventory movements",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update item",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/item/archive": {
+            "patch": {
+                "description": "Archive an item that is no longer sold. Archived items are hidden from the catalog but stay in the order history and can be published again.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Archive an item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item archived successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item status",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/item/image": {
+            "get": {
+                "description": "Get images of the seller's item: the primary image first, then by position",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Get item images",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "item_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item images",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ImageInfo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get images",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Upload an image for a specific item by ID. Accepts JPEG, PNG or WebP up to 10 MB and 8000x8000 px; metadata is stripped and thumbnail/medium/large renditions are generated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Upload an image for an item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "item_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Uploaded image with renditions",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImageInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID, unsupported or too large image",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to save image",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete the image and all of its renditions from storage. If the primary image is deleted, the next one becomes primary.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Delete an item image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "item_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image ID",
+                        "name": "image_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item or image ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item or image not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete image",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/item/image/batch": {
+            "post": {
+                "description": "Upload up to 10 images in one multipart request under the \"images\" field. All files are validated first; if any of them is rejected or saving fails partway, nothing is saved.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Upload several images for an item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "item_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image files",
+                        "name": "images",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Uploaded images with renditions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ImageInfo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID, unsupported or too large image",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to save images",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/item/image/order": {
+            "put": {
+                "description": "Set the display order of item images. The list must contain every image of the item exactly once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Reorder item images",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Item ID and image IDs in the new order",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ImageOrderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Images reordered",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reorder images",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/item/image/primary": {
+            "patch": {
+                "description": "Mark the image as the main photo of the item; it is returned first in listings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Set the primary image of an item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "item_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image ID",
+                        "name": "image_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Primary image updated",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item or image ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item or image not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update primary image",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/item/publish": {
+            "patch": {
+                "description": "Submit a draft or archived item to moderation; it appears in the catalog once an admin approves it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Publish an item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item published successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item status",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/item/unpublish": {
+            "patch": {
+                "description": "Hide an item from the catalog and move it back to drafts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Unpublish an item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item unpublished successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item status",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/moderation": {
+            "get": {
+                "description": "Retrieve moderation requests of the current seller with decisions and reject reasons, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Get seller moderation requests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "item_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Moderation requests",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ModerationRequestOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get moderation requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/notifications": {
+            "get": {
+                "description": "Retrieve notifications of the current seller, newest first, e.g. low-stock alerts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get seller notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notifications",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.NotificationOutput"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get notifications",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/notifications/read": {
+            "patch": {
+                "description": "Mark the given notifications of the current seller as read, or all of them when ids are omitted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark seller notifications as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Notification IDs",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.NotificationReadInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notifications marked as read",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update notifications",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/product": {
+            "post": {
+                "description": "Create a product card that groups item variants by axes (colour, size, package volume). Variants are created via POST /seller/item with product_id and variant_values.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create a product card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Product data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ProductID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/statistic": {
+            "get": {
+                "description": "Retrieve seller earnings for the current and previous week",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sellers"
+                ],
+                "summary": "Get seller earnings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Earnings for current and last week",
+                        "schema": {
+                            "$ref": "#/definitions/model.Statistic"
+                        }
+                    },
+                    "403": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve earnings",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/warehouse": {
+            "get": {
+                "description": "Retrieve all warehouses of the current seller",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Get seller warehouses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Warehouses",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.WarehouseOutput"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get warehouses",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update name, address, coordinates and pickup option of a seller warehouse",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Update a warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Warehouse data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WarehouseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Warehouse updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Warehouse not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a seller warehouse (yard) with address, coordinates and pickup option",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Create a warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Warehouse data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WarehouseInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "WarehouseID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a seller warehouse together with its stock; item quantities are recalculated from the remaining warehouses",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Delete a warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Warehouse ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Warehouse deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid warehouse ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Warehouse not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete warehouse",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/warehouse/stock": {
+            "put": {
+                "description": "Set the quantity of an item at one of the seller's warehouses. Once an item has per-warehouse stock, its quantity is the sum over warehouses.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Set item stock at a warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {JWT}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Item, warehouse and quantity",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WarehouseStockInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "You are not authorized to access this resource",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item or warehouse not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update stock",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sign-in/admin": {
+            "post": {
+                "description": "Logs in an admin user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Sign in as an admin",
+                "parameters": [
+                    {
+                        "description": "Admin login data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AdminLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Authentication token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid login data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sign-in/buyer": {
+            "post": {
+                "description": "Authenticate a buyer with email and password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Buyers"
+                ],
+                "summary": "Buyer login",
+                "parameters": [
+                    {
+                        "description": "Login data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Authenticated buyer data",
+                        "schema": {
+                            "$ref": "#/definitions/model.BuyerOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid login data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sign-in/seller": {
+            "post": {
+                "description": "Authenticate a seller with their email and password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sellers"
+                ],
+                "summary": "Seller sign in",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SellerSignInResponse"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Seller data",
+                        "schema": {
+                            "$ref": "#/definitions/model.Seller"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sign-up/buyer": {
+            "post": {
+                "description": "Register a new buyer in the system",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Buyers"
+                ],
+                "summary": "Create a new buyer",
+                "parameters": [
+                    {
+                        "description": "Buyer data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BuyerInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Buyer signed up successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to sign up",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sign-up/seller": {
+            "post": {
+                "description": "Register a new seller with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sellers"
+                ],
+                "summary": "Register a new seller",
+                "parameters": [
+                    {
+                        "description": "Seller data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SellerInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Seller registered successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to register seller",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sitemap.xml": {
+            "get": {
+                "description": "Sitemap with the home page, categories and published items. When there are more than 50000 URLs, the root file is a sitemap index that links to the parts by page.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "SEO"
+                ],
+                "summary": "Get sitemap.xml",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sitemap part, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sitemap",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid page",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Sitemap page not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to generate sitemap",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-subscription": {
+            "post": {
+                "description": "Subscribe an email address to an out-of-stock item without signing in. A confirmation with an unsubscribe link is sent right away, and an email is sent when the item is restocked. Repeating a subscription that is still waiting for the restock sends nothing. Subscriptions per client IP and confirmations per email address are rate limited.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock subscriptions"
+                ],
+                "summary": "Subscribe to back-in-stock email",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "item_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Email address",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StockSubscriptionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subscribed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID or email",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Item is in stock",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to subscribe",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock-subscription/unsubscribe": {
+            "get": {
+                "description": "Retrieve the email address and item of a back-in-stock subscription using the token from the unsubscribe link in an email. The subscription is not changed: mail scanners open links, so unsubscribing requires a POST to the same path.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock subscriptions"
+                ],
+                "summary": "Get subscription by email link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unsubscribe token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subscription",
+                        "schema": {
+                            "$ref": "#/definitions/model.GuestSubscriptionOutput"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get subscription",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Cancel a back-in-stock subscription using the token from the unsubscribe link in an email",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Stock subscriptions"
+                ],
+                "summary": "Unsubscribe by email link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unsubscribe token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unsubscribed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to unsubscribe",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handler.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.NotificationReadInput": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "handler.OrderCreatedResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.SuccessResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AddBundleToCartInput": {
+            "type": "object",
+            "properties": {
+                "bundle_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AddToCartInput": {
+            "type": "object",
+            "properties": {
+                "item_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AdminLoginRequest": {
+            "type": "object",
+            "properties": {
+                "login": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AttributeFilter": {
+            "type": "object",
+            "properties": {
+                "attribute_id": {
+                    "type": "integer"
+                },
+                "bool": {
+                    "type": "boolean"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.AttributeInput": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "number",
+                        "enum",
+                        "boolean"
+                    ]
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AttributeOutput": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Brand": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Item"
+                    }
+                },
+                "meta_description": {
+                    "type": "string"
+                },
+                "meta_title": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BrandInput": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "meta_description": {
+                    "type": "string"
+                },
+                "meta_title": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BrandOutput": {
+            "type": "object",
+            "properties": {
+                "canonical_url": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "logo_url": {
+                    "type": "string"
+                },
+                "meta_description": {
+                    "type": "string"
+                },
+                "meta_title": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BundleComponentInfo": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BundleComponentInput": {
+            "type": "object",
+            "properties": {
+                "item_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.BundleInfo": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BundleComponentInfo"
+                    }
+                },
+                "components_price": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "savings": {
+                    "type": "number"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BundleInput": {
+            "type": "object",
+            "required": [
+                "category_id",
+                "components",
+                "name",
+                "price"
+            ],
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BundleComponentInput"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "published",
+                        "archived"
+                    ]
+                }
+            }
+        },
+        "model.Buyer": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "favorites": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Item"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Order"
+                    }
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BuyerInput": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BuyerOutput": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "favorites": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ItemInfo"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrderOutput"
+                    }
+                }
+            }
+        },
+        "model.CartBundleInfo": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.CartItemInfo": {
+            "type": "object",
+            "properties": {
+                "base_quantity": {
+                    "type": "number"
+                },
+                "base_unit": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CartOutput": {
+            "type": "object",
+            "properties": {
+                "bundles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CartBundleInfo"
+                    }
+                },
+                "buyer_id": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CartItemInfo"
+                    }
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Category": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Item"
+                    }
+                },
+                "meta_description": {
+                    "type": "string"
+                },
+                "meta_title": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CategoryInput": {
+            "type": "object",
+            "properties": {
+                "meta_description": {
+                    "type": "string"
+                },
+                "meta_title": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CategoryOutput": {
+            "type": "object",
+            "properties": {
+                "canonical_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "meta_description": {
+                    "type": "string"
+                },
+                "meta_title": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CheckoutInput": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "pickup": {
+                    "type": "boolean"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ComparisonCategory": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ItemInfo"
+                    }
+                }
+            }
+        },
+        "model.ComparisonRow": {
+            "type": "object",
+            "properties": {
+                "differs": {
+                    "type": "boolean"
+                },
+                "group": {
+                    "type": "string",
+                    "enum": [
+                        "main",
+                        "dimensions",
+                        "attributes"
+                    ]
+                },
+                "key": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ComparisonTable": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ItemInfo"
+                    }
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ComparisonRow"
+                    }
+                }
+            }
+        },
+        "model.CurrentItemInfo": {
+            "type": "object",
+            "properties": {
+                "article": {
+                    "type": "string"
+                },
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ItemAttributeInfo"
+                    }
+                },
+                "available": {
+                    "type": "integer"
+                },
+                "base_unit": {
+                    "type": "string"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "canonical_url": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImageInfo"
+                    }
+                },
+                "length": {
+                    "type": "integer"
+                },
+                "material": {
+                    "type": "string"
+                },
+                "meta_description": {
+                    "type": "string"
+                },
+                "meta_title": {
+                    "type": "string"
+                },
+                "min_quantity": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pickup_available": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "price_per_base_unit": {
+                    "description": "Цены за базовую единицу (например, за м² при продаже упаковками)",
+                    "type": "number"
+                },
+                "price_tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PriceTierInfo"
+                    }
+                },
+                "price_with_discount": {
+                    "type": "number"
+                },
+                "price_with_discount_per_base_unit": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "quantity_step": {
+                    "type": "integer"
+                },
+                "seller": {
+                    "type": "string"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "unit_ratio": {
+                    "type": "number"
+                },
+                "variants": {
+                    "$ref": "#/definitions/model.VariantMatrix"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "warehouses": {
+                    "description": "Наличие по складам продавца и возможность самовывоза хотя бы с одного из них",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WarehouseAvailability"
+                    }
+                },
+                "weight": {
+                    "type": "integer"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.FilterRequest": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AttributeFilter"
+                    }
+                },
+                "brands": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "materials": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "max_price": {
+                    "type": "number"
+                },
+                "min_price": {
+                    "type": "number"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "sellers": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "sort": {
+                    "type": "string",
+                    "enum": [
+                        "popular",
+                        "price_asc",
+                        "price_desc",
+                        "newest"
+                    ]
+                }
+            }
+        },
+        "model.GuestSubscriptionOutput": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "item": {
+                    "$ref": "#/definitions/model.ItemInfo"
+                }
+            }
+        },
+        "model.Image": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "item": {
+                    "$ref": "#/definitions/model.Item"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "renditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImageRendition"
+                    }
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ImageInfo": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "renditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImageRenditionInfo"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ImageOrderInput": {
+            "type": "object",
+            "properties": {
+                "image_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "item_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ImageRendition": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ImageRenditionInfo": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ImportJobOutput": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_count": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed_count": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "processed_rows": {
+                    "type": "integer"
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "processing",
+                        "completed",
+                        "failed"
+                    ]
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "updated_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.InventoryUpdate": {
+            "type": "object",
+            "properties": {
+                "article": {
+                    "type": "string"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "price_with_discount": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "Причина изменения остатка для журнала движений",
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.InventoryUpdateResult": {
+            "type": "object",
+            "properties": {
+                "article": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "updated",
+                        "not_found",
+                        "conflict",
+                        "invalid",
+                        "error"
+                    ]
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Item": {
+            "type": "object",
+            "properties": {
+                "article": {
+                    "type": "string"
+                },
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ItemAttribute"
+                    }
+                },
+                "base_unit": {
+                    "type": "string"
+                },
+                "brand": {
+                    "$ref": "#/definitions/model.Brand"
+                },
+                "brand_id": {
+                    "type": "integer"
+                },
+                "category": {
+                    "$ref": "#/definitions/model.Category"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "favoritedBy": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Buyer"
+                    }
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Image"
+                    }
+                },
+                "length": {
+                    "type": "integer"
+                },
+                "low_stock_threshold": {
+                    "type": "integer"
+                },
+                "material": {
+                    "$ref": "#/definitions/model.Material"
+                },
+                "material_id": {
+                    "type": "integer"
+                },
+                "meta_description": {
+                    "type": "string"
+                },
+                "meta_title": {
+                    "type": "string"
+                },
+                "min_quantity": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "price_tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PriceTier"
+                    }
+                },
+                "price_with_discount": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "quantity_step": {
+                    "type": "integer"
+                },
+                "seller": {
+                    "$ref": "#/definitions/model.Seller"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "unit_ratio": {
+                    "type": "number"
+                },
+                "variant_values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.VariantValue"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "integer"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ItemAttribute": {
+            "type": "object",
+            "properties": {
+                "attribute_id": {
+                    "type": "integer"
+                },
+                "bool_value": {
+                    "type": "boolean"
+                },
+                "enum_value": {
+                    "type": "string"
+                },
+                "number_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.ItemAttributeInfo": {
+            "type": "object",
+            "properties": {
+                "attribute_id": {
+                    "type": "integer"
+                },
+                "bool_value": {
+                    "type": "boolean"
+                },
+                "enum_value": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "number_value": {
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ItemAttributeInput": {
+            "type": "object",
+            "properties": {
+                "attribute_id": {
+                    "type": "integer"
+                },
+                "bool_value": {
+                    "type": "boolean"
+                },
+                "enum_value": {
+                    "type": "string"
+                },
+                "number_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.ItemInfo": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "base_unit": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "price_per_base_unit": {
+                    "type": "number"
+                },
+                "price_with_discount": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
         },
-        "model.AddToCartInput": {
+        "model.ItemInput": {
             "type": "object",
             "properties": {
-                "item_id": {
+                "article": {
+                    "type": "string"
+                },
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ItemAttributeInput"
+                    }
+                },
+                "base_unit": {
+                    "type": "string"
+                },
+                "brand_id": {
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "length": {
+                    "type": "integer"
+                },
+                "low_stock_threshold": {
+                    "type": "integer"
+                },
+                "material_id": {
+                    "type": "integer"
+                },
+                "meta_description": {
+                    "type": "string"
+                },
+                "meta_title": {
+                    "type": "string"
+                },
+                "min_quantity": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "price_tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PriceTierInput"
+                    }
+                },
+                "price_with_discount": {
+                    "type": "number"
+                },
+                "product_id": {
                     "type": "integer"
                 },
                 "quantity": {
                     "type": "integer"
+                },
+                "quantity_step": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "published"
+                    ]
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "unit_ratio": {
+                    "type": "number"
+                },
+                "variant_values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.VariantValueInput"
+                    }
+                },
+                "weight": {
+                    "type": "integer"
+                },
+                "width": {
+                    "type": "integer"
                 }
             }
         },
-        "model.AdminLoginRequest": {
+        "model.LoginRequest": {
             "type": "object",
             "properties": {
-                "login": {
+                "email": {
                     "type": "string"
                 },
                 "password": {
@@ -1781,7 +7705,18 @@ const docTemplate = `{
                 }
             }
         },
-        "model.Brand": {
+        "model.LowStockThresholdInput": {
+            "type": "object",
+            "properties": {
+                "item_id": {
+                    "type": "integer"
+                },
+                "threshold": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Material": {
             "type": "object",
             "properties": {
                 "id": {
@@ -1798,7 +7733,7 @@ const docTemplate = `{
                 }
             }
         },
-        "model.BrandInput": {
+        "model.MaterialInput": {
             "type": "object",
             "properties": {
                 "name": {
@@ -1806,7 +7741,7 @@ const docTemplate = `{
                 }
             }
         },
-        "model.BrandOutput": {
+        "model.MaterialOutput": {
             "type": "object",
             "properties": {
                 "id": {
@@ -1817,221 +7752,271 @@ const docTemplate = `{
                 }
             }
         },
-        "model.Buyer": {
+        "model.MergeInput": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "favorites": {
+                "source_ids": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Item"
+                        "type": "integer"
                     }
                 },
-                "id": {
+                "target_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ModerationChange": {
+            "type": "object",
+            "properties": {
+                "field": {
                     "type": "string"
                 },
-                "name": {
+                "new": {
                     "type": "string"
                 },
-                "orders": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Order"
-                    }
-                },
-                "password": {
+                "old": {
                     "type": "string"
                 }
             }
         },
-        "model.BuyerInput": {
+        "model.ModerationRejectInput": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "password": {
+                "reason": {
                     "type": "string"
                 }
             }
         },
-        "model.BuyerOutput": {
+        "model.ModerationRequestOutput": {
             "type": "object",
             "properties": {
-                "email": {
+                "bundle_id": {
+                    "type": "integer"
+                },
+                "bundle_name": {
                     "type": "string"
                 },
-                "favorites": {
+                "changes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.ItemInfo"
+                        "$ref": "#/definitions/model.ModerationChange"
                     }
                 },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
+                "created_at": {
                     "type": "string"
                 },
-                "orders": {
+                "flags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.OrderOutput"
+                        "type": "string"
                     }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "item_name": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "model.CartItemInfo": {
+        "model.NotificationOutput": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "item_id": {
+                    "type": "integer"
+                },
+                "message": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "read": {
+                    "type": "boolean"
                 },
-                "quantity": {
-                    "type": "integer"
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "model.CartOutput": {
+        "model.Order": {
             "type": "object",
             "properties": {
+                "buyer": {
+                    "$ref": "#/definitions/model.Buyer"
+                },
                 "buyer_id": {
                     "type": "string"
                 },
-                "items": {
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.CartItemInfo"
+                        "$ref": "#/definitions/model.OrderItem"
                     }
+                },
+                "pickup_warehouse_id": {
+                    "description": "Склад самовывоза; пусто, если заказ доставляется",
+                    "type": "integer"
+                },
+                "reserved_until": {
+                    "description": "До этого момента товары заказа зарезервированы; неоплаченный заказ затем отменяется",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "number"
                 }
             }
         },
-        "model.Category": {
+        "model.OrderItem": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
-                },
-                "items": {
+                "allocations": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Item"
+                        "$ref": "#/definitions/model.OrderItemAllocation"
                     }
                 },
-                "name": {
+                "bundle_id": {
+                    "description": "Комплект, в составе которого куплен товар; цена позиции — её доля цены комплекта",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "item": {
+                    "$ref": "#/definitions/model.Item"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "order": {
+                    "$ref": "#/definitions/model.Order"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "seller_id": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "number"
+                },
+                "unit_price": {
+                    "type": "number"
                 }
             }
         },
-        "model.CategoryInput": {
+        "model.OrderItemAllocation": {
             "type": "object",
             "properties": {
-                "name": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "integer"
                 }
             }
         },
-        "model.CategoryOutput": {
+        "model.OrderItemInfo": {
             "type": "object",
             "properties": {
+                "bundle_id": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "number"
                 }
             }
         },
-        "model.CurrentItemInfo": {
+        "model.OrderOutput": {
             "type": "object",
             "properties": {
-                "article": {
-                    "type": "string"
-                },
-                "brand": {
-                    "type": "string"
-                },
-                "category": {
+                "buyer_id": {
                     "type": "string"
                 },
-                "description": {
+                "currency": {
                     "type": "string"
                 },
-                "height": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "images": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/model.OrderItemInfo"
                     }
                 },
-                "length": {
+                "pickup_warehouse_id": {
                     "type": "integer"
                 },
-                "material": {
+                "reserved_until": {
                     "type": "string"
                 },
-                "name": {
+                "status": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
-                },
-                "price_with_discount": {
+                "total": {
                     "type": "number"
-                },
-                "quantity": {
-                    "type": "integer"
-                },
-                "seller": {
-                    "type": "string"
-                },
-                "seller_id": {
-                    "type": "string"
-                },
-                "weight": {
-                    "type": "integer"
-                },
-                "width": {
-                    "type": "integer"
                 }
             }
         },
-        "model.FilterRequest": {
+        "model.PriceHistoryOutput": {
             "type": "object",
             "properties": {
-                "brands": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "categories": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "currency": {
+                    "type": "string"
                 },
-                "materials": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "item_id": {
+                    "type": "integer"
                 },
                 "max_price": {
                     "type": "number"
@@ -2039,437 +8024,502 @@ const docTemplate = `{
                 "min_price": {
                     "type": "number"
                 },
-                "query": {
-                    "type": "string"
-                },
-                "sellers": {
+                "points": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/model.PricePoint"
                     }
                 }
             }
         },
-        "model.Image": {
+        "model.PricePoint": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
-                },
-                "item": {
-                    "$ref": "#/definitions/model.Item"
+                "date": {
+                    "type": "string"
                 },
-                "item_id": {
-                    "type": "integer"
+                "price": {
+                    "type": "number"
                 },
-                "url": {
-                    "type": "string"
+                "price_with_discount": {
+                    "type": "number"
                 }
             }
         },
-        "model.Item": {
+        "model.PriceTier": {
             "type": "object",
             "properties": {
-                "article": {
-                    "type": "string"
-                },
-                "brand": {
-                    "$ref": "#/definitions/model.Brand"
-                },
-                "brand_id": {
-                    "type": "integer"
-                },
-                "category": {
-                    "$ref": "#/definitions/model.Category"
-                },
-                "category_id": {
+                "min_quantity": {
                     "type": "integer"
                 },
-                "description": {
-                    "type": "string"
-                },
-                "favoritedBy": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Buyer"
-                    }
-                },
-                "height": {
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.PriceTierInfo": {
+            "type": "object",
+            "properties": {
+                "min_quantity": {
                     "type": "integer"
                 },
-                "id": {
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.PriceTierInput": {
+            "type": "object",
+            "properties": {
+                "min_quantity": {
                     "type": "integer"
                 },
-                "images": {
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.ProductInput": {
+            "type": "object",
+            "properties": {
+                "axes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Image"
+                        "type": "string"
                     }
                 },
-                "length": {
-                    "type": "integer"
-                },
-                "material": {
-                    "$ref": "#/definitions/model.Material"
-                },
-                "material_id": {
-                    "type": "integer"
+                "description": {
+                    "type": "string"
                 },
                 "name": {
                     "type": "string"
+                }
+            }
+        },
+        "model.RecentlyViewedOutput": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "$ref": "#/definitions/model.ItemInfo"
                 },
-                "price": {
-                    "type": "number"
-                },
-                "price_with_discount": {
-                    "type": "number"
-                },
-                "quantity": {
-                    "type": "integer"
-                },
-                "seller": {
-                    "$ref": "#/definitions/model.Seller"
-                },
-                "seller_id": {
+                "viewed_at": {
                     "type": "string"
-                },
-                "weight": {
-                    "type": "integer"
-                },
-                "width": {
-                    "type": "integer"
                 }
             }
         },
-        "model.ItemInfo": {
+        "model.RecommendationsOutput": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "images": {
+                "bought_together": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/model.ItemInfo"
                     }
                 },
-                "name": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "number"
+                "complete_project": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ItemInfo"
+                    }
                 },
-                "price_with_discount": {
-                    "type": "number"
+                "similar": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ItemInfo"
+                    }
                 }
             }
         },
-        "model.ItemInput": {
+        "model.Review": {
             "type": "object",
             "properties": {
-                "article": {
+                "buyer_id": {
                     "type": "string"
                 },
-                "brand_id": {
-                    "type": "integer"
-                },
-                "category_id": {
-                    "type": "integer"
+                "comment": {
+                    "type": "string"
                 },
-                "description": {
+                "created_at": {
                     "type": "string"
                 },
-                "height": {
+                "id": {
                     "type": "integer"
                 },
-                "length": {
+                "item_id": {
                     "type": "integer"
                 },
-                "material_id": {
-                    "type": "integer"
+                "rating": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Seller": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
                 },
-                "name": {
+                "email": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "id": {
+                    "type": "string"
                 },
-                "price_with_discount": {
-                    "type": "number"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Item"
+                    }
                 },
-                "quantity": {
+                "low_stock_threshold": {
+                    "description": "Порог низкого остатка по умолчанию для товаров без собственного порога; 0 — уведомления отключены",
                     "type": "integer"
                 },
-                "weight": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "width": {
-                    "type": "integer"
+                "password": {
+                    "type": "string"
+                },
+                "shop_name": {
+                    "type": "string"
                 }
             }
         },
-        "model.LoginRequest": {
+        "model.SellerInput": {
             "type": "object",
             "properties": {
                 "email": {
                     "type": "string"
                 },
+                "name": {
+                    "type": "string"
+                },
                 "password": {
                     "type": "string"
+                },
+                "shop_name": {
+                    "type": "string"
                 }
             }
         },
-        "model.Material": {
+        "model.SellerOutput": {
             "type": "object",
             "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
                 "id": {
-                    "type": "integer"
+                    "type": "string"
                 },
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Item"
+                        "$ref": "#/definitions/model.ItemInfo"
                     }
                 },
                 "name": {
                     "type": "string"
+                },
+                "shop_name": {
+                    "type": "string"
                 }
             }
         },
-        "model.MaterialInput": {
+        "model.SellerSignInResponse": {
             "type": "object",
             "properties": {
-                "name": {
+                "seller": {
+                    "$ref": "#/definitions/model.SellerOutput"
+                },
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "model.MaterialOutput": {
+        "model.SlugRedirectOutput": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
+                "canonical_url": {
+                    "type": "string"
                 },
-                "name": {
+                "slug": {
                     "type": "string"
                 }
             }
         },
-        "model.Order": {
+        "model.Statistic": {
             "type": "object",
             "properties": {
-                "buyer": {
-                    "$ref": "#/definitions/model.Buyer"
-                },
-                "buyer_id": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "order_items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.OrderItem"
-                    }
-                },
-                "status": {
-                    "type": "string"
+                "current_week": {
+                    "type": "number"
                 },
-                "total": {
+                "last_week": {
                     "type": "number"
                 }
             }
         },
-        "model.OrderItem": {
+        "model.StockMovementOutput": {
             "type": "object",
             "properties": {
+                "actor_id": {
+                    "type": "string"
+                },
+                "article": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
+                "delta": {
                     "type": "integer"
                 },
-                "item": {
-                    "$ref": "#/definitions/model.Item"
+                "id": {
+                    "type": "integer"
                 },
                 "item_id": {
                     "type": "integer"
                 },
-                "order": {
-                    "$ref": "#/definitions/model.Order"
+                "item_name": {
+                    "type": "string"
                 },
                 "order_id": {
                     "type": "integer"
                 },
-                "quantity": {
+                "quantity_after": {
                     "type": "integer"
                 },
-                "seller_id": {
+                "reason": {
                     "type": "string"
                 },
-                "total": {
-                    "type": "number"
+                "type": {
+                    "type": "string"
                 },
-                "unit_price": {
-                    "type": "number"
+                "warehouse_id": {
+                    "type": "integer"
                 }
             }
         },
-        "model.OrderItemInfo": {
+        "model.StockSubscriptionInput": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StockSubscriptionOutput": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "in_stock": {
+                    "type": "boolean"
                 },
-                "price": {
-                    "type": "number"
+                "item": {
+                    "$ref": "#/definitions/model.ItemInfo"
                 },
-                "quantity": {
-                    "type": "integer"
+                "notified_at": {
+                    "type": "string"
                 }
             }
         },
-        "model.OrderOutput": {
+        "model.VariantAxisInfo": {
             "type": "object",
             "properties": {
-                "buyer_id": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "items": {
+                "values": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.OrderItemInfo"
+                        "type": "string"
                     }
-                },
-                "status": {
-                    "type": "string"
-                },
-                "total": {
-                    "type": "number"
                 }
             }
         },
-        "model.Review": {
+        "model.VariantInfo": {
             "type": "object",
             "properties": {
-                "buyer_id": {
-                    "type": "string"
-                },
-                "comment": {
-                    "type": "string"
-                },
-                "created_at": {
+                "article": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "item_id": {
                     "type": "integer"
                 },
-                "rating": {
+                "price": {
+                    "type": "number"
+                },
+                "price_with_discount": {
                     "type": "number"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "values": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "model.Seller": {
+        "model.VariantMatrix": {
             "type": "object",
             "properties": {
-                "balance": {
-                    "type": "number"
+                "axes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.VariantAxisInfo"
+                    }
                 },
-                "email": {
+                "description": {
                     "type": "string"
                 },
-                "id": {
+                "name": {
                     "type": "string"
                 },
-                "items": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "variants": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Item"
+                        "$ref": "#/definitions/model.VariantInfo"
                     }
+                }
+            }
+        },
+        "model.VariantValue": {
+            "type": "object",
+            "properties": {
+                "axis_id": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string"
-                },
-                "password": {
+                "value": {
                     "type": "string"
+                }
+            }
+        },
+        "model.VariantValueInput": {
+            "type": "object",
+            "properties": {
+                "axis_id": {
+                    "type": "integer"
                 },
-                "shop_name": {
+                "value": {
                     "type": "string"
                 }
             }
         },
-        "model.SellerInput": {
+        "model.WarehouseAvailability": {
             "type": "object",
             "properties": {
-                "email": {
+                "address": {
                     "type": "string"
                 },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
                 "name": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string"
+                "pickup_available": {
+                    "type": "boolean"
                 },
-                "shop_name": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "integer"
                 }
             }
         },
-        "model.SellerOutput": {
+        "model.WarehouseInput": {
             "type": "object",
             "properties": {
-                "balance": {
-                    "type": "number"
-                },
-                "email": {
+                "address": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "latitude": {
+                    "type": "number"
                 },
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.ItemInfo"
-                    }
+                "longitude": {
+                    "type": "number"
                 },
                 "name": {
                     "type": "string"
                 },
-                "shop_name": {
-                    "type": "string"
+                "pickup_available": {
+                    "type": "boolean"
                 }
             }
         },
-        "model.SellerSignInResponse": {
+        "model.WarehouseOutput": {
             "type": "object",
             "properties": {
-                "seller": {
-                    "$ref": "#/definitions/model.SellerOutput"
+                "address": {
+                    "type": "string"
                 },
-                "token": {
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
                     "type": "string"
+                },
+                "pickup_available": {
+                    "type": "boolean"
                 }
             }
         },
-        "model.Statistic": {
+        "model.WarehouseStockInput": {
             "type": "object",
             "properties": {
-                "current_week": {
-                    "type": "number"
+                "item_id": {
+                    "type": "integer"
                 },
-                "last_week": {
-                    "type": "number"
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "type": "integer"
                 }
             }
         }
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"stroycity/pkg/model"
+)
+
+// CreateAttribute создаёт характеристику товаров категории
+// @Summary Create a new category attribute
+// @Description Create a typed attribute (number with unit, enum or boolean) for items of a category. Only accessible by admin.
+// @Tags Attributes
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param input body model.AttributeInput true "Attribute data"
+// @Success 201 {string} string "AttributeID"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to create attribute"
+// @Router /admin/attribute [post]
+func (h *Handler) CreateAttribute(c *gin.Context) {
+	// Проверка роли пользователя
+	if role := c.GetString("role"); role != "admin" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	var input model.AttributeInput
+	// Валидация JSON данных
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	// Создание характеристики
+	attributeID, err := h.services.CreateAttribute(input)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Failed to create attribute: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	c.JSON(http.StatusCreated, strconv.Itoa(attributeID)) // 201 Created
+}
+
+// DeleteAttribute удаляет характеристику вместе со значениями у товаров
+// @Summary Delete a category attribute
+// @Description Delete an attribute by ID together with item values. Only accessible by admin.
+// @Tags Attributes
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param attribute_id query int true "Attribute ID"
+// @Success 200 {string} string "Attribute deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid attribute ID"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to delete attribute"
+// @Router /admin/attribute [delete]
+func (h *Handler) DeleteAttribute(c *gin.Context) {
+	// Проверка роли пользователя
+	if role := c.GetString("role"); role != "admin" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	attributeIdStr := c.Query("attribute_id")
+	attributeId, err := strconv.Atoi(attributeIdStr)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid attribute ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	// Удаление характеристики
+	if err := h.services.DeleteAttribute(attributeId); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to delete attribute: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, "Attribute deleted successfully") // 200 OK
+}
+
+// GetAttributeList возвращает характеристики категории
+// @Summary Get category attributes
+// @Description Retrieve attributes defined for a category, with options for enum attributes
+// @Tags Attributes
+// @Produce json
+// @Param category_id query int true "Category ID"
+// @Success 200 {array} model.AttributeOutput "List of attributes"
+// @Failure 400 {object} ErrorResponse "Invalid category ID"
+// @Failure 500 {object} ErrorResponse "Failed to get attribute list"
+// @Router /attribute [get]
+func (h *Handler) GetAttributeList(c *gin.Context) {
+	categoryIdStr := c.Query("category_id")
+	categoryId, err := strconv.Atoi(categoryIdStr)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid category ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	// Получение списка характеристик
+	attributes, err := h.services.GetAttributesByCategoryID(categoryId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get attribute list: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, attributes) // 200 OK
+}
@@ -43,16 +43,107 @@ func (h *Handler) CreateBrand(c *gin.Context) {
 	c.JSON(http.StatusCreated, "Brand created successfully") // 201 Created
 }
 
+// UpdateBrand
+// @Summary      Update a brand
+// @Description  Renames a brand and updates its description, meta title and meta description. Renaming generates a new slug; the old one redirects to it. Only accessible by admin.
+// @Tags         brands
+// @Accept       json
+// @Produce      json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param        brand_id  query  int  true  "Brand ID"
+// @Param        input  body  model.BrandInput  true  "Brand data"
+// @Success      200  {string}  string  "Brand updated successfully"
+// @Failure      400  {string}  string  "Invalid input data"
+// @Failure      401  {string}  string  "You are not authorized to access this resource"
+// @Failure      404  {string}  string  "Brand not found"
+// @Failure      500  {string}  string  "Failed to update brand"
+// @Router       /admin/brand [put]
+func (h *Handler) UpdateBrand(c *gin.Context) {
+	// Проверка роли пользователя
+	if role := c.GetString("role"); role != "admin" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	brandId, err := strconv.Atoi(c.Query("brand_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid brand ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	var input model.BrandInput
+	// Валидация JSON данных
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	// Изменение бренда
+	if err := h.services.UpdateBrand(brandId, input); err != nil {
+		dictionaryErrorResponse(c, err, "Failed to update brand: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, "Brand updated successfully") // 200 OK
+}
+
+// UploadBrandLogo
+// @Summary      Upload a brand logo
+// @Description  Replaces the brand logo with the image from the "logo" multipart field (JPEG, PNG or WebP). Only accessible by admin.
+// @Tags         brands
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param        brand_id  query  int  true  "Brand ID"
+// @Param        logo  formData  file  true  "Logo image"
+// @Success      200  {string}  string  "Logo URL"
+// @Failure      400  {string}  string  "Invalid brand ID, unsupported or too large image"
+// @Failure      401  {string}  string  "You are not authorized to access this resource"
+// @Failure      404  {string}  string  "Brand not found"
+// @Failure      500  {string}  string  "Failed to upload logo"
+// @Router       /admin/brand/logo [post]
+func (h *Handler) UploadBrandLogo(c *gin.Context) {
+	// Проверка роли пользователя
+	if role := c.GetString("role"); role != "admin" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	brandId, err := strconv.Atoi(c.Query("brand_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid brand ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	fileHeader, err := c.FormFile("logo")
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Failed to upload logo: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	// Сохранение логотипа
+	url, err := h.services.UploadBrandLogo(brandId, fileHeader)
+	if err != nil {
+		dictionaryErrorResponse(c, err, "Failed to upload logo: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, url) // 200 OK
+}
+
 // DeleteBrand
 // @Summary      Delete a brand
-// @Description  Deletes a brand by ID. Only accessible by admin.
+// @Description  Deletes a brand by ID. A brand with items is deleted only with reassign_to: its items are moved to that brand first. Only accessible by admin.
 // @Tags         brands
 // @Produce      json
 // @Param Authorization header string true "Bearer {JWT}"
 // @Param        brand_id  query  int  true  "Brand ID"
+// @Param        reassign_to  query  int  false  "Brand ID to move the items to"
 // @Success      200  {string}  string  "Brand deleted successfully"
 // @Failure      400  {string}  string  "Invalid brand ID"
 // @Failure      401  {string}  string  "You are not authorized to access this resource"
+// @Failure      404  {string}  string  "Brand not found"
+// @Failure      409  {string}  string  "Brand has items"
 // @Failure      500  {string}  string  "Failed to delete brand"
 // @Router       /admin/brand [delete]
 func (h *Handler) DeleteBrand(c *gin.Context) {
@@ -68,16 +159,57 @@ func (h *Handler) DeleteBrand(c *gin.Context) {
 		newErrorResponse(c, http.StatusBadRequest, "Invalid brand ID: "+err.Error()) // 400 Bad Request
 		return
 	}
+	reassignTo, ok := reassignToQuery(c)
+	if !ok {
+		return
+	}
 
 	// Удаление бренда
-	if err := h.services.DeleteBrand(brandId); err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, "Failed to delete brand: "+err.Error()) // 500 Internal Server Error
+	if err := h.services.DeleteBrand(brandId, reassignTo); err != nil {
+		dictionaryErrorResponse(c, err, "Failed to delete brand: ")
 		return
 	}
 
 	c.JSON(http.StatusOK, "Brand deleted successfully") // 200 OK
 }
 
+// MergeBrands
+// @Summary      Merge duplicate brands
+// @Description  Moves all items of the source brands (e.g. "Knauf" and "КНАУФ") to the target brand and deletes the sources. A missing description or logo of the target is taken from the sources. Only accessible by admin.
+// @Tags         brands
+// @Accept       json
+// @Produce      json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param        input  body  model.MergeInput  true  "Target and duplicate brand IDs"
+// @Success      200  {string}  string  "Brands merged successfully"
+// @Failure      400  {string}  string  "Invalid input data"
+// @Failure      401  {string}  string  "You are not authorized to access this resource"
+// @Failure      404  {string}  string  "Brand not found"
+// @Failure      500  {string}  string  "Failed to merge brands"
+// @Router       /admin/brand/merge [post]
+func (h *Handler) MergeBrands(c *gin.Context) {
+	// Проверка роли пользователя
+	if role := c.GetString("role"); role != "admin" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	var input model.MergeInput
+	// Валидация JSON данных
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	// Объединение брендов
+	if err := h.services.MergeBrands(input); err != nil {
+		dictionaryErrorResponse(c, err, "Failed to merge brands: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, "Brands merged successfully") // 200 OK
+}
+
 // GetBrandList
 // @Summary      Get list of brands
 // @Description  Retrieves a list of all brands. Only accessible by admin.
@@ -97,3 +229,29 @@ func (h *Handler) GetBrandList(c *gin.Context) {
 
 	c.JSON(http.StatusOK, brands) // 200 OK
 }
+
+// GetBrandBySlug
+// @Summary      Get brand by slug
+// @Description  Retrieves a brand by its slug. A former slug of a renamed or merged brand responds with 301 and the current slug in the Location header and body.
+// @Tags         brands
+// @Produce      json
+// @Param        slug  query  string  true  "Brand slug"
+// @Success      200  {object}  model.BrandOutput  "Brand"
+// @Success      301  {object}  model.SlugRedirectOutput  "Current slug"
+// @Failure      404  {string}  string  "Brand not found"
+// @Failure      500  {string}  string  "Failed to get brand"
+// @Router       /brand/slug [get]
+func (h *Handler) GetBrandBySlug(c *gin.Context) {
+	slug := c.Query("slug")
+	brand, err := h.services.GetBrandBySlug(slug)
+	if err != nil {
+		dictionaryErrorResponse(c, err, "Failed to get brand: ")
+		return
+	}
+	if brand.Slug != slug {
+		redirectToSlug(c, brand.Slug, brand.CanonicalURL)
+		return
+	}
+
+	c.JSON(http.StatusOK, brand) // 200 OK
+}
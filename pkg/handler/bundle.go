@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"stroycity/pkg/model"
+	"stroycity/pkg/service"
+)
+
+// CreateBundle создаёт комплект из товаров продавца
+// @Summary Create a bundle
+// @Description Create a bundle (kit) of the seller's items sold at its own price. Stock is derived from the components. Component quantities must respect the items' minimum order quantity and step. A published bundle is sent to moderation and appears in the catalog once approved; set status to "draft" to prepare it without submitting.
+// @Tags Bundles
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param input body model.BundleInput true "Bundle data"
+// @Success 201 {string} string "BundleID"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Category not found"
+// @Router /seller/bundle [post]
+func (h *Handler) CreateBundle(c *gin.Context) {
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	var input model.BundleInput
+	// Валидация JSON данных
+	if err := c.ShouldBindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	bundleID, err := h.services.CreateBundle(sellerId, input)
+	if err != nil {
+		bundleErrorResponse(c, err, "Failed to create bundle: ")
+		return
+	}
+
+	c.JSON(http.StatusCreated, strconv.Itoa(bundleID)) // 201 Created
+}
+
+// GetSellerBundles возвращает комплекты продавца
+// @Summary Get seller bundles
+// @Description Retrieve all bundles of the current seller in any status
+// @Tags Bundles
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Success 200 {array} model.BundleInfo "Bundles"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to get bundles"
+// @Router /seller/bundle [get]
+func (h *Handler) GetSellerBundles(c *gin.Context) {
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	bundles, err := h.services.GetSellerBundles(sellerId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get bundles: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, bundles) // 200 OK
+}
+
+// UpdateBundle обновляет комплект продавца
+// @Summary Update a bundle
+// @Description Update name, price, status and components of a seller bundle. Price and components change right away; a new name, description or category of a published bundle is held for moderation while the approved version stays in the catalog. Moving a bundle to drafts or the archive withdraws its pending moderation request.
+// @Tags Bundles
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param id query string true "Bundle ID"
+// @Param input body model.BundleInput true "Bundle data"
+// @Success 200 {string} string "Bundle updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Bundle not found"
+// @Router /seller/bundle [put]
+func (h *Handler) UpdateBundle(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid bundle ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	var input model.BundleInput
+	// Валидация JSON данных
+	if err := c.ShouldBindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	if err := h.services.UpdateBundle(sellerId, id, input); err != nil {
+		bundleErrorResponse(c, err, "Failed to update bundle: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, "Bundle updated successfully") // 200 OK
+}
+
+// DeleteBundle удаляет комплект продавца
+// @Summary Delete a bundle
+// @Description Delete a seller bundle and remove it from buyers' carts. Component items are not affected.
+// @Tags Bundles
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param id query string true "Bundle ID"
+// @Success 200 {string} string "Bundle deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid bundle ID"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Bundle not found"
+// @Router /seller/bundle [delete]
+func (h *Handler) DeleteBundle(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid bundle ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	if err := h.services.DeleteBundle(sellerId, id); err != nil {
+		bundleErrorResponse(c, err, "Failed to delete bundle: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, "Bundle deleted successfully") // 200 OK
+}
+
+// GetBundleById возвращает опубликованный комплект
+// @Summary Get a bundle
+// @Description Get a published bundle with its components, savings and the number of bundles available from component stock
+// @Tags Bundles
+// @Produce json
+// @Param id query string true "Bundle ID"
+// @Success 200 {object} model.BundleInfo "Bundle"
+// @Failure 400 {object} ErrorResponse "Invalid bundle ID"
+// @Failure 404 {object} ErrorResponse "Bundle not found"
+// @Router /bundle [get]
+func (h *Handler) GetBundleById(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid bundle ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	bundle, err := h.services.GetBundleById(id)
+	if err != nil {
+		bundleErrorResponse(c, err, "Failed to get bundle: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, bundle) // 200 OK
+}
+
+// GetBundleList возвращает опубликованные комплекты для каталога
+// @Summary Get bundle list
+// @Description Get published bundles, optionally of a single category
+// @Tags Bundles
+// @Produce json
+// @Param category_id query string false "Category ID"
+// @Success 200 {array} model.BundleInfo "Bundles"
+// @Failure 400 {object} ErrorResponse "Invalid category ID"
+// @Failure 500 {object} ErrorResponse "Failed to get bundles"
+// @Router /bundle/list [get]
+func (h *Handler) GetBundleList(c *gin.Context) {
+	categoryID := 0
+	if value := c.Query("category_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "Invalid category ID: "+err.Error()) // 400 Bad Request
+			return
+		}
+		categoryID = id
+	}
+
+	bundles, err := h.services.GetBundles(categoryID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get bundles: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, bundles) // 200 OK
+}
+
+func bundleErrorResponse(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidBundle), errors.Is(err, service.ErrNameRequired):
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+	case errors.Is(err, service.ErrBundleNotFound), errors.Is(err, service.ErrCategoryNotFound):
+		newErrorResponse(c, http.StatusNotFound, err.Error()) // 404 Not Found
+	default:
+		newErrorResponse(c, http.StatusInternalServerError, message+err.Error()) // 500 Internal Server Error
+	}
+}
@@ -133,7 +133,7 @@ func (h *Handler) BuyerSignIn(c *gin.Context) {
 
 // AddToFavorites добавляет товар в избранное покупателя
 // @Summary Add item to favorites
-// @Description Add a specific item to the buyer's favorites
+// @Description Add a specific item to the buyer's favorites. The buyer is notified when the item gets cheaper or comes back in stock.
 // @Tags Favorites
 // @Accept json
 // @Produce json
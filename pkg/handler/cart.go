@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
+	"stroycity/pkg/model"
 )
 
 // AddToCart
@@ -110,3 +111,74 @@ func (h *Handler) RemoveFromCart(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Item removed from cart"}) // 200 OK
 }
+
+// AddBundleToCart
+// @Summary      Add a bundle to cart
+// @Description  Adds a bundle with a specified quantity to the buyer's cart; if the bundle is already there, its quantity is replaced. Only accessible by buyers.
+// @Tags         cart
+// @Accept       json
+// @Produce      json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param        input  body  model.AddBundleToCartInput  true  "Bundle ID and Quantity"
+// @Success      200  {string}  string  "Bundle added to cart"
+// @Failure      400  {string}  string  "Invalid input"
+// @Failure      403  {string}  string  "You are not authorized to access this resource"
+// @Failure      404  {string}  string  "Bundle not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /buyer/cart/bundle [post]
+func (h *Handler) AddBundleToCart(c *gin.Context) {
+	buyerID := c.GetString("user_id")
+
+	if role := c.GetString("role"); role != "buyer" {
+		newErrorResponse(c, http.StatusForbidden, "You are not authorized to access this resource") // 403 Forbidden
+		return
+	}
+
+	var input model.AddBundleToCartInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input") // 400 Bad Request
+		return
+	}
+
+	if err := h.services.AddBundleToCart(buyerID, input.BundleID, input.Quantity); err != nil {
+		bundleErrorResponse(c, err, "")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bundle added to cart"}) // 200 OK
+}
+
+// RemoveBundleFromCart
+// @Summary      Remove a bundle from the cart
+// @Description  Removes a bundle from the buyer's cart by bundle ID. Only accessible by buyers.
+// @Tags         cart
+// @Produce      json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param        bundle_id  query  int  true  "Bundle ID"
+// @Success      200  {string}  string  "Bundle removed from cart"
+// @Failure      400  {string}  string  "Invalid bundle ID"
+// @Failure      403  {string}  string  "You are not authorized to access this resource"
+// @Failure      404  {string}  string  "Bundle not found"
+// @Failure      500  {string}  string  "Internal server error"
+// @Router       /buyer/cart/bundle [delete]
+func (h *Handler) RemoveBundleFromCart(c *gin.Context) {
+	buyerID := c.GetString("user_id")
+
+	if role := c.GetString("role"); role != "buyer" {
+		newErrorResponse(c, http.StatusForbidden, "You are not authorized to access this resource") // 403 Forbidden
+		return
+	}
+
+	bundleID, err := strconv.Atoi(c.Query("bundle_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid bundle ID") // 400 Bad Request
+		return
+	}
+
+	if err := h.services.RemoveBundleFromCart(buyerID, bundleID); err != nil {
+		bundleErrorResponse(c, err, "")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Bundle removed from cart"}) // 200 OK
+}
@@ -43,16 +43,62 @@ func (h *Handler) CreateCategory(c *gin.Context) {
 	c.JSON(http.StatusOK, "Category created successfully")
 }
 
+// UpdateCategory переименовывает категорию
+// @Summary Update a category
+// @Description Change the name and the meta title and description of a category by ID. Renaming generates a new slug; the old one redirects to it.
+// @Tags Categories
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param category_id query string true "Category ID"
+// @Param input body model.CategoryInput true "Category data"
+// @Success 200 {string} string "Category updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Category not found"
+// @Failure 500 {object} ErrorResponse "Failed to update category"
+// @Router /admin/category [put]
+func (h *Handler) UpdateCategory(c *gin.Context) {
+	// Проверка роли пользователя
+	if role := c.GetString("role"); role != "admin" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource")
+		return
+	}
+
+	// Получение и проверка category_id
+	categoryId, err := strconv.Atoi(c.Query("category_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid category ID: "+err.Error())
+		return
+	}
+
+	var input model.CategoryInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error())
+		return
+	}
+
+	// Ошибка при изменении категории
+	if err := h.services.UpdateCategory(categoryId, input); err != nil {
+		dictionaryErrorResponse(c, err, "Failed to update category: ")
+		return
+	}
+	c.JSON(http.StatusOK, "Category updated successfully")
+}
+
 // DeleteCategory удаляет категорию
 // @Summary Delete a category
-// @Description Delete a category by ID
+// @Description Delete a category by ID together with its attributes. A category with items or bundles is deleted only with reassign_to: its items, bundles and attributes are moved to that category first.
 // @Tags Categories
 // @Produce json
 // @Param Authorization header string true "Bearer {JWT}"
 // @Param category_id query string true "Category ID"
+// @Param reassign_to query string false "Category ID to move the items and bundles to"
 // @Success 200 {string} string "Category deleted successfully"
 // @Failure 400 {object} ErrorResponse "Invalid category ID"
 // @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Category not found"
+// @Failure 409 {object} ErrorResponse "Category has items or bundles"
 // @Failure 500 {object} ErrorResponse "Failed to delete category"
 // @Router /admin/category [delete]
 func (h *Handler) DeleteCategory(c *gin.Context) {
@@ -69,16 +115,55 @@ func (h *Handler) DeleteCategory(c *gin.Context) {
 		newErrorResponse(c, http.StatusBadRequest, "Invalid category ID: "+err.Error())
 		return
 	}
+	reassignTo, ok := reassignToQuery(c)
+	if !ok {
+		return
+	}
 
 	// Ошибка при удалении категории
-	err = h.services.DeleteCategory(categoryId)
+	err = h.services.DeleteCategory(categoryId, reassignTo)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, "Failed to delete category: "+err.Error())
+		dictionaryErrorResponse(c, err, "Failed to delete category: ")
 		return
 	}
 	c.JSON(http.StatusOK, "Category deleted successfully")
 }
 
+// MergeCategories объединяет дубликаты категорий
+// @Summary Merge duplicate categories
+// @Description Move all items and bundles of the source categories to the target category and delete the sources. Attributes with the same name and type are merged, the rest are moved.
+// @Tags Categories
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param input body model.MergeInput true "Target and duplicate category IDs"
+// @Success 200 {string} string "Categories merged successfully"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Category not found"
+// @Failure 500 {object} ErrorResponse "Failed to merge categories"
+// @Router /admin/category/merge [post]
+func (h *Handler) MergeCategories(c *gin.Context) {
+	// Проверка роли пользователя
+	if role := c.GetString("role"); role != "admin" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource")
+		return
+	}
+
+	var input model.MergeInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error())
+		return
+	}
+
+	// Ошибка при объединении категорий
+	if err := h.services.MergeCategories(input); err != nil {
+		dictionaryErrorResponse(c, err, "Failed to merge categories: ")
+		return
+	}
+	c.JSON(http.StatusOK, "Categories merged successfully")
+}
+
 // GetCategoryList возвращает список категорий
 // @Summary Get category list
 // @Description Retrieve a list of all categories
@@ -96,3 +181,28 @@ func (h *Handler) GetCategoryList(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, categories)
 }
+
+// GetCategoryBySlug возвращает категорию по адресу
+// @Summary Get category by slug
+// @Description Retrieve a category by its slug. A former slug of a renamed or merged category responds with 301 and the current slug in the Location header and body.
+// @Tags Categories
+// @Produce json
+// @Param slug query string true "Category slug"
+// @Success 200 {object} model.CategoryOutput "Category"
+// @Success 301 {object} model.SlugRedirectOutput "Current slug"
+// @Failure 404 {object} ErrorResponse "Category not found"
+// @Failure 500 {object} ErrorResponse "Failed to retrieve category"
+// @Router /category/slug [get]
+func (h *Handler) GetCategoryBySlug(c *gin.Context) {
+	slug := c.Query("slug")
+	category, err := h.services.GetCategoryBySlug(slug)
+	if err != nil {
+		dictionaryErrorResponse(c, err, "Failed to retrieve category: ")
+		return
+	}
+	if category.Slug != slug {
+		redirectToSlug(c, category.Slug, category.CanonicalURL)
+		return
+	}
+	c.JSON(http.StatusOK, category)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+	"stroycity/pkg/service"
+)
+
+// commerceMLCookie — имя cookie, которую 1С получает в checkauth и передаёт в остальных запросах
+const commerceMLCookie = "stroycity_1c"
+
+// CommerceMLExchange обрабатывает обмен с 1С по протоколу CommerceML 2
+// @Summary 1C CommerceML exchange
+// @Description CommerceML 2 exchange endpoint for the seller's 1C (type=catalog). mode=checkauth authenticates with HTTP Basic seller email/password and returns a session cookie; mode=init starts a session and reports zip=no and file_limit; mode=file uploads import.xml/offers.xml (raw body, large files arrive in parts); mode=import processes the file: import.xml links 1C goods to items by article, offers.xml updates price, price_with_discount and quantity. Responses are plain text: "success" or "failure" followed by details.
+// @Tags 1C
+// @Accept octet-stream
+// @Produce plain
+// @Param type query string true "Exchange type" Enums(catalog)
+// @Param mode query string true "Exchange step" Enums(checkauth, init, file, import)
+// @Param filename query string false "File name for file and import steps"
+// @Success 200 {string} string "success"
+// @Failure 400 {string} string "failure"
+// @Failure 401 {string} string "failure"
+// @Failure 500 {string} string "failure"
+// @Router /1c/exchange [get]
+// @Router /1c/exchange [post]
+func (h *Handler) CommerceMLExchange(c *gin.Context) {
+	if exchangeType := c.Query("type"); exchangeType != "catalog" {
+		commerceMLResponse(c, http.StatusBadRequest, "failure", "unsupported exchange type: "+exchangeType) // 400 Bad Request
+		return
+	}
+
+	mode := c.Query("mode")
+	if mode == "checkauth" {
+		h.commerceMLCheckAuth(c)
+		return
+	}
+
+	// Остальные шаги выполняются в сеансе, открытом checkauth
+	sellerId, ok := commerceMLSeller(c)
+	if !ok {
+		commerceMLResponse(c, http.StatusUnauthorized, "failure", "not authorized") // 401 Unauthorized
+		return
+	}
+
+	filename := c.Query("filename")
+	switch mode {
+	case "init":
+		if err := h.services.CommerceMLInit(sellerId); err != nil {
+			commerceMLResponse(c, http.StatusInternalServerError, "failure", err.Error()) // 500 Internal Server Error
+			return
+		}
+		commerceMLResponse(c, http.StatusOK, "zip=no", fmt.Sprintf("file_limit=%d", service.CommerceMLFileLimit)) // 200 OK
+
+	case "file":
+		body := http.MaxBytesReader(c.Writer, c.Request.Body, service.CommerceMLFileLimit)
+		err := h.services.CommerceMLSaveFile(sellerId, filename, body)
+		if errors.Is(err, service.ErrInvalidExchangeFile) {
+			commerceMLResponse(c, http.StatusBadRequest, "failure", err.Error()) // 400 Bad Request
+			return
+		}
+		if err != nil {
+			commerceMLResponse(c, http.StatusInternalServerError, "failure", err.Error()) // 500 Internal Server Error
+			return
+		}
+		commerceMLResponse(c, http.StatusOK, "success") // 200 OK
+
+	case "import":
+		result, err := h.services.CommerceMLImport(sellerId, filename)
+		if errors.Is(err, service.ErrInvalidExchangeFile) {
+			commerceMLResponse(c, http.StatusBadRequest, "failure", err.Error()) // 400 Bad Request
+			return
+		}
+		if err != nil {
+			commerceMLResponse(c, http.StatusInternalServerError, "failure", err.Error()) // 500 Internal Server Error
+			return
+		}
+		lines := []string{"success", fmt.Sprintf("linked=%d updated=%d not_found=%d failed=%d", result.Linked, result.Updated, result.NotFound, result.Failed)}
+		commerceMLResponse(c, http.StatusOK, append(lines, result.Errors...)...) // 200 OK
+
+	default:
+		commerceMLResponse(c, http.StatusBadRequest, "failure", "unsupported mode: "+mode) // 400 Bad Request
+	}
+}
+
+// commerceMLCheckAuth проверяет логин и пароль продавца из Basic-авторизации и открывает сеанс
+func (h *Handler) commerceMLCheckAuth(c *gin.Context) {
+	mail, password, ok := c.Request.BasicAuth()
+	if !ok {
+		commerceMLResponse(c, http.StatusUnauthorized, "failure", "basic authorization required") // 401 Unauthorized
+		return
+	}
+
+	response, err := h.services.SellerSignIn(mail, password)
+	if err != nil {
+		commerceMLResponse(c, http.StatusUnauthorized, "failure", "invalid email or password") // 401 Unauthorized
+		return
+	}
+
+	commerceMLResponse(c, http.StatusOK, "success", commerceMLCookie, response.Token) // 200 OK
+}
+
+// commerceMLSeller возвращает ID продавца из cookie сеанса обмена
+func commerceMLSeller(c *gin.Context) (string, bool) {
+	token, err := c.Cookie(commerceMLCookie)
+	if err != nil {
+		return "", false
+	}
+	userId, role, err := service.ParseToken(token)
+	if err != nil || role != "seller" {
+		return "", false
+	}
+	return userId, true
+}
+
+func commerceMLResponse(c *gin.Context, statusCode int, lines ...string) {
+	c.String(statusCode, strings.Join(lines, "\n"))
+}
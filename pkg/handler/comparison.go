@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"stroycity/pkg/service"
+)
+
+// GetComparison возвращает списки сравнения по категориям
+// @Summary Get comparison lists
+// @Description Retrieve the comparison lists of the current buyer, or of a guest identified by the X-Device-Token header, grouped by category
+// @Tags Comparison
+// @Produce json
+// @Param Authorization header string false "Bearer {JWT}"
+// @Param X-Device-Token header string false "Guest device token"
+// @Success 200 {array} model.ComparisonCategory "Comparison lists"
+// @Failure 400 {object} ErrorResponse "Device token is required for guests"
+// @Failure 401 {object} ErrorResponse "Invalid authorization header"
+// @Failure 500 {object} ErrorResponse "Failed to get comparison lists"
+// @Router /comparison [get]
+func (h *Handler) GetComparison(c *gin.Context) {
+	buyerID, deviceToken := visitorIdentity(c)
+
+	categories, err := h.services.GetComparison(buyerID, deviceToken)
+	if err != nil {
+		comparisonErrorResponse(c, err, "Failed to get comparison lists: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, categories) // 200 OK
+}
+
+// AddToComparison добавляет товар в список сравнения его категории
+// @Summary Add item to comparison
+// @Description Add a published item to the comparison list of its category. A category list holds up to 10 items.
+// @Tags Comparison
+// @Produce json
+// @Param Authorization header string false "Bearer {JWT}"
+// @Param X-Device-Token header string false "Guest device token"
+// @Param item_id query int true "Item ID"
+// @Success 200 {string} string "Item added to comparison"
+// @Failure 400 {object} ErrorResponse "Invalid item ID or missing device token"
+// @Failure 401 {object} ErrorResponse "Invalid authorization header"
+// @Failure 404 {object} ErrorResponse "Item not found"
+// @Failure 409 {object} ErrorResponse "Comparison list of the category is full"
+// @Failure 500 {object} ErrorResponse "Failed to add item to comparison"
+// @Router /comparison [post]
+func (h *Handler) AddToComparison(c *gin.Context) {
+	buyerID, deviceToken := visitorIdentity(c)
+
+	itemID, err := strconv.Atoi(c.Query("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid item ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	if err := h.services.AddToComparison(buyerID, deviceToken, itemID); err != nil {
+		comparisonErrorResponse(c, err, "Failed to add item to comparison: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, "Item added to comparison") // 200 OK
+}
+
+// RemoveFromComparison удаляет товар из списка сравнения
+// @Summary Remove item from comparison
+// @Description Remove an item from the comparison list of its category
+// @Tags Comparison
+// @Produce json
+// @Param Authorization header string false "Bearer {JWT}"
+// @Param X-Device-Token header string false "Guest device token"
+// @Param item_id query int true "Item ID"
+// @Success 200 {string} string "Item removed from comparison"
+// @Failure 400 {object} ErrorResponse "Invalid item ID or missing device token"
+// @Failure 401 {object} ErrorResponse "Invalid authorization header"
+// @Failure 404 {object} ErrorResponse "Item is not in the comparison list"
+// @Failure 500 {object} ErrorResponse "Failed to remove item from comparison"
+// @Router /comparison [delete]
+func (h *Handler) RemoveFromComparison(c *gin.Context) {
+	buyerID, deviceToken := visitorIdentity(c)
+
+	itemID, err := strconv.Atoi(c.Query("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid item ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	if err := h.services.RemoveFromComparison(buyerID, deviceToken, itemID); err != nil {
+		comparisonErrorResponse(c, err, "Failed to remove item from comparison: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, "Item removed from comparison") // 200 OK
+}
+
+// ClearComparison очищает список сравнения категории или все списки
+// @Summary Clear comparison list
+// @Description Remove all items from the comparison list of a category, or from all lists when category_id is omitted
+// @Tags Comparison
+// @Produce json
+// @Param Authorization header string false "Bearer {JWT}"
+// @Param X-Device-Token header string false "Guest device token"
+// @Param category_id query int false "Category ID"
+// @Success 200 {string} string "Comparison cleared"
+// @Failure 400 {object} ErrorResponse "Invalid category ID or missing device token"
+// @Failure 401 {object} ErrorResponse "Invalid authorization header"
+// @Failure 500 {object} ErrorResponse "Failed to clear comparison"
+// @Router /comparison/clear [delete]
+func (h *Handler) ClearComparison(c *gin.Context) {
+	buyerID, deviceToken := visitorIdentity(c)
+
+	categoryID := 0
+	if value := c.Query("category_id"); value != "" {
+		var err error
+		if categoryID, err = strconv.Atoi(value); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "Invalid category ID: "+err.Error()) // 400 Bad Request
+			return
+		}
+	}
+
+	if err := h.services.ClearComparison(buyerID, deviceToken, categoryID); err != nil {
+		comparisonErrorResponse(c, err, "Failed to clear comparison: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, "Comparison cleared") // 200 OK
+}
+
+// GetComparisonTable возвращает таблицу сравнения товаров категории
+// @Summary Get comparison table
+// @Description Side-by-side table of the compared items of a category: main card fields, dimensions and category attributes. Values follow the order of items; rows where items differ are marked with differs.
+// @Tags Comparison
+// @Produce json
+// @Param Authorization header string false "Bearer {JWT}"
+// @Param X-Device-Token header string false "Guest device token"
+// @Param category_id query int true "Category ID"
+// @Param only_differences query bool false "Return only rows where items differ"
+// @Success 200 {object} model.ComparisonTable "Comparison table"
+// @Failure 400 {object} ErrorResponse "Invalid category ID or missing device token"
+// @Failure 401 {object} ErrorResponse "Invalid authorization header"
+// @Failure 500 {object} ErrorResponse "Failed to get comparison table"
+// @Router /comparison/table [get]
+func (h *Handler) GetComparisonTable(c *gin.Context) {
+	buyerID, deviceToken := visitorIdentity(c)
+
+	categoryID, err := strconv.Atoi(c.Query("category_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid category ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	table, err := h.services.GetComparisonTable(buyerID, deviceToken, categoryID, c.Query("only_differences") == "true")
+	if err != nil {
+		comparisonErrorResponse(c, err, "Failed to get comparison table: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, table) // 200 OK
+}
+
+func comparisonErrorResponse(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrVisitorRequired), errors.Is(err, service.ErrComparisonCategoryNeeded):
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+	case errors.Is(err, service.ErrItemNotFound), errors.Is(err, service.ErrComparisonItemNotFound):
+		newErrorResponse(c, http.StatusNotFound, err.Error()) // 404 Not Found
+	case errors.Is(err, service.ErrComparisonFull):
+		newErrorResponse(c, http.StatusConflict, err.Error()) // 409 Conflict
+	default:
+		newErrorResponse(c, http.StatusInternalServerError, message+err.Error()) // 500 Internal Server Error
+	}
+}
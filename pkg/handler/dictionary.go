@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"stroycity/pkg/service"
+)
+
+// reassignToQuery читает необязательный параметр reassign_to — запись справочника, в которую переносятся товары при удалении
+func reassignToQuery(c *gin.Context) (int, bool) {
+	value := c.Query("reassign_to")
+	if value == "" {
+		return 0, true
+	}
+	reassignTo, err := strconv.Atoi(value)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid reassign_to: "+err.Error()) // 400 Bad Request
+		return 0, false
+	}
+	return reassignTo, true
+}
+
+// dictionaryErrorResponse переводит ошибки изменения справочников в HTTP-статусы
+func dictionaryErrorResponse(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrNameRequired), errors.Is(err, service.ErrInvalidMerge), errors.Is(err, service.ErrInvalidImage):
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+	case errors.Is(err, service.ErrCategoryNotFound), errors.Is(err, service.ErrBrandNotFound), errors.Is(err, service.ErrMaterialNotFound):
+		newErrorResponse(c, http.StatusNotFound, err.Error()) // 404 Not Found
+	case errors.Is(err, service.ErrReferencedByItems), errors.Is(err, service.ErrReferencedByBundles):
+		newErrorResponse(c, http.StatusConflict, err.Error()) // 409 Conflict
+	default:
+		newErrorResponse(c, http.StatusInternalServerError, message+err.Error()) // 500 Internal Server Error
+	}
+}
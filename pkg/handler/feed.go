@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"stroycity/pkg/model"
+	"stroycity/pkg/service"
+)
+
+// GetYMLFeed отдаёт выгрузку каталога в формате Яндекс YML
+// @Summary Get YML catalog feed
+// @Description Catalog feed in Yandex Market YML format. Without seller_id the whole platform is exported. The feed is regenerated periodically; ETag and Last-Modified change only when the data changes, conditional requests get 304.
+// @Tags Feeds
+// @Produce xml
+// @Param seller_id query string false "Seller ID"
+// @Param If-None-Match header string false "ETag of the cached feed"
+// @Param If-Modified-Since header string false "Last-Modified of the cached feed"
+// @Success 200 {file} file "YML feed"
+// @Success 304 {string} string "Not modified"
+// @Failure 404 {object} ErrorResponse "Seller not found"
+// @Failure 500 {object} ErrorResponse "Failed to get feed"
+// @Router /feed/yml [get]
+func (h *Handler) GetYMLFeed(c *gin.Context) {
+	h.serveFeed(c, model.FeedFormatYML)
+}
+
+// GetCSVFeed отдаёт выгрузку каталога в CSV
+// @Summary Get CSV catalog feed
+// @Description Catalog feed in CSV (semicolon separated, UTF-8 with BOM) with the same column names as the catalog import. Without seller_id the whole platform is exported. Supports ETag and Last-Modified like the YML feed.
+// @Tags Feeds
+// @Produce text/csv
+// @Param seller_id query string false "Seller ID"
+// @Param If-None-Match header string false "ETag of the cached feed"
+// @Param If-Modified-Since header string false "Last-Modified of the cached feed"
+// @Success 200 {file} file "CSV feed"
+// @Success 304 {string} string "Not modified"
+// @Failure 404 {object} ErrorResponse "Seller not found"
+// @Failure 500 {object} ErrorResponse "Failed to get feed"
+// @Router /feed/csv [get]
+func (h *Handler) GetCSVFeed(c *gin.Context) {
+	h.serveFeed(c, model.FeedFormatCSV)
+}
+
+func (h *Handler) serveFeed(c *gin.Context, format string) {
+	feed, err := h.services.GetFeed(format, c.Query("seller_id"))
+	if errors.Is(err, service.ErrFeedSellerNotFound) || errors.Is(err, service.ErrUnknownFeedFormat) {
+		newErrorResponse(c, http.StatusNotFound, "Failed to get feed: "+err.Error()) // 404 Not Found
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get feed: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.Header("ETag", feed.ETag)
+	c.Header("Last-Modified", feed.ModifiedAt.UTC().Format(http.TimeFormat))
+
+	// Условные запросы: агрегатор перекачивает выгрузку только при изменениях
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		if match == feed.ETag {
+			c.Status(http.StatusNotModified) // 304 Not Modified
+			return
+		}
+	} else if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil && !feed.ModifiedAt.After(since) {
+		c.Status(http.StatusNotModified) // 304 Not Modified
+		return
+	}
+
+	c.Data(http.StatusOK, feed.ContentType, feed.Data) // 200 OK
+}
@@ -7,6 +7,7 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 	_ "stroycity/docs"
 	"stroycity/pkg/service"
+	"stroycity/pkg/storage"
 )
 
 type Handler struct {
@@ -24,7 +25,7 @@ func (h *Handler) InitRoutes() *gin.Engine {
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With", "Content-Type", "Accept"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With", "Content-Type", "Accept", "X-Device-Token"},
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
 	}))
@@ -44,23 +45,77 @@ func (h *Handler) InitRoutes() *gin.Engine {
 	// @Success 200 {file} file "Successfully retrieved file"
 	// @Failure 404 {object} gin.H "File Not Found"
 	// @Router /uploads/{file} [get]
-	router.Static("/uploads", "./uploads")
+	// Раздаём файлы только при локальном хранилище, S3 отдаёт их сам
+	if local, ok := h.services.Storage.(*storage.LocalStorage); ok {
+		router.Static(local.PublicURL(), local.Dir())
+	}
 	////////////////////////////////////////////////////////////
 
 	// EVERYONE
 	////////////////////////////////////////////////////////////
 	router.GET("/category", h.GetCategoryList)
+	router.GET("/category/slug", h.GetCategoryBySlug)
 	router.GET("/brand", h.GetBrandList)
+	router.GET("/brand/slug", h.GetBrandBySlug)
 	router.GET("/material", h.GetMaterialList)
 	router.GET("/review", h.GetReviews)
+	router.GET("/attribute", h.GetAttributeList)
+	router.GET("/product", h.GetProductById)
 
 	item := router.Group("/item")
 	{
 		item.POST("", h.GetItemList)
-		item.GET("", h.GetItemById)
+		item.GET("", h.ViewerIdentity, h.GetItemById)
+		item.GET("/slug", h.ViewerIdentity, h.GetItemBySlug)
+		item.GET("/price-history", h.GetPriceHistory)
+		item.GET("/:id/recommendations", h.GetItemRecommendations)
+	}
+
+	bundle := router.Group("/bundle")
+	{
+		bundle.GET("", h.GetBundleById)
+		bundle.GET("/list", h.GetBundleList)
+	}
+
+	router.GET("/sitemap.xml", h.GetSitemap)
+
+	stockSubscription := router.Group("/stock-subscription")
+	{
+		stockSubscription.POST("", h.SubscribeGuestToStock)
+		stockSubscription.GET("/unsubscribe", h.GetSubscriptionByToken)
+		stockSubscription.POST("/unsubscribe", h.UnsubscribeByToken)
+	}
+
+	// Сравнение доступно и покупателям, и гостям по токену устройства
+	comparison := router.Group("/comparison", h.OptionalIdentity)
+	{
+		comparison.GET("", h.GetComparison)
+		comparison.POST("", h.AddToComparison)
+		comparison.DELETE("", h.RemoveFromComparison)
+		comparison.DELETE("/clear", h.ClearComparison)
+		comparison.GET("/table", h.GetComparisonTable)
+	}
+
+	recentlyViewed := router.Group("/recently-viewed", h.OptionalIdentity)
+	{
+		recentlyViewed.GET("", h.GetGuestRecentlyViewed)
+		recentlyViewed.DELETE("", h.ClearGuestRecentlyViewed)
+	}
+
+	feed := router.Group("/feed")
+	{
+		feed.GET("/yml", h.GetYMLFeed)
+		feed.GET("/csv", h.GetCSVFeed)
 	}
 	////////////////////////////////////////////////////////////
 
+	//1C
+	////////////////////////////////////////////////////////////
+	// 1С авторизуется Basic-авторизацией и cookie сеанса, а не JWT в заголовке
+	router.GET("/1c/exchange", h.CommerceMLExchange)
+	router.POST("/1c/exchange", h.CommerceMLExchange)
+	////////////////////////////////////////////////////////////
+
 	//SIGN UP
 	////////////////////////////////////////////////////////////
 	signUp := router.Group("/sign-up")
@@ -89,20 +144,42 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		category := admin.Group("/category")
 		{
 			category.POST("", h.CreateCategory)
+			category.PUT("", h.UpdateCategory)
 			category.DELETE("", h.DeleteCategory)
+			category.POST("/merge", h.MergeCategories)
 		}
 
 		brand := admin.Group("/brand")
 		{
 			brand.POST("", h.CreateBrand)
+			brand.PUT("", h.UpdateBrand)
 			brand.DELETE("", h.DeleteBrand)
+			brand.POST("/merge", h.MergeBrands)
+			brand.POST("/logo", h.UploadBrandLogo)
 		}
 
 		material := admin.Group("/material")
 		{
 			material.POST("", h.CreateMaterial)
+			material.PUT("", h.UpdateMaterial)
 			material.DELETE("", h.DeleteMaterial)
+			material.POST("/merge", h.MergeMaterials)
+		}
+
+		attribute := admin.Group("/attribute")
+		{
+			attribute.POST("", h.CreateAttribute)
+			attribute.DELETE("", h.DeleteAttribute)
+		}
+
+		moderation := admin.Group("/moderation")
+		{
+			moderation.GET("", h.GetModerationQueue)
+			moderation.POST("/approve", h.ApproveModerationRequest)
+			moderation.POST("/reject", h.RejectModerationRequest)
 		}
+
+		admin.POST("/order/refund", h.RefundOrder)
 	}
 	////////////////////////////////////////////////////////////
 
@@ -117,9 +194,59 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		{
 			sellerItem.POST("", h.CreateItem)
 			sellerItem.PUT("", h.UpdateItem)
+			sellerItem.DELETE("", h.DeleteItem)
+			sellerItem.PATCH("/publish", h.PublishItem)
+			sellerItem.PATCH("/unpublish", h.UnpublishItem)
+			sellerItem.PATCH("/archive", h.ArchiveItem)
+			sellerItem.GET("/image", h.GetItemImages)
 			sellerItem.POST("/image", h.UploadImage)
+			sellerItem.DELETE("/image", h.DeleteImage)
+			sellerItem.POST("/image/batch", h.UploadImages)
+			sellerItem.PUT("/image/order", h.ReorderImages)
+			sellerItem.PATCH("/image/primary", h.SetPrimaryImage)
 		}
 
+		seller.POST("/product", h.CreateProduct)
+
+		inventory := seller.Group("/inventory")
+		{
+			inventory.PATCH("", h.UpdateInventory)
+			inventory.GET("/movements", h.GetInventoryMovements)
+			inventory.PUT("/low-stock-threshold", h.SetLowStockThreshold)
+		}
+
+		warehouse := seller.Group("/warehouse")
+		{
+			warehouse.GET("", h.GetWarehouses)
+			warehouse.POST("", h.CreateWarehouse)
+			warehouse.PUT("", h.UpdateWarehouse)
+			warehouse.DELETE("", h.DeleteWarehouse)
+			warehouse.PUT("/stock", h.SetWarehouseStock)
+		}
+
+		sellerBundle := seller.Group("/bundle")
+		{
+			sellerBundle.GET("", h.GetSellerBundles)
+			sellerBundle.POST("", h.CreateBundle)
+			sellerBundle.PUT("", h.UpdateBundle)
+			sellerBundle.DELETE("", h.DeleteBundle)
+		}
+
+		sellerImport := seller.Group("/import")
+		{
+			sellerImport.POST("", h.StartImport)
+			sellerImport.GET("", h.GetImportJob)
+			sellerImport.GET("/report", h.GetImportErrorReport)
+		}
+
+		notifications := seller.Group("/notifications")
+		{
+			notifications.GET("", h.GetSellerNotifications)
+			notifications.PATCH("/read", h.MarkSellerNotificationsRead)
+		}
+
+		seller.GET("/moderation", h.GetSellerModerationRequests)
+
 		seller.GET("/statistic", h.GetSellerEarnings)
 
 	}
@@ -136,6 +263,8 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		{
 			order.GET("", h.GetOrder)
 			order.POST("", h.CreateOrder)
+			order.POST("/pay", h.PayOrder)
+			order.POST("/cancel", h.CancelOrder)
 		}
 
 		cart := buyer.Group("/cart")
@@ -143,6 +272,9 @@ func (h *Handler) InitRoutes() *gin.Engine {
 			cart.GET("", h.GetCart)
 			cart.POST("", h.AddToCart)
 			cart.DELETE("", h.RemoveFromCart)
+			cart.GET("/recommendations", h.GetCartRecommendations)
+			cart.POST("/bundle", h.AddBundleToCart)
+			cart.DELETE("/bundle", h.RemoveBundleFromCart)
 		}
 
 		favorites := buyer.Group("/favorites")
@@ -151,6 +283,22 @@ func (h *Handler) InitRoutes() *gin.Engine {
 			favorites.DELETE("", h.RemoveFromFavorites)
 		}
 
+		buyerNotifications := buyer.Group("/notifications")
+		{
+			buyerNotifications.GET("", h.GetBuyerNotifications)
+			buyerNotifications.PATCH("/read", h.MarkBuyerNotificationsRead)
+		}
+
+		buyer.GET("/recently-viewed", h.GetRecentlyViewed)
+		buyer.DELETE("/recently-viewed", h.ClearRecentlyViewed)
+
+		subscriptions := buyer.Group("/subscriptions")
+		{
+			subscriptions.GET("", h.GetStockSubscriptions)
+			subscriptions.POST("", h.SubscribeToStock)
+			subscriptions.DELETE("", h.UnsubscribeFromStock)
+		}
+
 		buyer.POST("/review", h.CreateReview)
 	}
 	////////////////////////////////////////////////////////////
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"stroycity/pkg/model"
+	"stroycity/pkg/service"
+)
+
+// GetItemImages возвращает изображения товара в порядке показа
+// @Summary Get item images
+// @Description Get images of the seller's item: the primary image first, then by position
+// @Tags Items
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param item_id query string true "Item ID"
+// @Success 200 {array} model.ImageInfo "Item images"
+// @Failure 400 {object} ErrorResponse "Invalid item ID"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Item not found"
+// @Failure 500 {object} ErrorResponse "Failed to get images"
+// @Router /seller/item/image [get]
+func (h *Handler) GetItemImages(c *gin.Context) {
+	itemID, ok := h.sellerItemID(c, c.Query("item_id"))
+	if !ok {
+		return
+	}
+
+	images, err := h.services.GetItemImages(itemID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get images: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, images) // 200 OK
+}
+
+// UploadImages загружает несколько изображений товара за один запрос
+// @Summary Upload several images for an item
+// @Description Upload up to 10 images in one multipart request under the "images" field. All files are validated first; if any of them is rejected or saving fails partway, nothing is saved.
+// @Tags Items
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param item_id query string true "Item ID"
+// @Param images formData file true "Image files"
+// @Success 200 {array} model.ImageInfo "Uploaded images with renditions"
+// @Failure 400 {object} ErrorResponse "Invalid item ID, unsupported or too large image"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Item not found"
+// @Failure 500 {object} ErrorResponse "Failed to save images"
+// @Router /seller/item/image/batch [post]
+func (h *Handler) UploadImages(c *gin.Context) {
+	itemID, ok := h.sellerItemID(c, c.Query("item_id"))
+	if !ok {
+		return
+	}
+
+	// Загрузка файлов
+	form, err := c.MultipartForm()
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Failed to upload images: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	// Сохранение изображений
+	images, err := h.services.UploadImages(itemID, form.File["images"])
+	if errors.Is(err, service.ErrInvalidImage) {
+		newErrorResponse(c, http.StatusBadRequest, "Failed to upload images: "+err.Error()) // 400 Bad Request
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to save images: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, images) // 200 OK
+}
+
+// ReorderImages меняет порядок изображений товара
+// @Summary Reorder item images
+// @Description Set the display order of item images. The list must contain every image of the item exactly once.
+// @Tags Items
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param input body model.ImageOrderInput true "Item ID and image IDs in the new order"
+// @Success 200 {string} string "Images reordered"
+// @Failure 400 {object} ErrorResponse "Invalid input"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Item not found"
+// @Failure 500 {object} ErrorResponse "Failed to reorder images"
+// @Router /seller/item/image/order [put]
+func (h *Handler) ReorderImages(c *gin.Context) {
+	var input model.ImageOrderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	itemID, ok := h.sellerItemID(c, strconv.Itoa(input.ItemID))
+	if !ok {
+		return
+	}
+
+	err := h.services.ReorderImages(itemID, input.ImageIDs)
+	if errors.Is(err, service.ErrInvalidImageOrder) {
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to reorder images: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Images reordered successfully"}) // 200 OK
+}
+
+// SetPrimaryImage делает изображение основным фото товара
+// @Summary Set the primary image of an item
+// @Description Mark the image as the main photo of the item; it is returned first in listings
+// @Tags Items
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param item_id query string true "Item ID"
+// @Param image_id query string true "Image ID"
+// @Success 200 {string} string "Primary image updated"
+// @Failure 400 {object} ErrorResponse "Invalid item or image ID"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Item or image not found"
+// @Failure 500 {object} ErrorResponse "Failed to update primary image"
+// @Router /seller/item/image/primary [patch]
+func (h *Handler) SetPrimaryImage(c *gin.Context) {
+	itemID, ok := h.sellerItemID(c, c.Query("item_id"))
+	if !ok {
+		return
+	}
+	imageID, err := strconv.Atoi(c.Query("image_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid image ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	err = h.services.SetPrimaryImage(itemID, imageID)
+	if errors.Is(err, service.ErrImageNotFound) {
+		newErrorResponse(c, http.StatusNotFound, "Image not found") // 404 Not Found
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to update primary image: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Primary image updated successfully"}) // 200 OK
+}
+
+// DeleteImage удаляет изображение товара вместе с файлами в хранилище
+// @Summary Delete an item image
+// @Description Delete the image and all of its renditions from storage. If the primary image is deleted, the next one becomes primary.
+// @Tags Items
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param item_id query string true "Item ID"
+// @Param image_id query string true "Image ID"
+// @Success 200 {string} string "Image deleted"
+// @Failure 400 {object} ErrorResponse "Invalid item or image ID"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Item or image not found"
+// @Failure 500 {object} ErrorResponse "Failed to delete image"
+// @Router /seller/item/image [delete]
+func (h *Handler) DeleteImage(c *gin.Context) {
+	itemID, ok := h.sellerItemID(c, c.Query("item_id"))
+	if !ok {
+		return
+	}
+	imageID, err := strconv.Atoi(c.Query("image_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid image ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	err = h.services.DeleteImage(itemID, imageID)
+	if errors.Is(err, service.ErrImageNotFound) {
+		newErrorResponse(c, http.StatusNotFound, "Image not found") // 404 Not Found
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to delete image: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Image deleted successfully"}) // 200 OK
+}
+
+// sellerItemID разбирает ID товара и проверяет, что он принадлежит текущему продавцу.
+// При ошибке ответ уже отправлен и возвращается false.
+func (h *Handler) sellerItemID(c *gin.Context, itemIDStr string) (int, bool) {
+	itemID, err := strconv.Atoi(itemIDStr)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid item ID: "+err.Error()) // 400 Bad Request
+		return 0, false
+	}
+
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return 0, false
+	}
+
+	// Проверка прав на товар
+	item, err := h.services.GetItemById(itemID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "Item not found: "+err.Error()) // 404 Not Found
+		return 0, false
+	}
+	if item.SellerID != sellerId {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to modify this item") // 401 Unauthorized
+		return 0, false
+	}
+
+	return itemID, true
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"stroycity/pkg/service"
+)
+
+// StartImport запускает асинхронный импорт каталога из CSV/XLSX
+// @Summary Start catalog import
+// @Description Upload a CSV or XLSX price list (up to 20 MB) to create or update the seller's items. Rows are matched by article: existing items are updated with filled cells only, new items are created. Columns are recognised by field name (article, name, price, category, ...) or common Russian headers; the optional mapping field overrides it with a JSON object {"field": "column header"}. Category, brand and material are given by name. Processing runs in background; poll GET /seller/import for progress.
+// @Tags Import
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param file formData file true "CSV or XLSX file"
+// @Param mapping formData string false "JSON object mapping item fields to column headers"
+// @Success 202 {string} string "JobID"
+// @Failure 400 {object} ErrorResponse "Invalid file or column mapping"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to start import"
+// @Router /seller/import [post]
+func (h *Handler) StartImport(c *gin.Context) {
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	// Загрузка файла
+	_, fileHeader, err := c.Request.FormFile("file")
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Failed to upload file: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	// Сопоставление колонок, если задано
+	var mapping map[string]string
+	if raw := c.PostForm("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "Invalid mapping: "+err.Error()) // 400 Bad Request
+			return
+		}
+	}
+
+	jobID, err := h.services.StartImport(sellerId, fileHeader, mapping)
+	if errors.Is(err, service.ErrInvalidImport) {
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to start import: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusAccepted, strconv.Itoa(jobID)) // 202 Accepted
+}
+
+// GetImportJob возвращает состояние задачи импорта
+// @Summary Get import job status
+// @Description Poll progress and counters of a catalog import job
+// @Tags Import
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param job_id query string true "Import job ID"
+// @Success 200 {object} model.ImportJobOutput "Import job"
+// @Failure 400 {object} ErrorResponse "Invalid job ID"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Import job not found"
+// @Router /seller/import [get]
+func (h *Handler) GetImportJob(c *gin.Context) {
+	jobID, sellerId, ok := importJobParams(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.services.GetImportJob(sellerId, jobID)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, err.Error()) // 404 Not Found
+		return
+	}
+
+	c.JSON(http.StatusOK, job) // 200 OK
+}
+
+// GetImportErrorReport отдаёт CSV-отчёт с ошибками строк импорта
+// @Summary Download import error report
+// @Description Download a CSV report (semicolon separated, UTF-8 with BOM) with the row number, article and error for every rejected row
+// @Tags Import
+// @Produce text/csv
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param job_id query string true "Import job ID"
+// @Success 200 {file} file "Error report"
+// @Failure 400 {object} ErrorResponse "Invalid job ID"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Import job not found"
+// @Failure 500 {object} ErrorResponse "Failed to build report"
+// @Router /seller/import/report [get]
+func (h *Handler) GetImportErrorReport(c *gin.Context) {
+	jobID, sellerId, ok := importJobParams(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.services.GetImportErrorReport(sellerId, jobID)
+	if errors.Is(err, service.ErrImportJobNotFound) {
+		newErrorResponse(c, http.StatusNotFound, err.Error()) // 404 Not Found
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to build report: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="import_%d_errors.csv"`, jobID))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", report) // 200 OK
+}
+
+// importJobParams проверяет роль продавца и разбирает ID задачи импорта
+func importJobParams(c *gin.Context) (int, string, bool) {
+	jobID, err := strconv.Atoi(c.Query("job_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid job ID: "+err.Error()) // 400 Bad Request
+		return 0, "", false
+	}
+
+	// Проверка роли пользователя
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return 0, "", false
+	}
+
+	return jobID, c.GetString("user_id"), true
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"stroycity/pkg/model"
+	"stroycity/pkg/service"
+	"time"
+)
+
+// UpdateInventory массово обновляет остатки и цены товаров продавца
+// @Summary Bulk update stock and prices
+// @Description Partially update quantity, price and price_with_discount of up to 1000 items identified by item_id or article. Omitted fields are left unchanged. Pass the item version read earlier to reject the row with status "conflict" if the item was changed meanwhile. Every row is applied independently and gets its own result; a row that fails with an internal error gets status "error" and the remaining rows are still applied. price_with_discount must not exceed price; changing only the price of an item without a discount keeps it without a discount. Stock changes are recorded in the inventory movements with the row reason.
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param input body []model.InventoryUpdate true "Inventory rows"
+// @Success 200 {array} model.InventoryUpdateResult "Per-row results"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to update inventory"
+// @Router /seller/inventory [patch]
+func (h *Handler) UpdateInventory(c *gin.Context) {
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	var input []model.InventoryUpdate
+	// Валидация JSON данных
+	if err := c.ShouldBindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	results, err := h.services.UpdateInventory(sellerId, input)
+	if errors.Is(err, service.ErrTooManyInventoryUpdates) {
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to update inventory: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, results) // 200 OK
+}
+
+// GetInventoryMovements возвращает журнал движений остатков продавца
+// @Summary Get inventory movements
+// @Description Retrieve the append-only log of stock changes of the seller's items, newest first: sales, cancellations, manual adjustments and imports with who made the change and why
+// @Tags Inventory
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param item_id query int false "Item ID"
+// @Param type query string false "Movement type" Enums(sale, cancellation, adjustment, import)
+// @Param from query string false "Start of the period, RFC 3339 or YYYY-MM-DD"
+// @Param to query string false "End of the period (exclusive), RFC 3339 or YYYY-MM-DD"
+// @Param limit query int false "Page size, 100 by default"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} model.StockMovementOutput "Movements"
+// @Failure 400 {object} ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to get movements"
+// @Router /seller/inventory/movements [get]
+func (h *Handler) GetInventoryMovements(c *gin.Context) {
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	filter := model.StockMovementFilter{Type: c.Query("type")}
+	var err error
+	for name, target := range map[string]*int{"item_id": &filter.ItemID, "limit": &filter.Limit, "offset": &filter.Offset} {
+		if value := c.Query(name); value != "" {
+			if *target, err = strconv.Atoi(value); err != nil {
+				newErrorResponse(c, http.StatusBadRequest, "Invalid "+name+": "+err.Error()) // 400 Bad Request
+				return
+			}
+		}
+	}
+	if filter.From, err = parseTimeQuery(c, "from"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+		return
+	}
+	if filter.To, err = parseTimeQuery(c, "to"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+		return
+	}
+
+	movements, err := h.services.GetMovements(sellerId, filter)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get movements: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, movements) // 200 OK
+}
+
+// SetLowStockThreshold задаёт порог низкого остатка
+// @Summary Set low-stock threshold
+// @Description Set the low-stock threshold of an item, or the seller's default threshold when item_id is omitted. When stock drops to the threshold the seller gets a notification. An item threshold of 0 falls back to the seller default; a seller default of 0 disables the alerts.
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param input body model.LowStockThresholdInput true "Threshold"
+// @Success 200 {string} string "Threshold updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Item not found"
+// @Router /seller/inventory/low-stock-threshold [put]
+func (h *Handler) SetLowStockThreshold(c *gin.Context) {
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	var input model.LowStockThresholdInput
+	// Валидация JSON данных
+	if err := c.ShouldBindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	err := h.services.SetLowStockThreshold(sellerId, input)
+	if errors.Is(err, service.ErrItemNotFound) {
+		newErrorResponse(c, http.StatusNotFound, err.Error()) // 404 Not Found
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Failed to update threshold: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	c.JSON(http.StatusOK, "Threshold updated successfully") // 200 OK
+}
+
+// parseTimeQuery разбирает момент времени из параметра запроса в формате RFC 3339 или дату YYYY-MM-DD
+func parseTimeQuery(c *gin.Context, name string) (*time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return &parsed, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid %s: expected RFC 3339 or YYYY-MM-DD", name)
+}
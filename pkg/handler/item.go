@@ -1,20 +1,24 @@
 package handler
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
+	"io"
 	"net/http"
 	"strconv"
 	"stroycity/pkg/model"
+	"stroycity/pkg/service"
 )
 
 // CreateItem создаёт новый товар
 // @Summary Create a new item
-// @Description Create a new item in the system
+// @Description Create a new item in the system. By default the item is sent to moderation and appears in the catalog once approved; set status to "draft" to prepare it without submitting.
 // @Tags Items
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer {JWT}"
 // @Param input body model.ItemInput true "Item data"
+// @Param reason query string false "Reason for the initial stock, recorded in the inventory movements"
 // @Success 201 {string} string "ItemID"
 // @Failure 400 {object} ErrorResponse "Invalid input data"
 // @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
@@ -37,8 +41,15 @@ func (h *Handler) CreateItem(c *gin.Context) {
 		return
 	}
 
+	// Начальный остаток попадает в журнал движений как ручная корректировка
+	change := model.StockChange{Type: model.MovementTypeAdjustment, ActorID: sellerId, Reason: c.DefaultQuery("reason", "item created")}
+
 	// Создание товара
-	itemID, err := h.services.CreateItem(input)
+	itemID, err := h.services.CreateItem(input, change)
+	if errors.Is(err, service.ErrInvalidItemStatus) {
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+		return
+	}
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, "Failed to create item: "+err.Error()) // 500 Internal Server Error
 		return
@@ -49,9 +60,11 @@ func (h *Handler) CreateItem(c *gin.Context) {
 
 // GetItemById возвращает товар по ID
 // @Summary Get item by ID
-// @Description Retrieve an item by its ID
+// @Description Retrieve a published item by its ID. Drafts, items on moderation and archived items are visible only to their seller and administrators. Viewing a published item adds it to the recently viewed items of the buyer or of the guest identified by X-Device-Token and counts towards popularity. Anonymous views without a buyer or device token are not counted, and guest views are counted up to a limit per client IP.
 // @Tags Items
 // @Produce json
+// @Param Authorization header string false "Bearer {JWT}"
+// @Param X-Device-Token header string false "Guest device token"
 // @Param id query string true "Item ID"
 // @Success 200 {object} model.CurrentItemInfo "Item details"
 // @Failure 400 {object} ErrorResponse "Invalid item ID"
@@ -72,18 +85,63 @@ func (h *Handler) GetItemById(c *gin.Context) {
 		newErrorResponse(c, http.StatusNotFound, "Item not found: "+err.Error()) // 404 Not Found
 		return
 	}
+	// Неопубликованный товар видят только его продавец и администраторы
+	if item.Status != model.ItemStatusPublished && !canViewUnpublishedItem(c, item.SellerID) {
+		newErrorResponse(c, http.StatusNotFound, "Item not found") // 404 Not Found
+		return
+	}
+	h.recordItemView(c, item)
+	c.JSON(http.StatusOK, item) // 200 OK
+}
+
+// canViewUnpublishedItem сообщает, может ли текущий пользователь видеть неопубликованный товар продавца sellerID
+func canViewUnpublishedItem(c *gin.Context, sellerID string) bool {
+	switch c.GetString("role") {
+	case "admin":
+		return true
+	case "seller":
+		return c.GetString("user_id") == sellerID
+	}
+	return false
+}
+
+// GetItemBySlug возвращает опубликованный товар по адресу
+// @Summary Get item by slug
+// @Description Retrieve a published item by its slug. A former slug of a renamed item responds with 301 and the current slug in the Location header and body. Views are recorded as for GET /item.
+// @Tags Items
+// @Produce json
+// @Param Authorization header string false "Bearer {JWT}"
+// @Param X-Device-Token header string false "Guest device token"
+// @Param slug query string true "Item slug"
+// @Success 200 {object} model.CurrentItemInfo "Item details"
+// @Success 301 {object} model.SlugRedirectOutput "Current slug"
+// @Failure 404 {object} ErrorResponse "Item not found"
+// @Router /item/slug [get]
+func (h *Handler) GetItemBySlug(c *gin.Context) {
+	slug := c.Query("slug")
+	item, err := h.services.GetItemBySlug(slug)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "Item not found: "+err.Error()) // 404 Not Found
+		return
+	}
+	if item.Slug != slug {
+		redirectToSlug(c, item.Slug, item.CanonicalURL)
+		return
+	}
+	h.recordItemView(c, item)
 	c.JSON(http.StatusOK, item) // 200 OK
 }
 
 // UpdateItem обновляет существующий товар
 // @Summary Update an existing item
-// @Description Update an item by its ID
+// @Description Update an item by its ID. Changing the name, description, article, category, brand or material of a published item is held for moderation: the approved version stays in the catalog until an admin approves the changes. Unpublishing the item withdraws the pending changes.
 // @Tags Items
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer {JWT}"
 // @Param id query string true "Item ID"
 // @Param input body model.ItemInput true "Item data"
+// @Param reason query string false "Reason for the stock change, recorded in the inventory movements"
 // @Success 200 {string} string "Item updated successfully"
 // @Failure 400 {object} ErrorResponse "Invalid input data"
 // @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
@@ -127,29 +185,133 @@ func (h *Handler) UpdateItem(c *gin.Context) {
 	}
 
 	input.ID = id
+	input.SellerID = sellerId
+	change := model.StockChange{Type: model.MovementTypeAdjustment, ActorID: sellerId, Reason: c.DefaultQuery("reason", "item updated")}
 	// Обновление товара
-	if err = h.services.UpdateItem(input); err != nil {
+	if err = h.services.UpdateItem(input, change); err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, "Failed to update item: "+err.Error()) // 500 Internal Server Error
 		return
 	}
 	c.JSON(http.StatusOK, "Item updated successfully") // 200 OK
 }
 
+// PublishItem публикует товар продавца
+// @Summary Publish an item
+// @Description Submit a draft or archived item to moderation; it appears in the catalog once an admin approves it
+// @Tags Items
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param id query string true "Item ID"
+// @Success 200 {string} string "Item published successfully"
+// @Failure 400 {object} ErrorResponse "Invalid item status"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Item not found"
+// @Router /seller/item/publish [patch]
+func (h *Handler) PublishItem(c *gin.Context) {
+	h.setItemStatus(c, model.ItemStatusPublished, "Item published successfully")
+}
+
+// UnpublishItem снимает товар продавца с публикации
+// @Summary Unpublish an item
+// @Description Hide an item from the catalog and move it back to drafts
+// @Tags Items
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param id query string true "Item ID"
+// @Success 200 {string} string "Item unpublished successfully"
+// @Failure 400 {object} ErrorResponse "Invalid item status"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Item not found"
+// @Router /seller/item/unpublish [patch]
+func (h *Handler) UnpublishItem(c *gin.Context) {
+	h.setItemStatus(c, model.ItemStatusDraft, "Item unpublished successfully")
+}
+
+// ArchiveItem отправляет товар продавца в архив
+// @Summary Archive an item
+// @Description Archive an item that is no longer sold. Archived items are hidden from the catalog but stay in the order history and can be published again.
+// @Tags Items
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param id query string true "Item ID"
+// @Success 200 {string} string "Item archived successfully"
+// @Failure 400 {object} ErrorResponse "Invalid item status"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Item not found"
+// @Router /seller/item/archive [patch]
+func (h *Handler) ArchiveItem(c *gin.Context) {
+	h.setItemStatus(c, model.ItemStatusArchived, "Item archived successfully")
+}
+
+// DeleteItem удаляет товар продавца
+// @Summary Delete an item
+// @Description Soft-delete an item: it disappears from the catalog, the seller's items and buyers' carts, but stays in the order history. An item reserved by unpaid orders cannot be deleted until they are paid or cancelled.
+// @Tags Items
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param id query string true "Item ID"
+// @Success 200 {string} string "Item deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid item ID"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Item not found"
+// @Failure 409 {object} ErrorResponse "Item is reserved by unpaid orders"
+// @Failure 500 {object} ErrorResponse "Failed to delete item"
+// @Router /seller/item [delete]
+func (h *Handler) DeleteItem(c *gin.Context) {
+	itemID, ok := h.sellerItemID(c, c.Query("id"))
+	if !ok {
+		return
+	}
+
+	err := h.services.DeleteItem(itemID)
+	if errors.Is(err, service.ErrItemReserved) {
+		newErrorResponse(c, http.StatusConflict, err.Error()) // 409 Conflict
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to delete item: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, "Item deleted successfully") // 200 OK
+}
+
+// setItemStatus меняет статус товара текущего продавца
+func (h *Handler) setItemStatus(c *gin.Context, status, message string) {
+	itemID, ok := h.sellerItemID(c, c.Query("id"))
+	if !ok {
+		return
+	}
+
+	err := h.services.SetItemStatus(itemID, status)
+	if errors.Is(err, service.ErrItemNotFound) {
+		newErrorResponse(c, http.StatusNotFound, err.Error()) // 404 Not Found
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+		return
+	}
+
+	c.JSON(http.StatusOK, message) // 200 OK
+}
+
 // GetItemList возвращает список товаров с фильтрами
 // @Summary Get item list
-// @Description Retrieve a list of items, optionally filtered
+// @Description Retrieve a list of items, optionally filtered and sorted. sort=popular orders by item page views.
 // @Tags Items
 // @Accept json
 // @Produce json
 // @Param filters body model.FilterRequest true "Filter criteria"
 // @Success 200 {array} model.ItemInfo "List of items"
+// @Failure 400 {object} ErrorResponse "Invalid sort"
 // @Failure 500 {object} ErrorResponse "Failed to get items"
 // @Router /item [post]
 func (h *Handler) GetItemList(c *gin.Context) {
 	var filters model.FilterRequest
 
 	// Проверка, есть ли фильтры
-	if err := c.ShouldBindJSON(&filters); err != nil && err.Error() == "EOF" {
+	if err := c.ShouldBindJSON(&filters); errors.Is(err, io.EOF) {
 		// Получение всех товаров без фильтрации
 		items, err := h.services.GetAllItems()
 		if err != nil {
@@ -161,7 +323,11 @@ func (h *Handler) GetItemList(c *gin.Context) {
 	}
 
 	// Получение товаров с фильтрами
-	items, err := h.services.GetItems(filters.BrandIDs, filters.SellerIDs, filters.CategoryIDs, filters.MaterialIDs, filters.MinPrice, filters.MaxPrice, filters.Query)
+	items, err := h.services.GetItems(filters.BrandIDs, filters.SellerIDs, filters.CategoryIDs, filters.MaterialIDs, filters.MinPrice, filters.MaxPrice, filters.Query, filters.Attributes, filters.Sort)
+	if errors.Is(err, service.ErrInvalidItemSort) {
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+		return
+	}
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, "Filtering error: "+err.Error()) // 500 Internal Server Error
 		return
@@ -172,42 +338,21 @@ func (h *Handler) GetItemList(c *gin.Context) {
 
 // UploadImage загружает изображение для товара
 // @Summary Upload an image for an item
-// @Description Upload an image for a specific item by ID
+// @Description Upload an image for a specific item by ID. Accepts JPEG, PNG or WebP up to 10 MB and 8000x8000 px; metadata is stripped and thumbnail/medium/large renditions are generated.
 // @Tags Items
 // @Produce json
 // @Param Authorization header string true "Bearer {JWT}"
 // @Param item_id query string true "Item ID"
 // @Param image formData file true "Image file"
-// @Success 200 {object} SuccessResponse ""url": "url""
-// @Failure 400 {object} ErrorResponse "Invalid item ID or failed to upload image"
+// @Success 200 {object} model.ImageInfo "Uploaded image with renditions"
+// @Failure 400 {object} ErrorResponse "Invalid item ID, unsupported or too large image"
 // @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
 // @Failure 404 {object} ErrorResponse "Item not found"
 // @Failure 500 {object} ErrorResponse "Failed to save image"
 // @Router /seller/item/image [post]
 func (h *Handler) UploadImage(c *gin.Context) {
-	// Получение ID товара из запроса
-	itemIDStr := c.Query("item_id")
-	itemID, err := strconv.Atoi(itemIDStr)
-	if err != nil {
-		newErrorResponse(c, http.StatusBadRequest, "Invalid item ID: "+err.Error()) // 400 Bad Request
-		return
-	}
-
-	// Проверка роли пользователя
-	sellerId := c.GetString("user_id")
-	if role := c.GetString("role"); role != "seller" {
-		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
-		return
-	}
-
-	// Проверка прав на добавление изображения
-	item, err := h.services.GetItemById(itemID)
-	if err != nil {
-		newErrorResponse(c, http.StatusNotFound, "Item not found: "+err.Error()) // 404 Not Found
-		return
-	}
-	if item.SellerID != sellerId {
-		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to modify this item") // 401 Unauthorized
+	itemID, ok := h.sellerItemID(c, c.Query("item_id"))
+	if !ok {
 		return
 	}
 
@@ -220,11 +365,15 @@ func (h *Handler) UploadImage(c *gin.Context) {
 	defer file.Close()
 
 	// Сохранение изображения
-	filePath, err := h.services.UploadImage(itemID, file, fileHeader)
+	image, err := h.services.UploadImage(itemID, file, fileHeader)
+	if errors.Is(err, service.ErrInvalidImage) {
+		newErrorResponse(c, http.StatusBadRequest, "Failed to upload image: "+err.Error()) // 400 Bad Request
+		return
+	}
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, "Failed to save image: "+err.Error()) // 500 Internal Server Error
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"url": filePath}) // 200 OK
+	c.JSON(http.StatusOK, image) // 200 OK
 }
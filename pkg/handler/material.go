@@ -45,16 +45,62 @@ func (h *Handler) CreateMaterial(c *gin.Context) {
 	c.JSON(http.StatusCreated, "Material created successfully") // 201 Created
 }
 
+// UpdateMaterial переименовывает материал
+// @Summary Rename a material
+// @Description Change the name of a material by its ID
+// @Tags Materials
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param material_id query string true "Material ID"
+// @Param input body model.MaterialInput true "Material data"
+// @Success 200 {string} string "Material updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 403 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Material not found"
+// @Failure 500 {object} ErrorResponse "Failed to update material"
+// @Router /admin/material [put]
+func (h *Handler) UpdateMaterial(c *gin.Context) {
+	// Проверка роли пользователя
+	if role := c.GetString("role"); role != "admin" {
+		newErrorResponse(c, http.StatusForbidden, "You are not authorized to access this resource") // 403 Forbidden
+		return
+	}
+
+	// Получение и проверка material_id
+	materialId, err := strconv.Atoi(c.Query("material_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid material ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	var input model.MaterialInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	if err := h.services.UpdateMaterial(materialId, input); err != nil {
+		dictionaryErrorResponse(c, err, "Failed to update material: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, "Material updated successfully") // 200 OK
+}
+
 // DeleteMaterial удаляет материал по ID
 // @Summary Delete a material by ID
-// @Description Delete a material from the system by its ID
+// @Description Delete a material from the system by its ID. A material used by items is deleted only with reassign_to: its items are moved to that material first.
 // @Tags Materials
 // @Produce json
 // @Param Authorization header string true "Bearer {JWT}"
 // @Param material_id query string true "Material ID"
+// @Param reassign_to query string false "Material ID to move the items to"
 // @Success 200 {string} string "Material deleted successfully"
 // @Failure 400 {object} ErrorResponse "Invalid material ID"
 // @Failure 403 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Material not found"
+// @Failure 409 {object} ErrorResponse "Material is used by items"
 // @Failure 500 {object} ErrorResponse "Failed to delete material"
 // @Router /admin/material [delete]
 func (h *Handler) DeleteMaterial(c *gin.Context) {
@@ -71,17 +117,56 @@ func (h *Handler) DeleteMaterial(c *gin.Context) {
 		newErrorResponse(c, http.StatusBadRequest, "Invalid material ID: "+err.Error()) // 400 Bad Request
 		return
 	}
+	reassignTo, ok := reassignToQuery(c)
+	if !ok {
+		return
+	}
 
 	// Ошибка при удалении материала
-	err = h.services.DeleteMaterial(materialId)
+	err = h.services.DeleteMaterial(materialId, reassignTo)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, "Failed to delete material: "+err.Error()) // 500 Internal Server Error
+		dictionaryErrorResponse(c, err, "Failed to delete material: ")
 		return
 	}
 
 	c.JSON(http.StatusOK, "Material deleted successfully") // 200 OK
 }
 
+// MergeMaterials объединяет дубликаты материалов
+// @Summary Merge duplicate materials
+// @Description Move all items of the source materials to the target material and delete the sources
+// @Tags Materials
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param input body model.MergeInput true "Target and duplicate material IDs"
+// @Success 200 {string} string "Materials merged successfully"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 403 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Material not found"
+// @Failure 500 {object} ErrorResponse "Failed to merge materials"
+// @Router /admin/material/merge [post]
+func (h *Handler) MergeMaterials(c *gin.Context) {
+	// Проверка роли пользователя
+	if role := c.GetString("role"); role != "admin" {
+		newErrorResponse(c, http.StatusForbidden, "You are not authorized to access this resource") // 403 Forbidden
+		return
+	}
+
+	var input model.MergeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	if err := h.services.MergeMaterials(input); err != nil {
+		dictionaryErrorResponse(c, err, "Failed to merge materials: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, "Materials merged successfully") // 200 OK
+}
+
 // GetMaterialList возвращает список материалов
 // @Summary Get material list
 // @Description Retrieve a list of materials from the system
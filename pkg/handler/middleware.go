@@ -9,6 +9,8 @@ import (
 
 const (
 	authorizationHeader = "Authorization"
+	// deviceTokenHeader — постоянный идентификатор устройства гостя, который генерирует клиент
+	deviceTokenHeader = "X-Device-Token"
 )
 
 func (h *Handler) UserIdentity(c *gin.Context) {
@@ -33,3 +35,52 @@ func (h *Handler) UserIdentity(c *gin.Context) {
 	c.Set("user_id", userId)
 	c.Set("role", role)
 }
+
+// OptionalIdentity определяет пользователя по заголовку авторизации, если он передан,
+// и пропускает гостей без него. Неверный токен отклоняется, чтобы покупатель не попал в гостевые списки.
+func (h *Handler) OptionalIdentity(c *gin.Context) {
+	header := c.GetHeader(authorizationHeader)
+	if header == "" {
+		return
+	}
+
+	headerParts := strings.Split(header, " ")
+	if len(headerParts) != 2 {
+		newErrorResponse(c, http.StatusUnauthorized, "Invalid authorization header")
+		return
+	}
+
+	userId, role, err := service.ParseToken(headerParts[1])
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	c.Set("user_id", userId)
+	c.Set("role", role)
+}
+
+// ViewerIdentity определяет пользователя, как OptionalIdentity, но неверный или истёкший токен
+// не отклоняет запрос: просмотр витрины не должен ломаться из-за устаревшей авторизации
+func (h *Handler) ViewerIdentity(c *gin.Context) {
+	headerParts := strings.Split(c.GetHeader(authorizationHeader), " ")
+	if len(headerParts) != 2 {
+		return
+	}
+
+	userId, role, err := service.ParseToken(headerParts[1])
+	if err != nil {
+		return
+	}
+
+	c.Set("user_id", userId)
+	c.Set("role", role)
+}
+
+// visitorIdentity возвращает ID покупателя, а для гостя и других ролей — токен устройства
+func visitorIdentity(c *gin.Context) (buyerID, deviceToken string) {
+	if c.GetString("role") == "buyer" {
+		buyerID = c.GetString("user_id")
+	}
+	return buyerID, c.GetHeader(deviceTokenHeader)
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"stroycity/pkg/model"
+	"stroycity/pkg/service"
+)
+
+// GetModerationQueue возвращает очередь модерации
+// @Summary Get moderation queue
+// @Description Retrieve item and bundle moderation requests with the given status (pending by default). Pending requests are sorted by auto-check priority: forbidden words, price anomalies and missing images go first.
+// @Tags Moderation
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param status query string false "Request status: pending, approved, rejected or withdrawn"
+// @Success 200 {array} model.ModerationRequestOutput "Moderation requests"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to get moderation queue"
+// @Router /admin/moderation [get]
+func (h *Handler) GetModerationQueue(c *gin.Context) {
+	// Проверка роли пользователя
+	if role := c.GetString("role"); role != "admin" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	requests, err := h.services.GetModerationQueue(c.Query("status"))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get moderation queue: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, requests) // 200 OK
+}
+
+// ApproveModerationRequest одобряет заявку и публикует товар
+// @Summary Approve moderation request
+// @Description Approve a pending moderation request: the item or bundle is published, or the pending changes of a published one replace its current version, and the seller is notified
+// @Tags Moderation
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param id query int true "Moderation request ID"
+// @Success 200 {string} string "Item approved"
+// @Failure 400 {object} ErrorResponse "Invalid request ID"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Moderation request not found"
+// @Failure 409 {object} ErrorResponse "Moderation request is already reviewed"
+// @Failure 500 {object} ErrorResponse "Failed to approve item"
+// @Router /admin/moderation/approve [post]
+func (h *Handler) ApproveModerationRequest(c *gin.Context) {
+	// Проверка роли пользователя
+	adminId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "admin" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	requestID, err := strconv.Atoi(c.Query("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid request ID") // 400 Bad Request
+		return
+	}
+
+	if err := h.services.ApproveItem(adminId, requestID); err != nil {
+		moderationErrorResponse(c, err, "Failed to approve item: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, "Item approved") // 200 OK
+}
+
+// RejectModerationRequest отклоняет заявку с указанием причины
+// @Summary Reject moderation request
+// @Description Reject a pending moderation request: a new item or bundle goes back to drafts, a published one keeps its approved version, and the seller is notified with the reason
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param id query int true "Moderation request ID"
+// @Param input body model.ModerationRejectInput true "Reject reason"
+// @Success 200 {string} string "Item rejected"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Moderation request not found"
+// @Failure 409 {object} ErrorResponse "Moderation request is already reviewed"
+// @Failure 500 {object} ErrorResponse "Failed to reject item"
+// @Router /admin/moderation/reject [post]
+func (h *Handler) RejectModerationRequest(c *gin.Context) {
+	// Проверка роли пользователя
+	adminId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "admin" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	requestID, err := strconv.Atoi(c.Query("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid request ID") // 400 Bad Request
+		return
+	}
+
+	var input model.ModerationRejectInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	if err := h.services.RejectItem(adminId, requestID, input.Reason); err != nil {
+		moderationErrorResponse(c, err, "Failed to reject item: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, "Item rejected") // 200 OK
+}
+
+// GetSellerModerationRequests возвращает заявки продавца на модерацию
+// @Summary Get seller moderation requests
+// @Description Retrieve moderation requests of the current seller with decisions and reject reasons, newest first
+// @Tags Moderation
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param item_id query int false "Item ID"
+// @Success 200 {array} model.ModerationRequestOutput "Moderation requests"
+// @Failure 400 {object} ErrorResponse "Invalid item ID"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to get moderation requests"
+// @Router /seller/moderation [get]
+func (h *Handler) GetSellerModerationRequests(c *gin.Context) {
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	var itemID int
+	if value := c.Query("item_id"); value != "" {
+		var err error
+		if itemID, err = strconv.Atoi(value); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "Invalid item ID") // 400 Bad Request
+			return
+		}
+	}
+
+	requests, err := h.services.GetSellerModerationRequests(sellerId, itemID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get moderation requests: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, requests) // 200 OK
+}
+
+// moderationErrorResponse переводит ошибки рассмотрения заявки в HTTP-статусы
+func moderationErrorResponse(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrRejectReasonRequired):
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+	case errors.Is(err, service.ErrModerationRequestNotFound):
+		newErrorResponse(c, http.StatusNotFound, err.Error()) // 404 Not Found
+	case errors.Is(err, service.ErrModerationRequestReviewed):
+		newErrorResponse(c, http.StatusConflict, err.Error()) // 409 Conflict
+	default:
+		newErrorResponse(c, http.StatusInternalServerError, message+err.Error()) // 500 Internal Server Error
+	}
+}
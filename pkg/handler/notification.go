@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+)
+
+// NotificationReadInput — уведомления, которые нужно отметить прочитанными; пустой список — все
+type NotificationReadInput struct {
+	IDs []int `json:"ids"`
+}
+
+// GetSellerNotifications возвращает уведомления продавца
+// @Summary Get seller notifications
+// @Description Retrieve notifications of the current seller, newest first, e.g. low-stock alerts
+// @Tags Notifications
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param unread query bool false "Only unread notifications"
+// @Success 200 {array} model.NotificationOutput "Notifications"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to get notifications"
+// @Router /seller/notifications [get]
+func (h *Handler) GetSellerNotifications(c *gin.Context) {
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	notifications, err := h.services.GetNotifications(sellerId, c.Query("unread") == "true")
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get notifications: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications) // 200 OK
+}
+
+// MarkSellerNotificationsRead отмечает уведомления продавца прочитанными
+// @Summary Mark seller notifications as read
+// @Description Mark the given notifications of the current seller as read, or all of them when ids are omitted
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param input body NotificationReadInput false "Notification IDs"
+// @Success 200 {string} string "Notifications marked as read"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to update notifications"
+// @Router /seller/notifications/read [patch]
+func (h *Handler) MarkSellerNotificationsRead(c *gin.Context) {
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	var input NotificationReadInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	if err := h.services.MarkNotificationsRead(sellerId, input.IDs); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to update notifications: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, "Notifications marked as read") // 200 OK
+}
+
+// GetBuyerNotifications возвращает уведомления покупателя
+// @Summary Get buyer notifications
+// @Description Retrieve notifications of the current buyer, newest first: price drops and restocks of favourite items
+// @Tags Notifications
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param unread query bool false "Only unread notifications"
+// @Success 200 {array} model.NotificationOutput "Notifications"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to get notifications"
+// @Router /buyer/notifications [get]
+func (h *Handler) GetBuyerNotifications(c *gin.Context) {
+	// Проверка роли пользователя
+	buyerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "buyer" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	notifications, err := h.services.GetNotifications(buyerId, c.Query("unread") == "true")
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get notifications: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications) // 200 OK
+}
+
+// MarkBuyerNotificationsRead отмечает уведомления покупателя прочитанными
+// @Summary Mark buyer notifications as read
+// @Description Mark the given notifications of the current buyer as read, or all of them when ids are omitted
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param input body NotificationReadInput false "Notification IDs"
+// @Success 200 {string} string "Notifications marked as read"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to update notifications"
+// @Router /buyer/notifications/read [patch]
+func (h *Handler) MarkBuyerNotificationsRead(c *gin.Context) {
+	// Проверка роли пользователя
+	buyerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "buyer" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	var input NotificationReadInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	if err := h.services.MarkNotificationsRead(buyerId, input.IDs); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to update notifications: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, "Notifications marked as read") // 200 OK
+}
@@ -1,18 +1,25 @@
 package handler
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
+	"io"
 	"net/http"
 	"strconv"
+	"stroycity/pkg/model"
+	"stroycity/pkg/service"
 )
 
 // CreateOrder создает заказ на основе товаров в корзине пользователя
 // @Summary Create a new order
-// @Description Create an order from the items in the cart of the current buyer
+// @Description Create an order from the items in the cart of the current buyer. Items stocked per warehouse are allocated from the chosen warehouse or the warehouses nearest to the buyer's coordinates; set pickup with warehouse_id for self-pickup.
+// @Description The items are reserved until reserved_until; an order that is not paid by then is cancelled and the reservation is released.
 // @Tags Orders
+// @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer {JWT}"
-// @Success 200 {object} SuccessResponse "Order created successfully"
+// @Param input body model.CheckoutInput false "Warehouse, pickup and buyer coordinates"
+// @Success 200 {object} OrderCreatedResponse "Order created, awaiting payment"
 // @Failure 400 {object} ErrorResponse "Cart is empty"
 // @Failure 403 {object} ErrorResponse "You are not authorized to access this resource"
 // @Failure 500 {object} ErrorResponse "Internal server error"
@@ -33,26 +40,162 @@ func (h *Handler) CreateOrder(c *gin.Context) {
 		return
 	}
 
-	if len(cartItems.Items) == 0 {
+	if len(cartItems.Items) == 0 && len(cartItems.Bundles) == 0 {
 		newErrorResponse(c, http.StatusBadRequest, "Cart is empty")
 		return
 	}
 
-	// Создание заказа
-	err = h.services.CreateOrder(buyerID)
+	// Параметры оформления необязательны
+	var input model.CheckoutInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error())
+		return
+	}
+
+	// Создание заказа с резервированием товаров
+	orderID, err := h.services.CreateOrder(buyerID, input)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	// Очистка корзины после заказа
-	err = h.services.ClearCart(buyerID)
+	// Корзину очищает сам CreateOrder после сохранения заказа
+	c.JSON(http.StatusOK, OrderCreatedResponse{Message: "Order created, awaiting payment", OrderID: orderID})
+}
+
+// PayOrder подтверждает оплату заказа
+// @Summary Pay for an order
+// @Description Confirm payment of an order awaiting payment. The reserved items are written off the stock and the sellers are credited.
+// @Tags Orders
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param order_id query int true "Order ID"
+// @Success 200 {object} SuccessResponse "Order paid successfully"
+// @Failure 400 {object} ErrorResponse "Invalid order ID"
+// @Failure 403 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Order not found"
+// @Failure 409 {object} ErrorResponse "Reservation expired or order already paid"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /buyer/order/pay [post]
+func (h *Handler) PayOrder(c *gin.Context) {
+	buyerID := c.GetString("user_id")
+
+	// Проверка роли пользователя
+	if role := c.GetString("role"); role != "buyer" {
+		newErrorResponse(c, http.StatusForbidden, "You are not authorized to access this resource")
+		return
+	}
+
+	orderID, err := strconv.Atoi(c.Query("order_id"))
 	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	err = h.services.PayOrder(buyerID, orderID)
+	switch {
+	case errors.Is(err, service.ErrOrderNotFound):
+		newErrorResponse(c, http.StatusNotFound, "Order not found")
+		return
+	case errors.Is(err, service.ErrReservationExpired), errors.Is(err, service.ErrOrderAlreadyPaid):
+		newErrorResponse(c, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Order paid successfully"})
+}
+
+// CancelOrder отменяет заказ покупателя
+// @Summary Cancel an order
+// @Description Cancel an unpaid order of the current buyer and release its reservation. A paid order cannot be cancelled by the buyer; it is refunded by an administrator.
+// @Tags Orders
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param order_id query int true "Order ID"
+// @Success 200 {object} SuccessResponse "Order cancelled successfully"
+// @Failure 400 {object} ErrorResponse "Invalid order ID"
+// @Failure 403 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Order not found"
+// @Failure 409 {object} ErrorResponse "Order is already cancelled or paid"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /buyer/order/cancel [post]
+func (h *Handler) CancelOrder(c *gin.Context) {
+	buyerID := c.GetString("user_id")
+
+	// Проверка роли пользователя
+	if role := c.GetString("role"); role != "buyer" {
+		newErrorResponse(c, http.StatusForbidden, "You are not authorized to access this resource")
+		return
+	}
+
+	orderID, err := strconv.Atoi(c.Query("order_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	err = h.services.CancelOrder(buyerID, orderID)
+	switch {
+	case errors.Is(err, service.ErrOrderNotFound):
+		newErrorResponse(c, http.StatusNotFound, "Order not found")
+		return
+	case errors.Is(err, service.ErrOrderNotCancellable):
+		newErrorResponse(c, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Order cancelled successfully"})
+}
+
+// RefundOrder отменяет заказ с возвратом оплаты
+// @Summary Refund an order
+// @Description Cancel any order that is not cancelled yet. The reservation of an unpaid order is released; the items of a paid order are returned to stock with a cancellation movement and the payment is withdrawn from the sellers in the same transaction. Only accessible by administrators.
+// @Tags Orders
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param order_id query int true "Order ID"
+// @Param reason query string false "Reason recorded in the inventory movements"
+// @Success 200 {object} SuccessResponse "Order refunded successfully"
+// @Failure 400 {object} ErrorResponse "Invalid order ID"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Order not found"
+// @Failure 409 {object} ErrorResponse "Order is already cancelled"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/order/refund [post]
+func (h *Handler) RefundOrder(c *gin.Context) {
+	// Проверка роли пользователя
+	adminId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "admin" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	orderID, err := strconv.Atoi(c.Query("order_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	err = h.services.RefundOrder(adminId, orderID, c.Query("reason"))
+	switch {
+	case errors.Is(err, service.ErrOrderNotFound):
+		newErrorResponse(c, http.StatusNotFound, "Order not found")
+		return
+	case errors.Is(err, service.ErrOrderNotCancellable):
+		newErrorResponse(c, http.StatusConflict, err.Error())
+		return
+	case err != nil:
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Order created successfully"})
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Order refunded successfully"})
 }
 
 // GetOrder возвращает заказ по ID
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"stroycity/pkg/service"
+)
+
+// GetPriceHistory возвращает историю цены товара для графика
+// @Summary Get item price history
+// @Description Price chart of an item: every change of price and price with discount in the period. When from is set, the first point is the price in effect at that moment. Items that are not published respond with 404.
+// @Tags Items
+// @Produce json
+// @Param id query string true "Item ID"
+// @Param from query string false "Period start, RFC 3339 or YYYY-MM-DD"
+// @Param to query string false "Period end (exclusive), RFC 3339 or YYYY-MM-DD"
+// @Success 200 {object} model.PriceHistoryOutput "Price history"
+// @Failure 400 {object} ErrorResponse "Invalid item ID or period"
+// @Failure 404 {object} ErrorResponse "Item not found"
+// @Failure 500 {object} ErrorResponse "Failed to get price history"
+// @Router /item/price-history [get]
+func (h *Handler) GetPriceHistory(c *gin.Context) {
+	itemID, err := strconv.Atoi(c.Query("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid item ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+		return
+	}
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+		return
+	}
+
+	history, err := h.services.GetPriceHistory(itemID, from, to)
+	if errors.Is(err, service.ErrItemNotFound) {
+		newErrorResponse(c, http.StatusNotFound, err.Error()) // 404 Not Found
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get price history: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, history) // 200 OK
+}
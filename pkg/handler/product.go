@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"stroycity/pkg/model"
+)
+
+// CreateProduct создаёт карточку товара с осями вариантов
+// @Summary Create a product card
+// @Description Create a product card that groups item variants by axes (colour, size, package volume). Variants are created via POST /seller/item with product_id and variant_values.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param input body model.ProductInput true "Product data"
+// @Success 201 {string} string "ProductID"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Router /seller/product [post]
+func (h *Handler) CreateProduct(c *gin.Context) {
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	var input model.ProductInput
+	// Валидация JSON данных
+	if err := c.ShouldBindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	// Создание карточки товара
+	productID, err := h.services.CreateProduct(sellerId, input)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Failed to create product: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	c.JSON(http.StatusCreated, strconv.Itoa(productID)) // 201 Created
+}
+
+// GetProductById возвращает карточку товара с матрицей вариантов
+// @Summary Get product by ID
+// @Description Retrieve a product card with its variant matrix
+// @Tags Products
+// @Produce json
+// @Param id query string true "Product ID"
+// @Success 200 {object} model.VariantMatrix "Product with variants"
+// @Failure 400 {object} ErrorResponse "Invalid product ID"
+// @Failure 404 {object} ErrorResponse "Product not found"
+// @Router /product [get]
+func (h *Handler) GetProductById(c *gin.Context) {
+	// Получение ID из запроса
+	idStr := c.Query("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid product ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	// Получение карточки товара по ID
+	product, err := h.services.GetProductById(id)
+	if err != nil {
+		newErrorResponse(c, http.StatusNotFound, "Product not found: "+err.Error()) // 404 Not Found
+		return
+	}
+	c.JSON(http.StatusOK, product) // 200 OK
+}
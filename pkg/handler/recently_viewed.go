@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"stroycity/pkg/model"
+	"stroycity/pkg/service"
+)
+
+// recordItemView учитывает просмотр опубликованного товара; сбой записи не мешает показу карточки
+func (h *Handler) recordItemView(c *gin.Context, item model.CurrentItemInfo) {
+	if item.Status != model.ItemStatusPublished {
+		return
+	}
+	buyerID, deviceToken := visitorIdentity(c)
+	if err := h.services.RecordView(buyerID, deviceToken, c.ClientIP(), item.ID); err != nil {
+		logrus.Errorf("failed to record view of item %d: %s", item.ID, err.Error())
+	}
+}
+
+// GetRecentlyViewed возвращает недавно просмотренные покупателем товары
+// @Summary Get recently viewed items
+// @Description Retrieve published items the current buyer has viewed, most recent first. Up to 50 items are kept.
+// @Tags Recently viewed
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Success 200 {array} model.RecentlyViewedOutput "Recently viewed items"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to get recently viewed items"
+// @Router /buyer/recently-viewed [get]
+func (h *Handler) GetRecentlyViewed(c *gin.Context) {
+	// Проверка роли пользователя
+	buyerID := c.GetString("user_id")
+	if role := c.GetString("role"); role != "buyer" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	views, err := h.services.GetRecentlyViewed(buyerID, "")
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get recently viewed items: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, views) // 200 OK
+}
+
+// ClearRecentlyViewed очищает историю просмотров покупателя
+// @Summary Clear recently viewed items
+// @Description Clear the browsing history of the current buyer. View counts used for popularity are kept.
+// @Tags Recently viewed
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Success 200 {string} string "Browsing history cleared"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to clear browsing history"
+// @Router /buyer/recently-viewed [delete]
+func (h *Handler) ClearRecentlyViewed(c *gin.Context) {
+	// Проверка роли пользователя
+	buyerID := c.GetString("user_id")
+	if role := c.GetString("role"); role != "buyer" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	if err := h.services.ClearRecentlyViewed(buyerID, ""); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to clear browsing history: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, "Browsing history cleared") // 200 OK
+}
+
+// GetGuestRecentlyViewed возвращает недавно просмотренные товары гостя
+// @Summary Get guest recently viewed items
+// @Description Retrieve published items viewed from the guest device identified by X-Device-Token, most recent first. A signed-in buyer gets their own history.
+// @Tags Recently viewed
+// @Produce json
+// @Param Authorization header string false "Bearer {JWT}"
+// @Param X-Device-Token header string false "Guest device token"
+// @Success 200 {array} model.RecentlyViewedOutput "Recently viewed items"
+// @Failure 400 {object} ErrorResponse "Device token is required for guests"
+// @Failure 401 {object} ErrorResponse "Invalid authorization header"
+// @Failure 500 {object} ErrorResponse "Failed to get recently viewed items"
+// @Router /recently-viewed [get]
+func (h *Handler) GetGuestRecentlyViewed(c *gin.Context) {
+	buyerID, deviceToken := visitorIdentity(c)
+
+	views, err := h.services.GetRecentlyViewed(buyerID, deviceToken)
+	if errors.Is(err, service.ErrVisitorRequired) {
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get recently viewed items: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, views) // 200 OK
+}
+
+// ClearGuestRecentlyViewed очищает историю просмотров гостя
+// @Summary Clear guest recently viewed items
+// @Description Clear the browsing history of the guest device identified by X-Device-Token
+// @Tags Recently viewed
+// @Produce json
+// @Param Authorization header string false "Bearer {JWT}"
+// @Param X-Device-Token header string false "Guest device token"
+// @Success 200 {string} string "Browsing history cleared"
+// @Failure 400 {object} ErrorResponse "Device token is required for guests"
+// @Failure 401 {object} ErrorResponse "Invalid authorization header"
+// @Failure 500 {object} ErrorResponse "Failed to clear browsing history"
+// @Router /recently-viewed [delete]
+func (h *Handler) ClearGuestRecentlyViewed(c *gin.Context) {
+	buyerID, deviceToken := visitorIdentity(c)
+
+	err := h.services.ClearRecentlyViewed(buyerID, deviceToken)
+	if errors.Is(err, service.ErrVisitorRequired) {
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to clear browsing history: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, "Browsing history cleared") // 200 OK
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"stroycity/pkg/service"
+)
+
+// GetItemRecommendations возвращает рекомендации к товару
+// @Summary Get item recommendations
+// @Description Items frequently bought together with the item, similar items of the same category within the price band (same material first) and complementary items from categories usually bought with it. Only in-stock items are recommended; co-purchases are refreshed by a background job.
+// @Tags Items
+// @Produce json
+// @Param id path int true "Item ID"
+// @Success 200 {object} model.RecommendationsOutput "Recommendations"
+// @Failure 400 {object} ErrorResponse "Invalid item ID"
+// @Failure 404 {object} ErrorResponse "Item not found"
+// @Failure 500 {object} ErrorResponse "Failed to get recommendations"
+// @Router /item/{id}/recommendations [get]
+func (h *Handler) GetItemRecommendations(c *gin.Context) {
+	itemID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid item ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	recommendations, err := h.services.GetItemRecommendations(itemID)
+	if errors.Is(err, service.ErrItemNotFound) {
+		newErrorResponse(c, http.StatusNotFound, err.Error()) // 404 Not Found
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get recommendations: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, recommendations) // 200 OK
+}
+
+// GetCartRecommendations возвращает рекомендации к корзине покупателя
+// @Summary Get cart recommendations
+// @Description "Complete your project" suggestions for the buyer's cart: items frequently bought together with the cart contents and complementary items from related categories (e.g. drywall → profiles, screws, putty). similar is always empty.
+// @Tags cart
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Success 200 {object} model.RecommendationsOutput "Recommendations"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to get recommendations"
+// @Router /buyer/cart/recommendations [get]
+func (h *Handler) GetCartRecommendations(c *gin.Context) {
+	// Проверка роли пользователя
+	buyerID := c.GetString("user_id")
+	if role := c.GetString("role"); role != "buyer" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	recommendations, err := h.services.GetCartRecommendations(buyerID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get recommendations: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, recommendations) // 200 OK
+}
@@ -8,6 +8,11 @@ type SuccessResponse struct {
 	Message string `json:"message"`
 }
 
+type OrderCreatedResponse struct {
+	Message string `json:"message"`
+	OrderID int    `json:"order_id"`
+}
+
 type ErrorResponse struct {
 	Message string `json:"message"`
 }
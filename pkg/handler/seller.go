@@ -166,10 +166,7 @@ func (h *Handler) GetSellerEarnings(c *gin.Context) {
 	}
 
 	// Формирование ответа
-	statistic := struct {
-		CurrentWeek float64 `json:"current_week"`
-		LastWeek    float64 `json:"last_week"`
-	}{
+	statistic := model.Statistic{
 		CurrentWeek: currentWeek,
 		LastWeek:    lastWeek,
 	}
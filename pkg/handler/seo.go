@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+	"strconv"
+	"stroycity/pkg/model"
+	"stroycity/pkg/service"
+)
+
+// GetSitemap отдаёт карту сайта
+// @Summary Get sitemap.xml
+// @Description Sitemap with the home page, categories and published items. When there are more than 50000 URLs, the root file is a sitemap index that links to the parts by page.
+// @Tags SEO
+// @Produce xml
+// @Param page query int false "Sitemap part, starting from 1"
+// @Success 200 {file} file "Sitemap"
+// @Failure 400 {object} ErrorResponse "Invalid page"
+// @Failure 404 {object} ErrorResponse "Sitemap page not found"
+// @Failure 500 {object} ErrorResponse "Failed to generate sitemap"
+// @Router /sitemap.xml [get]
+func (h *Handler) GetSitemap(c *gin.Context) {
+	var page int
+	if value := c.Query("page"); value != "" {
+		var err error
+		if page, err = strconv.Atoi(value); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "Invalid page: "+err.Error()) // 400 Bad Request
+			return
+		}
+	}
+
+	sitemap, err := h.services.GetSitemap(page)
+	if errors.Is(err, service.ErrSitemapPageNotFound) {
+		newErrorResponse(c, http.StatusNotFound, err.Error()) // 404 Not Found
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to generate sitemap: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", sitemap) // 200 OK
+}
+
+// redirectToSlug перенаправляет запрос по прежнему адресу на актуальный
+func redirectToSlug(c *gin.Context, slug, canonicalURL string) {
+	location := url.URL{Path: c.Request.URL.Path, RawQuery: url.Values{"slug": {slug}}.Encode()}
+	c.Header("Location", location.String())
+	c.JSON(http.StatusMovedPermanently, model.SlugRedirectOutput{Slug: slug, CanonicalURL: canonicalURL}) // 301 Moved Permanently
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"stroycity/pkg/model"
+	"stroycity/pkg/service"
+)
+
+// SubscribeToStock подписывает покупателя на поступление товара
+// @Summary Subscribe to back-in-stock notification
+// @Description Subscribe the current buyer to an out-of-stock item. When the item is restocked the buyer gets one notification, even if the item is also in favourites. Subscribing again after a notification waits for the next restock.
+// @Tags Stock subscriptions
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param item_id query int true "Item ID"
+// @Success 200 {string} string "Subscribed"
+// @Failure 400 {object} ErrorResponse "Invalid item ID"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Item not found"
+// @Failure 409 {object} ErrorResponse "Item is in stock"
+// @Failure 500 {object} ErrorResponse "Failed to subscribe"
+// @Router /buyer/subscriptions [post]
+func (h *Handler) SubscribeToStock(c *gin.Context) {
+	// Проверка роли пользователя
+	buyerID := c.GetString("user_id")
+	if role := c.GetString("role"); role != "buyer" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Query("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid item ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	if err := h.services.SubscribeBuyer(buyerID, itemID); err != nil {
+		stockSubscriptionErrorResponse(c, err, "Failed to subscribe: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, "Subscribed") // 200 OK
+}
+
+// GetStockSubscriptions возвращает подписки покупателя на поступление товаров
+// @Summary Get back-in-stock subscriptions
+// @Description Retrieve the current buyer's back-in-stock subscriptions, newest first. notified_at is set once the item has been restocked.
+// @Tags Stock subscriptions
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Success 200 {array} model.StockSubscriptionOutput "Subscriptions"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to get subscriptions"
+// @Router /buyer/subscriptions [get]
+func (h *Handler) GetStockSubscriptions(c *gin.Context) {
+	// Проверка роли пользователя
+	buyerID := c.GetString("user_id")
+	if role := c.GetString("role"); role != "buyer" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	subscriptions, err := h.services.GetBuyerSubscriptions(buyerID)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get subscriptions: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, subscriptions) // 200 OK
+}
+
+// UnsubscribeFromStock отменяет подписку покупателя на поступление товара
+// @Summary Unsubscribe from back-in-stock notification
+// @Description Cancel the current buyer's back-in-stock subscription to an item
+// @Tags Stock subscriptions
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param item_id query int true "Item ID"
+// @Success 200 {string} string "Unsubscribed"
+// @Failure 400 {object} ErrorResponse "Invalid item ID"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Subscription not found"
+// @Failure 500 {object} ErrorResponse "Failed to unsubscribe"
+// @Router /buyer/subscriptions [delete]
+func (h *Handler) UnsubscribeFromStock(c *gin.Context) {
+	// Проверка роли пользователя
+	buyerID := c.GetString("user_id")
+	if role := c.GetString("role"); role != "buyer" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Query("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid item ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	if err := h.services.UnsubscribeBuyer(buyerID, itemID); err != nil {
+		stockSubscriptionErrorResponse(c, err, "Failed to unsubscribe: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, "Unsubscribed") // 200 OK
+}
+
+// SubscribeGuestToStock подписывает гостя на поступление товара по электронной почте
+// @Summary Subscribe to back-in-stock email
+// @Description Subscribe an email address to an out-of-stock item without signing in. A confirmation with an unsubscribe link is sent right away, and an email is sent when the item is restocked. Repeating a subscription that is still waiting for the restock sends nothing. Subscriptions per client IP and confirmations per email address are rate limited.
+// @Tags Stock subscriptions
+// @Accept json
+// @Produce json
+// @Param item_id query int true "Item ID"
+// @Param input body model.StockSubscriptionInput true "Email address"
+// @Success 200 {string} string "Subscribed"
+// @Failure 400 {object} ErrorResponse "Invalid item ID or email"
+// @Failure 404 {object} ErrorResponse "Item not found"
+// @Failure 409 {object} ErrorResponse "Item is in stock"
+// @Failure 429 {object} ErrorResponse "Too many requests"
+// @Failure 500 {object} ErrorResponse "Failed to subscribe"
+// @Router /stock-subscription [post]
+func (h *Handler) SubscribeGuestToStock(c *gin.Context) {
+	itemID, err := strconv.Atoi(c.Query("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid item ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	var input model.StockSubscriptionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	if err := h.services.SubscribeGuest(itemID, input.Email, c.ClientIP()); err != nil {
+		stockSubscriptionErrorResponse(c, err, "Failed to subscribe: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, "Subscribed") // 200 OK
+}
+
+// GetSubscriptionByToken возвращает подписку по ссылке из письма для подтверждения отписки
+// @Summary Get subscription by email link
+// @Description Retrieve the email address and item of a back-in-stock subscription using the token from the unsubscribe link in an email. The subscription is not changed: mail scanners open links, so unsubscribing requires a POST to the same path.
+// @Tags Stock subscriptions
+// @Produce json
+// @Param token query string true "Unsubscribe token"
+// @Success 200 {object} model.GuestSubscriptionOutput "Subscription"
+// @Failure 404 {object} ErrorResponse "Subscription not found"
+// @Failure 500 {object} ErrorResponse "Failed to get subscription"
+// @Router /stock-subscription/unsubscribe [get]
+func (h *Handler) GetSubscriptionByToken(c *gin.Context) {
+	subscription, err := h.services.GetSubscriptionByToken(c.Query("token"))
+	if err != nil {
+		stockSubscriptionErrorResponse(c, err, "Failed to get subscription: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription) // 200 OK
+}
+
+// UnsubscribeByToken отменяет подписку по ссылке из письма после подтверждения
+// @Summary Unsubscribe by email link
+// @Description Cancel a back-in-stock subscription using the token from the unsubscribe link in an email
+// @Tags Stock subscriptions
+// @Produce json
+// @Param token query string true "Unsubscribe token"
+// @Success 200 {string} string "Unsubscribed"
+// @Failure 404 {object} ErrorResponse "Subscription not found"
+// @Failure 500 {object} ErrorResponse "Failed to unsubscribe"
+// @Router /stock-subscription/unsubscribe [post]
+func (h *Handler) UnsubscribeByToken(c *gin.Context) {
+	if err := h.services.Unsubscribe(c.Query("token")); err != nil {
+		stockSubscriptionErrorResponse(c, err, "Failed to unsubscribe: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, "Unsubscribed") // 200 OK
+}
+
+func stockSubscriptionErrorResponse(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidEmail):
+		newErrorResponse(c, http.StatusBadRequest, err.Error()) // 400 Bad Request
+	case errors.Is(err, service.ErrItemNotFound), errors.Is(err, service.ErrSubscriptionNotFound):
+		newErrorResponse(c, http.StatusNotFound, err.Error()) // 404 Not Found
+	case errors.Is(err, service.ErrItemInStock):
+		newErrorResponse(c, http.StatusConflict, err.Error()) // 409 Conflict
+	case errors.Is(err, service.ErrTooManyRequests):
+		newErrorResponse(c, http.StatusTooManyRequests, err.Error()) // 429 Too Many Requests
+	default:
+		newErrorResponse(c, http.StatusInternalServerError, message+err.Error()) // 500 Internal Server Error
+	}
+}
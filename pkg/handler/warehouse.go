@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"stroycity/pkg/model"
+	"stroycity/pkg/service"
+)
+
+// CreateWarehouse создаёт склад продавца
+// @Summary Create a warehouse
+// @Description Create a seller warehouse (yard) with address, coordinates and pickup option
+// @Tags Warehouses
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param input body model.WarehouseInput true "Warehouse data"
+// @Success 201 {string} string "WarehouseID"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Router /seller/warehouse [post]
+func (h *Handler) CreateWarehouse(c *gin.Context) {
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	var input model.WarehouseInput
+	// Валидация JSON данных
+	if err := c.ShouldBindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	warehouseID, err := h.services.CreateWarehouse(sellerId, input)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Failed to create warehouse: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	c.JSON(http.StatusCreated, strconv.Itoa(warehouseID)) // 201 Created
+}
+
+// GetWarehouses возвращает склады продавца
+// @Summary Get seller warehouses
+// @Description Retrieve all warehouses of the current seller
+// @Tags Warehouses
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Success 200 {array} model.WarehouseOutput "Warehouses"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 500 {object} ErrorResponse "Failed to get warehouses"
+// @Router /seller/warehouse [get]
+func (h *Handler) GetWarehouses(c *gin.Context) {
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	warehouses, err := h.services.GetWarehouses(sellerId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to get warehouses: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, warehouses) // 200 OK
+}
+
+// UpdateWarehouse обновляет склад продавца
+// @Summary Update a warehouse
+// @Description Update name, address, coordinates and pickup option of a seller warehouse
+// @Tags Warehouses
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param id query string true "Warehouse ID"
+// @Param input body model.WarehouseInput true "Warehouse data"
+// @Success 200 {string} string "Warehouse updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Warehouse not found"
+// @Router /seller/warehouse [put]
+func (h *Handler) UpdateWarehouse(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid warehouse ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	var input model.WarehouseInput
+	// Валидация JSON данных
+	if err := c.ShouldBindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	err = h.services.UpdateWarehouse(sellerId, id, input)
+	if errors.Is(err, service.ErrWarehouseNotFound) {
+		newErrorResponse(c, http.StatusNotFound, err.Error()) // 404 Not Found
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Failed to update warehouse: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	c.JSON(http.StatusOK, "Warehouse updated successfully") // 200 OK
+}
+
+// DeleteWarehouse удаляет склад продавца
+// @Summary Delete a warehouse
+// @Description Delete a seller warehouse together with its stock; item quantities are recalculated from the remaining warehouses
+// @Tags Warehouses
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param id query string true "Warehouse ID"
+// @Success 200 {string} string "Warehouse deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid warehouse ID"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Warehouse not found"
+// @Failure 500 {object} ErrorResponse "Failed to delete warehouse"
+// @Router /seller/warehouse [delete]
+func (h *Handler) DeleteWarehouse(c *gin.Context) {
+	id, err := strconv.Atoi(c.Query("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid warehouse ID: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	err = h.services.DeleteWarehouse(sellerId, id)
+	if errors.Is(err, service.ErrWarehouseNotFound) {
+		newErrorResponse(c, http.StatusNotFound, err.Error()) // 404 Not Found
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, "Failed to delete warehouse: "+err.Error()) // 500 Internal Server Error
+		return
+	}
+
+	c.JSON(http.StatusOK, "Warehouse deleted successfully") // 200 OK
+}
+
+// SetWarehouseStock задаёт остаток товара на складе
+// @Summary Set item stock at a warehouse
+// @Description Set the quantity of an item at one of the seller's warehouses. Once an item has per-warehouse stock, its quantity is the sum over warehouses.
+// @Tags Warehouses
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer {JWT}"
+// @Param input body model.WarehouseStockInput true "Item, warehouse and quantity"
+// @Success 200 {string} string "Stock updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid input data"
+// @Failure 401 {object} ErrorResponse "You are not authorized to access this resource"
+// @Failure 404 {object} ErrorResponse "Item or warehouse not found"
+// @Failure 500 {object} ErrorResponse "Failed to update stock"
+// @Router /seller/warehouse/stock [put]
+func (h *Handler) SetWarehouseStock(c *gin.Context) {
+	// Проверка роли пользователя
+	sellerId := c.GetString("user_id")
+	if role := c.GetString("role"); role != "seller" {
+		newErrorResponse(c, http.StatusUnauthorized, "You are not authorized to access this resource") // 401 Unauthorized
+		return
+	}
+
+	var input model.WarehouseStockInput
+	// Валидация JSON данных
+	if err := c.ShouldBindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input data: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	err := h.services.SetWarehouseStock(sellerId, input)
+	if errors.Is(err, service.ErrWarehouseNotFound) || errors.Is(err, service.ErrItemNotFound) {
+		newErrorResponse(c, http.StatusNotFound, err.Error()) // 404 Not Found
+		return
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Failed to update stock: "+err.Error()) // 400 Bad Request
+		return
+	}
+
+	c.JSON(http.StatusOK, "Stock updated successfully") // 200 OK
+}
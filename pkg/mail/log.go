@@ -0,0 +1,18 @@
+package mail
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+)
+
+// LogSender пишет письма в журнал вместо отправки — для разработки и тестовых стендов
+type LogSender struct{}
+
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+func (s *LogSender) Send(_ context.Context, to, subject, body string) error {
+	logrus.Infof("mail to %s: %s\n%s", to, subject, body)
+	return nil
+}
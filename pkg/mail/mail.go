@@ -0,0 +1,33 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+)
+
+// Sender отправляет письма пользователям, в том числе гостям без учётной записи
+type Sender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+type Config struct {
+	Driver string
+
+	// SMTP-сервер
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func NewSender(cfg Config) (Sender, error) {
+	switch cfg.Driver {
+	case "", "log":
+		return NewLogSender(), nil
+	case "smtp":
+		return NewSMTPSender(cfg)
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", cfg.Driver)
+	}
+}
@@ -0,0 +1,62 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+type SMTPSender struct {
+	addr string
+	auth smtp.Auth
+	// from — заголовок письма с именем отправителя, envelope — адрес для SMTP-сессии
+	from     string
+	envelope string
+}
+
+func NewSMTPSender(cfg Config) (*SMTPSender, error) {
+	if cfg.Host == "" || cfg.From == "" {
+		return nil, errors.New("smtp host and sender address are required")
+	}
+	port := cfg.Port
+	if port == "" {
+		port = "587"
+	}
+
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	sender := &SMTPSender{addr: net.JoinHostPort(cfg.Host, port), from: from.String(), envelope: from.Address}
+	if cfg.Username != "" {
+		sender.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return sender, nil
+}
+
+// Send отправляет текстовое письмо в UTF-8. Контекст не прерывает уже начатую отправку:
+// net/smtp его не поддерживает, поэтому он проверяется только перед соединением.
+func (s *SMTPSender) Send(ctx context.Context, to, subject, body string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(body)
+
+	return smtp.SendMail(s.addr, s.auth, s.envelope, []string{to}, msg.Bytes())
+}
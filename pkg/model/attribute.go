@@ -0,0 +1,99 @@
+package model
+
+const (
+	AttributeTypeNumber  = "number"
+	AttributeTypeEnum    = "enum"
+	AttributeTypeBoolean = "boolean"
+)
+
+type Attribute struct {
+	ID         int               `json:"id" gorm:"autoIncrement;primaryKey"`
+	CategoryID int               `json:"category_id" gorm:"not null;index"`
+	Name       string            `json:"name" gorm:"not null"`
+	Type       string            `json:"type" gorm:"not null"`
+	Unit       string            `json:"unit"`
+	Options    []AttributeOption `json:"options" gorm:"foreignKey:AttributeID;constraint:OnDelete:CASCADE"`
+
+	Category Category `json:"-" gorm:"foreignKey:CategoryID"`
+}
+
+type AttributeOption struct {
+	ID          int    `json:"id" gorm:"autoIncrement;primaryKey"`
+	AttributeID int    `json:"attribute_id" gorm:"not null;index"`
+	Value       string `json:"value" gorm:"not null"`
+}
+
+// ItemAttribute хранит значение характеристики конкретного товара.
+// Заполняется только поле, соответствующее типу характеристики.
+type ItemAttribute struct {
+	ID          int      `json:"-" gorm:"autoIncrement;primaryKey"`
+	ItemID      int      `json:"-" gorm:"not null;uniqueIndex:idx_item_attribute"`
+	AttributeID int      `json:"attribute_id" gorm:"not null;uniqueIndex:idx_item_attribute"`
+	NumberValue *float64 `json:"number_value,omitempty"`
+	BoolValue   *bool    `json:"bool_value,omitempty"`
+	EnumValue   string   `json:"enum_value,omitempty"`
+
+	Attribute Attribute `json:"-" gorm:"foreignKey:AttributeID;constraint:OnDelete:CASCADE"`
+}
+
+type AttributeInput struct {
+	CategoryID int      `json:"category_id"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type" enums:"number,enum,boolean"`
+	Unit       string   `json:"unit"`
+	Options    []string `json:"options"`
+}
+
+type AttributeOutput struct {
+	ID         int      `json:"id"`
+	CategoryID int      `json:"category_id"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Unit       string   `json:"unit"`
+	Options    []string `json:"options"`
+}
+
+type ItemAttributeInput struct {
+	AttributeID int      `json:"attribute_id"`
+	NumberValue *float64 `json:"number_value,omitempty"`
+	BoolValue   *bool    `json:"bool_value,omitempty"`
+	EnumValue   string   `json:"enum_value,omitempty"`
+}
+
+type ItemAttributeInfo struct {
+	AttributeID int      `json:"attribute_id"`
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Unit        string   `json:"unit"`
+	NumberValue *float64 `json:"number_value,omitempty"`
+	BoolValue   *bool    `json:"bool_value,omitempty"`
+	EnumValue   string   `json:"enum_value,omitempty"`
+}
+
+// AttributeFilter задаёт условие по характеристике в FilterRequest:
+// диапазон для числовых, список значений для перечислений и флаг для логических.
+type AttributeFilter struct {
+	AttributeID int      `json:"attribute_id"`
+	Min         *float64 `json:"min,omitempty"`
+	Max         *float64 `json:"max,omitempty"`
+	Values      []string `json:"values,omitempty"`
+	Bool        *bool    `json:"bool,omitempty"`
+}
+
+func ConvertItemAttributesToInfo(attributes []ItemAttribute) []ItemAttributeInfo {
+	var infos []ItemAttributeInfo
+
+	for _, attribute := range attributes {
+		infos = append(infos, ItemAttributeInfo{
+			AttributeID: attribute.AttributeID,
+			Name:        attribute.Attribute.Name,
+			Type:        attribute.Attribute.Type,
+			Unit:        attribute.Attribute.Unit,
+			NumberValue: attribute.NumberValue,
+			BoolValue:   attribute.BoolValue,
+			EnumValue:   attribute.EnumValue,
+		})
+	}
+
+	return infos
+}
@@ -1,16 +1,30 @@
 package model
 
 type Brand struct {
-	ID    int    `json:"id" gorm:"autoIncrement;primaryKey"`
-	Name  string `json:"name" gorm:"not null"`
-	Items []Item `json:"items" gorm:"foreignKey:BrandID"`
+	ID              int    `json:"id" gorm:"autoIncrement;primaryKey"`
+	Name            string `json:"name" gorm:"not null"`
+	Description     string `json:"description"`
+	LogoKey         string `json:"-"`
+	Slug            string `json:"slug" gorm:"uniqueIndex:idx_brands_slug_unique,where:slug <> ''"`
+	MetaTitle       string `json:"meta_title"`
+	MetaDescription string `json:"meta_description"`
+	Items           []Item `json:"items" gorm:"foreignKey:BrandID"`
 }
 
 type BrandInput struct {
-	Name string `json:"name"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	MetaTitle       string `json:"meta_title"`
+	MetaDescription string `json:"meta_description"`
 }
 
 type BrandOutput struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	Description     string `json:"description,omitempty"`
+	LogoURL         string `json:"logo_url,omitempty"`
+	Slug            string `json:"slug"`
+	CanonicalURL    string `json:"canonical_url"`
+	MetaTitle       string `json:"meta_title"`
+	MetaDescription string `json:"meta_description"`
 }
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// Bundle — комплект из нескольких товаров продавца, который продаётся по своей цене
+// (например, «набор для гидроизоляции ванной»). Собственного остатка у комплекта нет:
+// доступное количество определяется остатками компонентов.
+type Bundle struct {
+	ID          int       `json:"id" gorm:"autoIncrement;primaryKey"`
+	SellerID    string    `json:"seller_id" gorm:"not null;index"`
+	CategoryID  int       `json:"category_id" gorm:"not null;index"`
+	Name        string    `json:"name" gorm:"not null"`
+	Description string    `json:"description" gorm:"not null;default:''"`
+	Price       Money     `json:"price" gorm:"not null" swaggertype:"number"`
+	Currency    string    `json:"currency" gorm:"not null;default:RUB"`
+	Status      string    `json:"status" gorm:"not null;default:published;index"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Category   Category          `json:"-" gorm:"foreignKey:CategoryID"`
+	Seller     Seller            `json:"-" gorm:"foreignKey:SellerID"`
+	Components []BundleComponent `json:"components" gorm:"foreignKey:BundleID;constraint:OnDelete:CASCADE"`
+}
+
+// BundleComponent — товар, входящий в комплект, и его количество в одном комплекте
+type BundleComponent struct {
+	ID       int  `json:"-" gorm:"autoIncrement;primaryKey"`
+	BundleID int  `json:"-" gorm:"not null;index"`
+	ItemID   int  `json:"item_id" gorm:"not null;index"`
+	Quantity int  `json:"quantity" gorm:"not null"`
+	Item     Item `json:"-" gorm:"foreignKey:ItemID"`
+}
+
+type BundleInput struct {
+	Name        string                 `json:"name" binding:"required"`
+	Description string                 `json:"description"`
+	CategoryID  int                    `json:"category_id" binding:"required"`
+	Price       Money                  `json:"price" binding:"required" swaggertype:"number"`
+	Status      string                 `json:"status" enums:"draft,published,archived"`
+	Components  []BundleComponentInput `json:"components" binding:"required"`
+}
+
+type BundleComponentInput struct {
+	ItemID   int `json:"item_id"`
+	Quantity int `json:"quantity"`
+}
+
+// BundleInfo — комплект для каталога. ComponentsPrice — стоимость компонентов по отдельности,
+// Savings — выгода покупки комплектом; Available — сколько комплектов можно собрать из остатков.
+type BundleInfo struct {
+	ID              int                   `json:"id"`
+	Name            string                `json:"name"`
+	Description     string                `json:"description"`
+	Price           Money                 `json:"price" swaggertype:"number"`
+	ComponentsPrice Money                 `json:"components_price" swaggertype:"number"`
+	Savings         Money                 `json:"savings" swaggertype:"number"`
+	Currency        string                `json:"currency"`
+	Status          string                `json:"status"`
+	CategoryID      int                   `json:"category_id"`
+	Category        string                `json:"category"`
+	SellerID        string                `json:"seller_id"`
+	Available       int                   `json:"available"`
+	Images          []string              `json:"images"`
+	Components      []BundleComponentInfo `json:"components"`
+}
+
+type BundleComponentInfo struct {
+	ItemID    int    `json:"item_id"`
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	Quantity  int    `json:"quantity"`
+	Price     Money  `json:"price" swaggertype:"number"`
+	Unit      string `json:"unit"`
+	Available int    `json:"available"`
+}
+
+// CartBundle — комплект в корзине покупателя
+type CartBundle struct {
+	ID       int    `json:"id" gorm:"autoIncrement;primaryKey"`
+	BuyerID  string `json:"buyer_id" gorm:"not null;uniqueIndex:idx_cart_bundle"`
+	BundleID int    `json:"bundle_id" gorm:"not null;uniqueIndex:idx_cart_bundle"`
+	Quantity int    `json:"quantity" gorm:"not null"`
+	Bundle   Bundle `gorm:"foreignKey:BundleID"`
+}
+
+type CartBundleInfo struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Price    Money  `json:"price" swaggertype:"number"`
+	Quantity int    `json:"quantity"`
+	Total    Money  `json:"total" swaggertype:"number"`
+}
+
+type AddBundleToCartInput struct {
+	BundleID int `json:"bundle_id"`
+	Quantity int `json:"quantity"`
+}
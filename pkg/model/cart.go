@@ -16,15 +16,22 @@ type Cart struct {
 }
 
 type CartOutput struct {
-	BuyerID string         `json:"buyer_id"`
-	Items   []CartItemInfo `json:"items"`
+	BuyerID  string           `json:"buyer_id"`
+	Items    []CartItemInfo   `json:"items"`
+	Bundles  []CartBundleInfo `json:"bundles"`
+	Total    Money            `json:"total" swaggertype:"number"`
+	Currency string           `json:"currency"`
 }
 
 type CartItemInfo struct {
-	ID       int     `json:"id"`
-	Name     string  `json:"name"`
-	Price    float64 `json:"price"`
-	Quantity int     `json:"quantity"`
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	Price        Money   `json:"price" swaggertype:"number"`
+	Quantity     int     `json:"quantity"`
+	Total        Money   `json:"total" swaggertype:"number"`
+	Unit         string  `json:"unit"`
+	BaseUnit     string  `json:"base_unit,omitempty"`
+	BaseQuantity float64 `json:"base_quantity,omitempty"`
 }
 
 type AddToCartInput struct {
@@ -1,16 +1,25 @@
 package model
 
 type Category struct {
-	ID    int    `json:"id" gorm:"autoIncrement;primaryKey"`
-	Name  string `json:"name" gorm:"not null"`
-	Items []Item `json:"items" gorm:"foreignKey:CategoryID"`
+	ID              int    `json:"id" gorm:"autoIncrement;primaryKey"`
+	Name            string `json:"name" gorm:"not null"`
+	Slug            string `json:"slug" gorm:"uniqueIndex:idx_categories_slug_unique,where:slug <> ''"`
+	MetaTitle       string `json:"meta_title"`
+	MetaDescription string `json:"meta_description"`
+	Items           []Item `json:"items" gorm:"foreignKey:CategoryID"`
 }
 
 type CategoryInput struct {
-	Name string `json:"name"`
+	Name            string `json:"name"`
+	MetaTitle       string `json:"meta_title"`
+	MetaDescription string `json:"meta_description"`
 }
 
 type CategoryOutput struct {
-	Id   int    `json:"id"`
-	Name string `json:"name"`
+	Id              int    `json:"id"`
+	Name            string `json:"name"`
+	Slug            string `json:"slug"`
+	CanonicalURL    string `json:"canonical_url"`
+	MetaTitle       string `json:"meta_title"`
+	MetaDescription string `json:"meta_description"`
 }
@@ -0,0 +1,10 @@
+package model
+
+// CommerceMLResult — итог загрузки файла обмена CommerceML из 1С
+type CommerceMLResult struct {
+	Linked   int      `json:"linked"`
+	Updated  int      `json:"updated"`
+	NotFound int      `json:"not_found"`
+	Failed   int      `json:"failed"`
+	Errors   []string `json:"errors"`
+}
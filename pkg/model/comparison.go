@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// MaxComparisonItems — сколько товаров одной категории можно сравнивать одновременно
+const MaxComparisonItems = 10
+
+// Группы строк таблицы сравнения
+const (
+	ComparisonGroupMain       = "main"
+	ComparisonGroupDimensions = "dimensions"
+	ComparisonGroupAttributes = "attributes"
+)
+
+// ComparisonItem — товар в списке сравнения покупателя (BuyerID) или гостя (DeviceToken).
+// Списки ведутся по категориям: категория берётся из самого товара.
+type ComparisonItem struct {
+	ID          int       `json:"id" gorm:"autoIncrement;primaryKey"`
+	BuyerID     string    `json:"buyer_id" gorm:"not null;default:'';uniqueIndex:idx_comparison_item"`
+	DeviceToken string    `json:"-" gorm:"not null;default:'';uniqueIndex:idx_comparison_item"`
+	ItemID      int       `json:"item_id" gorm:"not null;uniqueIndex:idx_comparison_item"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Item        Item      `json:"-" gorm:"foreignKey:ItemID"`
+}
+
+// ComparisonCategory — список сравнения одной категории
+type ComparisonCategory struct {
+	CategoryID int        `json:"category_id"`
+	Category   string     `json:"category"`
+	Items      []ItemInfo `json:"items"`
+}
+
+// ComparisonRow — строка таблицы сравнения: значения идут в порядке товаров таблицы,
+// пустая строка — значение у товара не задано
+type ComparisonRow struct {
+	Key     string   `json:"key"`
+	Label   string   `json:"label"`
+	Group   string   `json:"group" enums:"main,dimensions,attributes"`
+	Values  []string `json:"values"`
+	Differs bool     `json:"differs"`
+}
+
+// ComparisonTable — таблица сравнения товаров одной категории
+type ComparisonTable struct {
+	CategoryID int             `json:"category_id"`
+	Category   string          `json:"category"`
+	Items      []ItemInfo      `json:"items"`
+	Rows       []ComparisonRow `json:"rows"`
+}
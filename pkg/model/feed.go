@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+const (
+	FeedFormatYML = "yml"
+	FeedFormatCSV = "csv"
+)
+
+// Feed — сгенерированная выгрузка каталога для агрегаторов.
+// ModifiedAt меняется только при изменении содержимого, поэтому подходит для Last-Modified.
+type Feed struct {
+	Data        []byte
+	ContentType string
+	ETag        string
+	ModifiedAt  time.Time
+}
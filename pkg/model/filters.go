@@ -1,11 +1,22 @@
 package model
 
+// Сортировки списка товаров; без сортировки порядок не гарантируется
+const (
+	ItemSortPopular   = "popular"
+	ItemSortPriceAsc  = "price_asc"
+	ItemSortPriceDesc = "price_desc"
+	ItemSortNewest    = "newest"
+)
+
 type FilterRequest struct {
-	BrandIDs    []uint  `json:"brands"`
-	SellerIDs   []uint  `json:"sellers"`
-	CategoryIDs []uint  `json:"categories"`
-	MaterialIDs []uint  `json:"materials"`
-	MinPrice    float64 `json:"min_price"`
-	MaxPrice    float64 `json:"max_price"`
-	Query       string  `json:"query"`
+	BrandIDs    []uint `json:"brands"`
+	SellerIDs   []uint `json:"sellers"`
+	CategoryIDs []uint `json:"categories"`
+	MaterialIDs []uint `json:"materials"`
+	MinPrice    Money  `json:"min_price" swaggertype:"number"`
+	MaxPrice    Money  `json:"max_price" swaggertype:"number"`
+	Query       string `json:"query"`
+	Sort        string `json:"sort" enums:"popular,price_asc,price_desc,newest"`
+
+	Attributes []AttributeFilter `json:"attributes"`
 }
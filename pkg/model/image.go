@@ -1,9 +1,97 @@
 package model
 
+import "sort"
+
+// URLResolver строит ссылку на файл по ключу объекта в хранилище
+type URLResolver func(key string) string
+
+// Image хранит ключ файла в хранилище, а не ссылку: ссылка строится при выдаче через URLResolver
 type Image struct {
-	ID     int    `json:"id" gorm:"autoIncrement;primaryKey"`
-	ItemID int    `json:"item_id" gorm:"not null"`
-	URL    string `json:"url" gorm:"not null"`
+	ID         int              `json:"id" gorm:"autoIncrement;primaryKey"`
+	ItemID     int              `json:"item_id" gorm:"not null"`
+	StorageKey string           `json:"-" gorm:"not null"`
+	Width      int              `json:"width" gorm:"default:0"`
+	Height     int              `json:"height" gorm:"default:0"`
+	Position   int              `json:"position" gorm:"default:0"`
+	IsPrimary  bool             `json:"is_primary" gorm:"default:false"`
+	Renditions []ImageRendition `json:"renditions" gorm:"foreignKey:ImageID;constraint:OnDelete:CASCADE"`
 
 	Item Item `gorm:"foreignKey:ItemID"`
 }
+
+// ImageRendition — уменьшенная копия изображения (thumbnail, medium, large)
+type ImageRendition struct {
+	ID         int    `json:"-" gorm:"autoIncrement;primaryKey"`
+	ImageID    int    `json:"-" gorm:"not null;index"`
+	Size       string `json:"size" gorm:"not null"`
+	StorageKey string `json:"-" gorm:"not null"`
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
+}
+
+type ImageInfo struct {
+	ID         int                  `json:"id"`
+	URL        string               `json:"url"`
+	Width      int                  `json:"width"`
+	Height     int                  `json:"height"`
+	Position   int                  `json:"position"`
+	IsPrimary  bool                 `json:"is_primary"`
+	Renditions []ImageRenditionInfo `json:"renditions"`
+}
+
+type ImageRenditionInfo struct {
+	Size   string `json:"size"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+func ConvertImageToInfo(image Image, resolveURL URLResolver) ImageInfo {
+	imageInfo := ImageInfo{
+		ID:        image.ID,
+		URL:       resolveURL(image.StorageKey),
+		Width:     image.Width,
+		Height:    image.Height,
+		Position:  image.Position,
+		IsPrimary: image.IsPrimary,
+	}
+	for _, rendition := range image.Renditions {
+		imageInfo.Renditions = append(imageInfo.Renditions, ImageRenditionInfo{
+			Size:   rendition.Size,
+			URL:    resolveURL(rendition.StorageKey),
+			Width:  rendition.Width,
+			Height: rendition.Height,
+		})
+	}
+	return imageInfo
+}
+
+func ConvertImagesToInfo(images []Image, resolveURL URLResolver) []ImageInfo {
+	var imageInfos []ImageInfo
+
+	for _, image := range SortImages(images) {
+		imageInfos = append(imageInfos, ConvertImageToInfo(image, resolveURL))
+	}
+
+	return imageInfos
+}
+
+// SortImages возвращает изображения в порядке показа: сначала основное, затем по позиции
+func SortImages(images []Image) []Image {
+	sorted := append([]Image(nil), images...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].IsPrimary != sorted[j].IsPrimary {
+			return sorted[i].IsPrimary
+		}
+		if sorted[i].Position != sorted[j].Position {
+			return sorted[i].Position < sorted[j].Position
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
+
+type ImageOrderInput struct {
+	ItemID   int   `json:"item_id"`
+	ImageIDs []int `json:"image_ids"`
+}
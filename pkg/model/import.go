@@ -0,0 +1,99 @@
+package model
+
+import "time"
+
+const (
+	ImportStatusPending    = "pending"
+	ImportStatusProcessing = "processing"
+	ImportStatusCompleted  = "completed"
+	ImportStatusFailed     = "failed"
+)
+
+// Поля ItemInput, которые можно заполнить из колонок файла импорта.
+// Категория, бренд и материал задаются названием, а не ID.
+const (
+	ImportFieldArticle           = "article"
+	ImportFieldName              = "name"
+	ImportFieldDescription       = "description"
+	ImportFieldPrice             = "price"
+	ImportFieldPriceWithDiscount = "price_with_discount"
+	ImportFieldCurrency          = "currency"
+	ImportFieldQuantity          = "quantity"
+	ImportFieldLength            = "length"
+	ImportFieldWidth             = "width"
+	ImportFieldHeight            = "height"
+	ImportFieldWeight            = "weight"
+	ImportFieldUnit              = "unit"
+	ImportFieldBaseUnit          = "base_unit"
+	ImportFieldUnitRatio         = "unit_ratio"
+	ImportFieldMinQuantity       = "min_quantity"
+	ImportFieldQuantityStep      = "quantity_step"
+	ImportFieldCategory          = "category"
+	ImportFieldBrand             = "brand"
+	ImportFieldMaterial          = "material"
+)
+
+// ImportJob — задача асинхронного импорта каталога продавца из CSV/XLSX
+type ImportJob struct {
+	ID            int        `json:"id" gorm:"autoIncrement;primaryKey"`
+	SellerID      string     `json:"seller_id" gorm:"not null;index"`
+	FileName      string     `json:"file_name"`
+	Status        string     `json:"status" gorm:"not null;default:pending"`
+	TotalRows     int        `json:"total_rows" gorm:"default:0"`
+	ProcessedRows int        `json:"processed_rows" gorm:"default:0"`
+	CreatedCount  int        `json:"created_count" gorm:"default:0"`
+	UpdatedCount  int        `json:"updated_count" gorm:"default:0"`
+	FailedCount   int        `json:"failed_count" gorm:"default:0"`
+	Error         string     `json:"error"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	FinishedAt    *time.Time `json:"finished_at"`
+
+	Errors []ImportRowError `json:"-" gorm:"foreignKey:JobID;constraint:OnDelete:CASCADE"`
+}
+
+// ImportRowError — ошибка проверки строки файла импорта
+type ImportRowError struct {
+	ID      int    `json:"-" gorm:"autoIncrement;primaryKey"`
+	JobID   int    `json:"-" gorm:"not null;index"`
+	Row     int    `json:"row"`
+	Article string `json:"article"`
+	Message string `json:"message"`
+}
+
+type ImportJobOutput struct {
+	ID            int        `json:"id"`
+	FileName      string     `json:"file_name"`
+	Status        string     `json:"status" enums:"pending,processing,completed,failed"`
+	TotalRows     int        `json:"total_rows"`
+	ProcessedRows int        `json:"processed_rows"`
+	Progress      int        `json:"progress"`
+	CreatedCount  int        `json:"created_count"`
+	UpdatedCount  int        `json:"updated_count"`
+	FailedCount   int        `json:"failed_count"`
+	Error         string     `json:"error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty"`
+}
+
+func ConvertImportJobToOutput(job ImportJob) ImportJobOutput {
+	output := ImportJobOutput{
+		ID:            job.ID,
+		FileName:      job.FileName,
+		Status:        job.Status,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		CreatedCount:  job.CreatedCount,
+		UpdatedCount:  job.UpdatedCount,
+		FailedCount:   job.FailedCount,
+		Error:         job.Error,
+		CreatedAt:     job.CreatedAt,
+		FinishedAt:    job.FinishedAt,
+	}
+	// Прогресс в процентах для опроса клиентом
+	if job.TotalRows > 0 {
+		output.Progress = job.ProcessedRows * 100 / job.TotalRows
+	} else if job.Status == ImportStatusCompleted {
+		output.Progress = 100
+	}
+	return output
+}
@@ -0,0 +1,36 @@
+package model
+
+const (
+	InventoryStatusUpdated  = "updated"
+	InventoryStatusNotFound = "not_found"
+	InventoryStatusConflict = "conflict"
+	InventoryStatusInvalid  = "invalid"
+	// Строка не применена из-за внутренней ошибки; остальные строки обрабатываются дальше
+	InventoryStatusError = "error"
+)
+
+// InventoryUpdate — строка массового обновления остатков и цен.
+// Товар задаётся item_id или article; незаполненные поля не меняются.
+// Если передана version, строка применяется только к товару этой версии.
+// Для товара с остатками по складам quantity задаётся вместе с warehouse_id.
+type InventoryUpdate struct {
+	ItemID            int    `json:"item_id"`
+	Article           string `json:"article"`
+	WarehouseID       *int   `json:"warehouse_id"`
+	Quantity          *int   `json:"quantity"`
+	Price             *Money `json:"price" swaggertype:"number"`
+	PriceWithDiscount *Money `json:"price_with_discount" swaggertype:"number"`
+	Version           *int   `json:"version"`
+
+	// Причина изменения остатка для журнала движений
+	Reason string `json:"reason"`
+}
+
+type InventoryUpdateResult struct {
+	Index   int    `json:"index"`
+	ItemID  int    `json:"item_id,omitempty"`
+	Article string `json:"article,omitempty"`
+	Status  string `json:"status" enums:"updated,not_found,conflict,invalid,error"`
+	Version int    `json:"version,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
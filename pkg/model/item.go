@@ -1,21 +1,48 @@
 package model
 
+import "gorm.io/gorm"
+
+// Статусы жизненного цикла товара. В каталоге и выгрузках показываются только опубликованные товары,
+// но товар в любом статусе и даже удалённый остаётся доступен для истории заказов.
+const (
+	ItemStatusDraft      = "draft"
+	ItemStatusModeration = "moderation"
+	ItemStatusPublished  = "published"
+	ItemStatusArchived   = "archived"
+)
+
 type Item struct {
 	ID                int     `json:"id" gorm:"autoIncrement;primaryKey"`
 	Name              string  `json:"name" gorm:"not null"`
 	Description       string  `json:"description" gorm:"not null"`
 	Article           string  `json:"article"`
-	Price             float64 `json:"price" gorm:"not null"`
-	PriceWithDiscount float64 `json:"price_with_discount" gorm:"not null"`
+	Price             Money   `json:"price" gorm:"not null" swaggertype:"number"`
+	PriceWithDiscount Money   `json:"price_with_discount" gorm:"not null" swaggertype:"number"`
+	Currency          string  `json:"currency" gorm:"not null;default:RUB"`
 	Quantity          int     `json:"quantity" gorm:"default:0"`
 	Length            int     `json:"length" gorm:"default:0"`
 	Width             int     `json:"width" gorm:"default:0"`
 	Height            int     `json:"height" gorm:"default:0"`
 	Weight            int     `json:"weight" gorm:"default:0"`
+	Unit              string  `json:"unit" gorm:"not null;default:pcs"`
+	BaseUnit          string  `json:"base_unit"`
+	UnitRatio         float64 `json:"unit_ratio" gorm:"not null;default:1"`
+	MinQuantity       int     `json:"min_quantity" gorm:"not null;default:1"`
+	QuantityStep      int     `json:"quantity_step" gorm:"not null;default:1"`
 	CategoryID        int     `json:"category_id" gorm:"not null"`
 	BrandID           int     `json:"brand_id" gorm:"not null"`
 	SellerID          string  `json:"seller_id" gorm:"not null"`
 	MaterialID        int     `json:"material_id" gorm:"not null"`
+	ProductID         *int    `json:"product_id" gorm:"index"`
+	ExternalID        string  `json:"external_id,omitempty" gorm:"index"`
+	Version           int     `json:"version" gorm:"not null;default:1"`
+	LowStockThreshold int     `json:"low_stock_threshold" gorm:"not null;default:0"`
+	Status            string  `json:"status" gorm:"not null;default:published;index"`
+	Slug              string  `json:"slug" gorm:"uniqueIndex:idx_items_slug_unique,where:slug <> ''"`
+	MetaTitle         string  `json:"meta_title"`
+	MetaDescription   string  `json:"meta_description"`
+
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
 	Category    Category `gorm:"foreignKey:CategoryID"`
 	Brand       Brand    `gorm:"foreignKey:BrandID"`
@@ -23,53 +50,107 @@ type Item struct {
 	Material    Material `gorm:"foreignKey:MaterialID"`
 	FavoritedBy []Buyer  `gorm:"many2many:buyer_favorites"`
 	Images      []Image  `gorm:"foreignKey:ItemID"`
+
+	Attributes    []ItemAttribute `json:"attributes" gorm:"foreignKey:ItemID;constraint:OnDelete:CASCADE"`
+	VariantValues []VariantValue  `json:"variant_values" gorm:"foreignKey:ItemID;constraint:OnDelete:CASCADE"`
+	PriceTiers    []PriceTier     `json:"price_tiers" gorm:"foreignKey:ItemID;constraint:OnDelete:CASCADE"`
 }
 
 type ItemInput struct {
 	Name              string  `json:"name"`
 	Description       string  `json:"description"`
 	Article           string  `json:"article"`
-	Price             float64 `json:"price"`
-	PriceWithDiscount float64 `json:"price_with_discount"`
+	Price             Money   `json:"price" swaggertype:"number"`
+	PriceWithDiscount Money   `json:"price_with_discount" swaggertype:"number"`
+	Currency          string  `json:"currency"`
 	Quantity          int     `json:"quantity"`
 	Length            int     `json:"length"`
 	Width             int     `json:"width"`
 	Height            int     `json:"height"`
 	Weight            int     `json:"weight"`
+	Unit              string  `json:"unit"`
+	BaseUnit          string  `json:"base_unit"`
+	UnitRatio         float64 `json:"unit_ratio"`
+	MinQuantity       int     `json:"min_quantity"`
+	QuantityStep      int     `json:"quantity_step"`
 	CategoryID        int     `json:"category_id"`
 	BrandID           int     `json:"brand_id"`
 	MaterialID        int     `json:"material_id"`
+	LowStockThreshold int     `json:"low_stock_threshold"`
+	Status            string  `json:"status" enums:"draft,published"`
+	MetaTitle         string  `json:"meta_title"`
+	MetaDescription   string  `json:"meta_description"`
+
+	Attributes    []ItemAttributeInput `json:"attributes"`
+	ProductID     *int                 `json:"product_id"`
+	VariantValues []VariantValueInput  `json:"variant_values"`
+	PriceTiers    []PriceTierInput     `json:"price_tiers"`
 }
 type ItemInfo struct {
 	ID                int      `json:"id"`
 	Name              string   `json:"name"`
 	Description       string   `json:"description"`
-	Price             float64  `json:"price"`
-	PriceWithDiscount float64  `json:"price_with_discount"`
+	Price             Money    `json:"price" swaggertype:"number"`
+	PriceWithDiscount Money    `json:"price_with_discount" swaggertype:"number"`
+	Currency          string   `json:"currency"`
+	Status            string   `json:"status"`
+	Slug              string   `json:"slug"`
+	Available         int      `json:"available"`
 	Images            []string `json:"images"`
+	ProductID         *int     `json:"product_id,omitempty"`
+	Unit              string   `json:"unit"`
+	BaseUnit          string   `json:"base_unit,omitempty"`
+	PricePerBaseUnit  Money    `json:"price_per_base_unit,omitempty" swaggertype:"number"`
 }
 
 type CurrentItemInfo struct {
-	ID                int      `json:"id"`
-	Name              string   `json:"name"`
-	Description       string   `json:"description"`
-	Article           string   `json:"article"`
-	Price             float64  `json:"price"`
-	PriceWithDiscount float64  `json:"price_with_discount"`
-	Quantity          int      `json:"quantity"`
-	Length            int      `json:"length"`
-	Width             int      `json:"width"`
-	Height            int      `json:"height"`
-	Weight            int      `json:"weight"`
-	Category          string   `json:"category"`
-	Brand             string   `json:"brand"`
-	Seller            string   `json:"seller"`
-	SellerID          string   `json:"seller_id"`
-	Material          string   `json:"material"`
-	Images            []string `json:"images"`
+	ID                int         `json:"id"`
+	Name              string      `json:"name"`
+	Description       string      `json:"description"`
+	Article           string      `json:"article"`
+	Price             Money       `json:"price" swaggertype:"number"`
+	PriceWithDiscount Money       `json:"price_with_discount" swaggertype:"number"`
+	Currency          string      `json:"currency"`
+	Quantity          int         `json:"quantity"`
+	Available         int         `json:"available"`
+	Length            int         `json:"length"`
+	Width             int         `json:"width"`
+	Height            int         `json:"height"`
+	Weight            int         `json:"weight"`
+	Unit              string      `json:"unit"`
+	BaseUnit          string      `json:"base_unit,omitempty"`
+	UnitRatio         float64     `json:"unit_ratio"`
+	MinQuantity       int         `json:"min_quantity"`
+	QuantityStep      int         `json:"quantity_step"`
+	Category          string      `json:"category"`
+	Brand             string      `json:"brand"`
+	Seller            string      `json:"seller"`
+	SellerID          string      `json:"seller_id"`
+	Material          string      `json:"material"`
+	Images            []ImageInfo `json:"images"`
+	Version           int         `json:"version"`
+	Status            string      `json:"status"`
+	Slug              string      `json:"slug"`
+	CanonicalURL      string      `json:"canonical_url"`
+	MetaTitle         string      `json:"meta_title"`
+	MetaDescription   string      `json:"meta_description"`
+
+	Attributes []ItemAttributeInfo `json:"attributes"`
+	ProductID  *int                `json:"product_id,omitempty"`
+	Variants   *VariantMatrix      `json:"variants,omitempty"`
+
+	// Цены за базовую единицу (например, за м² при продаже упаковками)
+	PricePerBaseUnit             Money `json:"price_per_base_unit,omitempty" swaggertype:"number"`
+	PriceWithDiscountPerBaseUnit Money `json:"price_with_discount_per_base_unit,omitempty" swaggertype:"number"`
+
+	PriceTiers []PriceTierInfo `json:"price_tiers"`
+
+	// Наличие по складам продавца и возможность самовывоза хотя бы с одного из них
+	Warehouses      []WarehouseAvailability `json:"warehouses,omitempty"`
+	PickupAvailable bool                    `json:"pickup_available"`
 }
 
-func ConvertItemsToItemInfo(items []Item) []ItemInfo {
+func ConvertItemsToItemInfo(items []Item, resolveURL URLResolver) []ItemInfo {
 	var itemInfos []ItemInfo
 
 	for _, item := range items {
@@ -79,9 +160,18 @@ func ConvertItemsToItemInfo(items []Item) []ItemInfo {
 			Description:       item.Description,
 			Price:             item.Price,
 			PriceWithDiscount: item.PriceWithDiscount,
+			Currency:          item.Currency,
+			Status:            item.Status,
+			Slug:              item.Slug,
+			ProductID:         item.ProductID,
+			Unit:              item.Unit,
+		}
+		if item.BaseUnit != "" {
+			itemInfo.BaseUnit = item.BaseUnit
+			itemInfo.PricePerBaseUnit = item.PriceWithDiscount.Div(item.UnitRatio)
 		}
-		for _, image := range item.Images {
-			itemInfo.Images = append(itemInfo.Images, image.URL)
+		for _, image := range SortImages(item.Images) {
+			itemInfo.Images = append(itemInfo.Images, resolveURL(image.StorageKey))
 		}
 		itemInfos = append(itemInfos, itemInfo)
 	}
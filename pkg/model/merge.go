@@ -0,0 +1,8 @@
+package model
+
+// MergeInput — дубликаты записи справочника, товары которых переносятся в каноническую запись TargetID.
+// После переноса дубликаты удаляются.
+type MergeInput struct {
+	TargetID  int   `json:"target_id"`
+	SourceIDs []int `json:"source_ids"`
+}
@@ -0,0 +1,168 @@
+package model
+
+import "time"
+
+const (
+	ModerationStatusPending   = "pending"
+	ModerationStatusApproved  = "approved"
+	ModerationStatusRejected  = "rejected"
+	ModerationStatusWithdrawn = "withdrawn"
+
+	ModerationKindNew  = "new"
+	ModerationKindEdit = "edit"
+
+	ModerationFlagForbiddenWords = "forbidden_words"
+	ModerationFlagMissingImages  = "missing_images"
+	ModerationFlagPriceAnomaly   = "price_anomaly"
+
+	NotificationTypeModeration = "moderation"
+)
+
+// ModerationRequest — заявка на проверку нового или изменённого товара либо комплекта (BundleID).
+// Changes хранит изменённые поля: для нового товара — все проверяемые поля с пустым старым значением.
+// Pending и PendingBundle — правка опубликованного товара или комплекта: до одобрения покупатели видят прежнюю версию.
+type ModerationRequest struct {
+	ID            int                `json:"id" gorm:"autoIncrement;primaryKey"`
+	ItemID        *int               `json:"item_id" gorm:"index"`
+	BundleID      *int               `json:"bundle_id" gorm:"index"`
+	SellerID      string             `json:"seller_id" gorm:"not null;index"`
+	Kind          string             `json:"kind" gorm:"not null"`
+	Status        string             `json:"status" gorm:"not null;default:pending;index"`
+	Changes       []ModerationChange `json:"changes" gorm:"type:text;serializer:json"`
+	Pending       *ItemContent       `json:"-" gorm:"type:text;serializer:json"`
+	PendingBundle *BundleContent     `json:"-" gorm:"type:text;serializer:json"`
+	Reason        string             `json:"reason"`
+	ReviewerID    string             `json:"reviewer_id"`
+	CreatedAt     time.Time          `json:"created_at" gorm:"autoCreateTime"`
+	ReviewedAt    *time.Time         `json:"reviewed_at"`
+
+	Item   Item   `json:"-" gorm:"foreignKey:ItemID"`
+	Bundle Bundle `json:"-" gorm:"foreignKey:BundleID"`
+}
+
+// IsEdit сообщает, что заявка — правка опубликованного товара или комплекта
+func (r ModerationRequest) IsEdit() bool {
+	return r.Pending != nil || r.PendingBundle != nil
+}
+
+// ItemContent — поля товара, изменение которых проходит модерацию
+type ItemContent struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Article     string `json:"article"`
+	CategoryID  int    `json:"category_id"`
+	BrandID     int    `json:"brand_id"`
+	MaterialID  int    `json:"material_id"`
+}
+
+func ItemContentOf(item Item) ItemContent {
+	return ItemContent{
+		Name:        item.Name,
+		Description: item.Description,
+		Article:     item.Article,
+		CategoryID:  item.CategoryID,
+		BrandID:     item.BrandID,
+		MaterialID:  item.MaterialID,
+	}
+}
+
+// Apply переносит поля в item
+func (c ItemContent) Apply(item *Item) {
+	item.Name = c.Name
+	item.Description = c.Description
+	item.Article = c.Article
+	item.CategoryID = c.CategoryID
+	item.BrandID = c.BrandID
+	item.MaterialID = c.MaterialID
+}
+
+// Columns возвращает поля для обновления записи товара
+func (c ItemContent) Columns() map[string]interface{} {
+	return map[string]interface{}{
+		"name":        c.Name,
+		"description": c.Description,
+		"article":     c.Article,
+		"category_id": c.CategoryID,
+		"brand_id":    c.BrandID,
+		"material_id": c.MaterialID,
+	}
+}
+
+// BundleContent — поля комплекта, изменение которых проходит модерацию
+type BundleContent struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CategoryID  int    `json:"category_id"`
+}
+
+func BundleContentOf(bundle Bundle) BundleContent {
+	return BundleContent{Name: bundle.Name, Description: bundle.Description, CategoryID: bundle.CategoryID}
+}
+
+// Apply переносит поля в bundle
+func (c BundleContent) Apply(bundle *Bundle) {
+	bundle.Name = c.Name
+	bundle.Description = c.Description
+	bundle.CategoryID = c.CategoryID
+}
+
+// Columns возвращает поля для обновления записи комплекта
+func (c BundleContent) Columns() map[string]interface{} {
+	return map[string]interface{}{
+		"name":        c.Name,
+		"description": c.Description,
+		"category_id": c.CategoryID,
+	}
+}
+
+// ModerationChange — значение поля товара до и после изменения
+type ModerationChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// ModerationRejectInput — причина отклонения, которую увидит продавец
+type ModerationRejectInput struct {
+	Reason string `json:"reason"`
+}
+
+// ModerationRequestOutput — заявка в очереди модерации; для комплекта заполнены BundleID и BundleName.
+// Flags — замечания автоматической проверки, Priority — их суммарный вес: заявки с большим приоритетом проверяются первыми.
+type ModerationRequestOutput struct {
+	ID         int                `json:"id"`
+	ItemID     int                `json:"item_id,omitempty"`
+	ItemName   string             `json:"item_name,omitempty"`
+	BundleID   int                `json:"bundle_id,omitempty"`
+	BundleName string             `json:"bundle_name,omitempty"`
+	SellerID   string             `json:"seller_id"`
+	Kind       string             `json:"kind"`
+	Status     string             `json:"status"`
+	Changes    []ModerationChange `json:"changes"`
+	Flags      []string           `json:"flags"`
+	Priority   int                `json:"priority"`
+	Reason     string             `json:"reason,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+	ReviewedAt *time.Time         `json:"reviewed_at,omitempty"`
+}
+
+func ConvertModerationRequestToOutput(request ModerationRequest) ModerationRequestOutput {
+	output := ModerationRequestOutput{
+		ID:         request.ID,
+		SellerID:   request.SellerID,
+		Kind:       request.Kind,
+		Status:     request.Status,
+		Changes:    request.Changes,
+		Flags:      []string{},
+		Reason:     request.Reason,
+		CreatedAt:  request.CreatedAt,
+		ReviewedAt: request.ReviewedAt,
+	}
+	if request.ItemID != nil {
+		output.ItemID, output.ItemName = *request.ItemID, request.Item.Name
+	}
+	if request.BundleID != nil {
+		output.BundleID, output.BundleName = *request.BundleID, request.Bundle.Name
+	}
+	return output
+}
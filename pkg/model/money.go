@@ -0,0 +1,159 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency — валюта по умолчанию (ISO 4217)
+const DefaultCurrency = "RUB"
+
+// currencySymbols — обозначения валют в сообщениях; для остальных валют выводится код
+var currencySymbols = map[string]string{
+	"RUB": "₽",
+	"USD": "$",
+	"EUR": "€",
+	"KZT": "₸",
+	"BYN": "Br",
+	"CNY": "¥",
+}
+
+// Money — денежная сумма в минимальных единицах валюты (копейках).
+// Хранится в БД как bigint, в JSON передаётся десятичным числом с двумя знаками (123.45).
+//
+// Правила округления:
+//   - при разборе десятичной суммы доли копейки округляются по математическим правилам (половина — от нуля);
+//   - сложение и умножение на количество выполняются точно в целых числах;
+//   - деление (например, цена за м²) округляется до копейки по тем же правилам.
+type Money int64
+
+// NewMoney создаёт сумму из рублей и копеек
+func NewMoney(units int64, minor int64) Money {
+	return Money(units*100 + minor)
+}
+
+// MoneyFromFloat переводит сумму в рублях в копейки с округлением до копейки
+func MoneyFromFloat(amount float64) Money {
+	return Money(math.Round(amount * 100))
+}
+
+// ParseMoney разбирает десятичную запись суммы ("123", "123.4", "123.456", "-5", "+5", "12,5") без потерь точности float.
+// Допускается только один ведущий знак; запятая считается десятичным разделителем, разделители разрядов не поддерживаются.
+func ParseMoney(value string) (Money, error) {
+	raw := value
+	value = strings.TrimSpace(strings.ReplaceAll(value, ",", "."))
+	if value == "" {
+		return 0, errors.New("empty money value")
+	}
+	invalid := fmt.Errorf("invalid money value: %s", raw)
+	if strings.ContainsAny(value, "eE") {
+		amount, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(amount) || math.Abs(amount*100) >= math.MaxInt64 {
+			return 0, invalid
+		}
+		return MoneyFromFloat(amount), nil
+	}
+
+	negative := false
+	switch value[0] {
+	case '-':
+		negative = true
+		value = value[1:]
+	case '+':
+		value = value[1:]
+	}
+
+	integerPart, fractionPart, _ := strings.Cut(value, ".")
+	if integerPart == "" && fractionPart == "" || !isDigits(integerPart) || !isDigits(fractionPart) {
+		return 0, invalid
+	}
+	if integerPart == "" {
+		integerPart = "0"
+	}
+	units, err := strconv.ParseInt(integerPart, 10, 64)
+	if err != nil || units > (math.MaxInt64-100)/100 {
+		return 0, invalid
+	}
+
+	fractionPart += "000"
+	minor, _ := strconv.ParseInt(fractionPart[:2], 10, 64)
+	amount := units*100 + minor
+	if fractionPart[2] >= '5' {
+		amount++
+	}
+
+	if negative {
+		amount = -amount
+	}
+	return Money(amount), nil
+}
+
+// isDigits проверяет, что строка состоит только из десятичных цифр
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Float возвращает сумму в рублях; используется только для отображения
+func (m Money) Float() float64 {
+	return float64(m) / 100
+}
+
+// Mul умножает сумму на целое количество
+func (m Money) Mul(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// Div делит сумму на дробный коэффициент с округлением до копейки
+func (m Money) Div(ratio float64) Money {
+	if ratio <= 0 {
+		return m
+	}
+	return Money(math.Round(float64(m) / ratio))
+}
+
+func (m Money) String() string {
+	sign := ""
+	amount := int64(m)
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
+
+// Format возвращает сумму с обозначением валюты для сообщений: "123.45 ₽"; пустая валюта — валюта по умолчанию
+func (m Money) Format(currency string) string {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	if symbol, ok := currencySymbols[currency]; ok {
+		currency = symbol
+	}
+	return m.String() + " " + currency
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if string(data) == "null" {
+		return nil
+	}
+	value, err := ParseMoney(string(data))
+	if err != nil {
+		return err
+	}
+	*m = value
+	return nil
+}
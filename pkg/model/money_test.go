@@ -0,0 +1,77 @@
+package model
+
+import "testing"
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Money
+		wantErr bool
+	}{
+		{name: "integer", value: "123", want: 12300},
+		{name: "one fraction digit", value: "123.4", want: 12340},
+		{name: "two fraction digits", value: "0.05", want: 5},
+		{name: "extra fraction digits round half away from zero", value: "1.005", want: 101},
+		{name: "extra fraction digits round down", value: "1.00499", want: 100},
+		{name: "rounding carries into units", value: "0.995", want: 100},
+		{name: "negative amount rounds away from zero", value: "-1.005", want: -101},
+		{name: "leading plus", value: "+5", want: 500},
+		{name: "leading minus", value: "-5", want: -500},
+		{name: "comma as decimal separator", value: "12,5", want: 1250},
+		{name: "surrounding spaces", value: " 7.10 ", want: 710},
+		{name: "fraction without integer part", value: ".5", want: 50},
+		{name: "exponent", value: "1.5e2", want: 15000},
+		{name: "empty string", value: "", wantErr: true},
+		{name: "only spaces", value: "   ", wantErr: true},
+		{name: "only a sign", value: "-", wantErr: true},
+		{name: "only a separator", value: ".", wantErr: true},
+		{name: "double minus", value: "--5", wantErr: true},
+		{name: "minus after plus", value: "+-5", wantErr: true},
+		{name: "sign inside the number", value: "5-1", wantErr: true},
+		{name: "sign in the fraction", value: "1.-5", wantErr: true},
+		{name: "thousands separator", value: "1,000.50", wantErr: true},
+		{name: "letters", value: "12abc", wantErr: true},
+		{name: "units overflow", value: "92233720368547758", wantErr: true},
+		{name: "int64 overflow", value: "9223372036854775808", wantErr: true},
+		{name: "exponent overflow", value: "1e20", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyFormat(t *testing.T) {
+	tests := []struct {
+		amount   Money
+		currency string
+		want     string
+	}{
+		{amount: 12345, currency: "RUB", want: "123.45 ₽"},
+		{amount: 500, currency: "", want: "5.00 ₽"},
+		{amount: 99, currency: "USD", want: "0.99 $"},
+		{amount: -150, currency: "KZT", want: "-1.50 ₸"},
+		{amount: 100, currency: "GEL", want: "1.00 GEL"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.Format(tt.currency); got != tt.want {
+			t.Errorf("Format(%d, %q) = %q, want %q", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
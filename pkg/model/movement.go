@@ -0,0 +1,90 @@
+package model
+
+import "time"
+
+const (
+	MovementTypeSale         = "sale"
+	MovementTypeCancellation = "cancellation"
+	MovementTypeAdjustment   = "adjustment"
+	MovementTypeImport       = "import"
+)
+
+// StockChange описывает, кто и почему меняет остаток; передаётся вместе с изменением и попадает в журнал движений
+type StockChange struct {
+	Type    string
+	ActorID string
+	Reason  string
+	OrderID *int
+}
+
+// StockMovement — запись журнала движений остатков. Записи только добавляются и не изменяются.
+// WarehouseID пуст для движений общего остатка товара без складов.
+type StockMovement struct {
+	ID            int       `json:"id" gorm:"autoIncrement;primaryKey"`
+	ItemID        int       `json:"item_id" gorm:"not null;index"`
+	SellerID      string    `json:"seller_id" gorm:"not null;index"`
+	WarehouseID   *int      `json:"warehouse_id"`
+	Type          string    `json:"type" gorm:"not null"`
+	Delta         int       `json:"delta" gorm:"not null"`
+	QuantityAfter int       `json:"quantity_after" gorm:"not null"`
+	ActorID       string    `json:"actor_id"`
+	Reason        string    `json:"reason"`
+	OrderID       *int      `json:"order_id"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime;index"`
+
+	Item Item `json:"-" gorm:"foreignKey:ItemID"`
+}
+
+// StockMovementFilter — параметры отчёта по движениям остатков
+type StockMovementFilter struct {
+	ItemID int
+	Type   string
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+}
+
+type StockMovementOutput struct {
+	ID            int       `json:"id"`
+	ItemID        int       `json:"item_id"`
+	ItemName      string    `json:"item_name"`
+	Article       string    `json:"article"`
+	WarehouseID   *int      `json:"warehouse_id,omitempty"`
+	Type          string    `json:"type"`
+	Delta         int       `json:"delta"`
+	QuantityAfter int       `json:"quantity_after"`
+	ActorID       string    `json:"actor_id"`
+	Reason        string    `json:"reason"`
+	OrderID       *int      `json:"order_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// LowStockThresholdInput задаёт порог низкого остатка: для товара, если указан item_id, иначе по умолчанию для всех товаров продавца
+type LowStockThresholdInput struct {
+	ItemID    *int `json:"item_id"`
+	Threshold int  `json:"threshold"`
+}
+
+func ConvertMovementsToOutput(movements []StockMovement) []StockMovementOutput {
+	outputs := []StockMovementOutput{}
+
+	for _, movement := range movements {
+		outputs = append(outputs, StockMovementOutput{
+			ID:            movement.ID,
+			ItemID:        movement.ItemID,
+			ItemName:      movement.Item.Name,
+			Article:       movement.Item.Article,
+			WarehouseID:   movement.WarehouseID,
+			Type:          movement.Type,
+			Delta:         movement.Delta,
+			QuantityAfter: movement.QuantityAfter,
+			ActorID:       movement.ActorID,
+			Reason:        movement.Reason,
+			OrderID:       movement.OrderID,
+			CreatedAt:     movement.CreatedAt,
+		})
+	}
+
+	return outputs
+}
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+const NotificationTypeLowStock = "low_stock"
+
+// Notification — уведомление пользователю (продавцу или покупателю), которое он видит в личном кабинете
+type Notification struct {
+	ID          int       `json:"id" gorm:"autoIncrement;primaryKey"`
+	RecipientID string    `json:"recipient_id" gorm:"not null;index"`
+	Type        string    `json:"type" gorm:"not null"`
+	Message     string    `json:"message" gorm:"not null"`
+	ItemID      *int      `json:"item_id"`
+	Read        bool      `json:"read" gorm:"not null;default:false"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+type NotificationOutput struct {
+	ID        int       `json:"id"`
+	Type      string    `json:"type"`
+	Message   string    `json:"message"`
+	ItemID    *int      `json:"item_id,omitempty"`
+	Read      bool      `json:"read"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func ConvertNotificationsToOutput(notifications []Notification) []NotificationOutput {
+	outputs := []NotificationOutput{}
+
+	for _, notification := range notifications {
+		outputs = append(outputs, NotificationOutput{
+			ID:        notification.ID,
+			Type:      notification.Type,
+			Message:   notification.Message,
+			ItemID:    notification.ItemID,
+			Read:      notification.Read,
+			CreatedAt: notification.CreatedAt,
+		})
+	}
+
+	return outputs
+}
@@ -1,24 +1,48 @@
 package model
 
+import "time"
+
+const (
+	OrderStatusAwaitingPayment = "AwaitingPayment"
+	OrderStatusProcessing      = "Processing"
+	OrderStatusCancelled       = "Cancelled"
+)
+
+// PaidOrderStatuses — статусы оплаченных заказов, которые учитываются в выручке продавца
+var PaidOrderStatuses = []string{OrderStatusProcessing}
+
 type Order struct {
 	ID         int         `json:"id" gorm:"autoIncrement;primaryKey"`
 	BuyerID    string      `json:"buyer_id"`
 	Buyer      Buyer       `gorm:"foreignKey:BuyerID"`
 	OrderItems []OrderItem `json:"order_items" gorm:"foreignKey:OrderID"`
-	Total      float64     `json:"total" gorm:"not null"`
+	Total      Money       `json:"total" gorm:"not null" swaggertype:"number"`
+	Currency   string      `json:"currency" gorm:"not null;default:RUB"`
 	Status     string      `json:"status" gorm:"not null"`
+
+	// Склад самовывоза; пусто, если заказ доставляется
+	PickupWarehouseID *int `json:"pickup_warehouse_id"`
+
+	// До этого момента товары заказа зарезервированы; неоплаченный заказ затем отменяется
+	ReservedUntil *time.Time `json:"reserved_until"`
 }
 
 type OrderOutput struct {
-	BuyerID string          `json:"buyer_id"`
-	Total   float64         `json:"total" gorm:"not null"`
-	Status  string          `json:"status" gorm:"not null"`
-	Items   []OrderItemInfo `json:"items"`
+	BuyerID  string          `json:"buyer_id"`
+	Total    Money           `json:"total" swaggertype:"number"`
+	Currency string          `json:"currency"`
+	Status   string          `json:"status"`
+	Items    []OrderItemInfo `json:"items"`
+
+	PickupWarehouseID *int       `json:"pickup_warehouse_id,omitempty"`
+	ReservedUntil     *time.Time `json:"reserved_until,omitempty"`
 }
 
 type OrderItemInfo struct {
-	ID       int     `json:"id"`
-	Name     string  `json:"name"`
-	Price    float64 `json:"price"`
-	Quantity int     `json:"quantity"`
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Price    Money  `json:"price" swaggertype:"number"`
+	Quantity int    `json:"quantity"`
+	Total    Money  `json:"total" swaggertype:"number"`
+	BundleID *int   `json:"bundle_id,omitempty"`
 }
@@ -8,10 +8,23 @@ type OrderItem struct {
 	ItemID    int       `json:"item_id" gorm:"not null"`
 	SellerId  string    `json:"seller_id" gorm:"not null"`
 	Quantity  int       `json:"quantity" gorm:"not null"`
-	UnitPrice float64   `json:"unit_price" gorm:"not null"`
-	Total     float64   `json:"total" gorm:"not null"`
+	UnitPrice Money     `json:"unit_price" gorm:"not null" swaggertype:"number"`
+	Total     Money     `json:"total" gorm:"not null" swaggertype:"number"`
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
 
-	Item  Item  `gorm:"foreignKey:ItemID"`
-	Order Order `gorm:"foreignKey:OrderID"`
+	// Комплект, в составе которого куплен товар; цена позиции — её доля цены комплекта.
+	// Если доля не делится на количество поровну, единица с остатком в копейках идёт отдельной позицией.
+	BundleID *int `json:"bundle_id" gorm:"index"`
+
+	Item        Item                  `gorm:"foreignKey:ItemID"`
+	Order       Order                 `gorm:"foreignKey:OrderID"`
+	Allocations []OrderItemAllocation `json:"allocations" gorm:"foreignKey:OrderItemID;constraint:OnDelete:CASCADE"`
+}
+
+// OrderItemAllocation — сколько единиц позиции заказа отгружается с каждого склада
+type OrderItemAllocation struct {
+	ID          int `json:"-" gorm:"autoIncrement;primaryKey"`
+	OrderItemID int `json:"-" gorm:"not null;index"`
+	WarehouseID int `json:"warehouse_id" gorm:"not null"`
+	Quantity    int `json:"quantity" gorm:"not null"`
 }
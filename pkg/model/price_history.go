@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+const (
+	NotificationTypePriceDrop   = "price_drop"
+	NotificationTypeBackInStock = "back_in_stock"
+)
+
+// PriceChange — запись истории цен товара: цены, действующие с момента CreatedAt
+type PriceChange struct {
+	ID                int       `json:"id" gorm:"autoIncrement;primaryKey"`
+	ItemID            int       `json:"item_id" gorm:"not null;index:idx_price_change_item"`
+	Price             Money     `json:"price" gorm:"not null" swaggertype:"number"`
+	PriceWithDiscount Money     `json:"price_with_discount" gorm:"not null" swaggertype:"number"`
+	CreatedAt         time.Time `json:"created_at" gorm:"autoCreateTime;index:idx_price_change_item"`
+}
+
+// PricePoint — точка графика цены
+type PricePoint struct {
+	Date              time.Time `json:"date"`
+	Price             Money     `json:"price" swaggertype:"number"`
+	PriceWithDiscount Money     `json:"price_with_discount" swaggertype:"number"`
+}
+
+// PriceHistoryOutput — история цены товара за период для графика.
+// Первая точка — цена, действовавшая на начало периода.
+type PriceHistoryOutput struct {
+	ItemID   int          `json:"item_id"`
+	Currency string       `json:"currency"`
+	Points   []PricePoint `json:"points"`
+	MinPrice Money        `json:"min_price" swaggertype:"number"`
+	MaxPrice Money        `json:"max_price" swaggertype:"number"`
+}
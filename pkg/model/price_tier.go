@@ -0,0 +1,53 @@
+package model
+
+// PriceTier — оптовая цена за единицу товара при покупке от MinQuantity единиц
+type PriceTier struct {
+	ID          int   `json:"-" gorm:"autoIncrement;primaryKey"`
+	ItemID      int   `json:"-" gorm:"not null;uniqueIndex:idx_item_min_quantity"`
+	MinQuantity int   `json:"min_quantity" gorm:"not null;uniqueIndex:idx_item_min_quantity"`
+	Price       Money `json:"price" gorm:"not null" swaggertype:"number"`
+}
+
+type PriceTierInput struct {
+	MinQuantity int   `json:"min_quantity"`
+	Price       Money `json:"price" swaggertype:"number"`
+}
+
+type PriceTierInfo struct {
+	MinQuantity int   `json:"min_quantity"`
+	Price       Money `json:"price" swaggertype:"number"`
+}
+
+// UnitPriceForQuantity возвращает цену за единицу с учётом скидки и оптовых порогов:
+// применяется порог с наибольшим MinQuantity, не превышающим quantity, если он выгоднее
+func UnitPriceForQuantity(item Item, quantity int) Money {
+	price := item.PriceWithDiscount
+	if price <= 0 {
+		price = item.Price
+	}
+
+	var applied *PriceTier
+	for i, tier := range item.PriceTiers {
+		if tier.MinQuantity <= quantity && (applied == nil || tier.MinQuantity > applied.MinQuantity) {
+			applied = &item.PriceTiers[i]
+		}
+	}
+	if applied != nil && applied.Price < price {
+		return applied.Price
+	}
+
+	return price
+}
+
+func ConvertPriceTiersToInfo(tiers []PriceTier) []PriceTierInfo {
+	var infos []PriceTierInfo
+
+	for _, tier := range tiers {
+		infos = append(infos, PriceTierInfo{
+			MinQuantity: tier.MinQuantity,
+			Price:       tier.Price,
+		})
+	}
+
+	return infos
+}
@@ -0,0 +1,120 @@
+package model
+
+// Product — карточка товара, объединяющая варианты (SKU), которые отличаются
+// значениями по осям: цвет, размер, объём упаковки и т.д.
+// Каждый вариант — это отдельный Item со своим артикулом, ценой, остатком и изображениями.
+type Product struct {
+	ID          int           `json:"id" gorm:"autoIncrement;primaryKey"`
+	SellerID    string        `json:"seller_id" gorm:"not null;index"`
+	Name        string        `json:"name" gorm:"not null"`
+	Description string        `json:"description"`
+	Axes        []VariantAxis `json:"axes" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Items       []Item        `json:"-" gorm:"foreignKey:ProductID"`
+}
+
+type VariantAxis struct {
+	ID        int    `json:"id" gorm:"autoIncrement;primaryKey"`
+	ProductID int    `json:"product_id" gorm:"not null;index"`
+	Name      string `json:"name" gorm:"not null"`
+	Position  int    `json:"position" gorm:"default:0"`
+}
+
+type VariantValue struct {
+	ID     int    `json:"-" gorm:"autoIncrement;primaryKey"`
+	ItemID int    `json:"-" gorm:"not null;uniqueIndex:idx_item_axis"`
+	AxisID int    `json:"axis_id" gorm:"not null;uniqueIndex:idx_item_axis"`
+	Value  string `json:"value" gorm:"not null"`
+
+	Axis VariantAxis `json:"-" gorm:"foreignKey:AxisID;constraint:OnDelete:CASCADE"`
+}
+
+type ProductInput struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Axes        []string `json:"axes"`
+}
+
+type VariantValueInput struct {
+	AxisID int    `json:"axis_id"`
+	Value  string `json:"value"`
+}
+
+type VariantMatrix struct {
+	ProductID   int               `json:"product_id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Axes        []VariantAxisInfo `json:"axes"`
+	Variants    []VariantInfo     `json:"variants"`
+}
+
+type VariantAxisInfo struct {
+	ID     int      `json:"id"`
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// VariantInfo — SKU в матрице вариантов; Quantity — количество, доступное к заказу
+type VariantInfo struct {
+	ItemID            int               `json:"item_id"`
+	Article           string            `json:"article"`
+	Price             Money             `json:"price" swaggertype:"number"`
+	PriceWithDiscount Money             `json:"price_with_discount" swaggertype:"number"`
+	Quantity          int               `json:"quantity"`
+	Values            map[string]string `json:"values"`
+	Images            []string          `json:"images"`
+}
+
+// ConvertProductToVariantMatrix строит матрицу вариантов: оси с уникальными значениями
+// в порядке появления и список SKU со значениями по каждой оси.
+// Количество SKU — доступное к заказу: остаток за вычетом резервов reserved.
+func ConvertProductToVariantMatrix(product Product, reserved map[int]ReservedStock, resolveURL URLResolver) VariantMatrix {
+	matrix := VariantMatrix{
+		ProductID:   product.ID,
+		Name:        product.Name,
+		Description: product.Description,
+	}
+
+	axisNames := make(map[int]string, len(product.Axes))
+	axisIndex := make(map[int]int, len(product.Axes))
+	for i, axis := range product.Axes {
+		axisNames[axis.ID] = axis.Name
+		axisIndex[axis.ID] = i
+		matrix.Axes = append(matrix.Axes, VariantAxisInfo{ID: axis.ID, Name: axis.Name})
+	}
+
+	seen := make(map[int]map[string]bool, len(product.Axes))
+	for _, item := range product.Items {
+		// Черновики и архивные SKU покупателю не показываются
+		if item.Status != ItemStatusPublished {
+			continue
+		}
+		variant := VariantInfo{
+			ItemID:            item.ID,
+			Article:           item.Article,
+			Price:             item.Price,
+			PriceWithDiscount: item.PriceWithDiscount,
+			Quantity:          Available(item.Quantity, reserved[item.ID].Total),
+			Values:            map[string]string{},
+		}
+		for _, value := range item.VariantValues {
+			i, ok := axisIndex[value.AxisID]
+			if !ok {
+				continue
+			}
+			variant.Values[axisNames[value.AxisID]] = value.Value
+			if seen[value.AxisID] == nil {
+				seen[value.AxisID] = map[string]bool{}
+			}
+			if !seen[value.AxisID][value.Value] {
+				seen[value.AxisID][value.Value] = true
+				matrix.Axes[i].Values = append(matrix.Axes[i].Values, value.Value)
+			}
+		}
+		for _, image := range SortImages(item.Images) {
+			variant.Images = append(variant.Images, resolveURL(image.StorageKey))
+		}
+		matrix.Variants = append(matrix.Variants, variant)
+	}
+
+	return matrix
+}
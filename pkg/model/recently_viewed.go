@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// MaxRecentlyViewed — сколько последних просмотренных товаров хранится у посетителя
+const MaxRecentlyViewed = 50
+
+// ItemView — последний просмотр товара покупателем (BuyerID) или гостем (DeviceToken).
+// Повторный просмотр обновляет ViewedAt, а не добавляет запись.
+type ItemView struct {
+	ID          int       `json:"id" gorm:"autoIncrement;primaryKey"`
+	BuyerID     string    `json:"buyer_id" gorm:"not null;default:'';uniqueIndex:idx_item_view"`
+	DeviceToken string    `json:"-" gorm:"not null;default:'';uniqueIndex:idx_item_view"`
+	ItemID      int       `json:"item_id" gorm:"not null;uniqueIndex:idx_item_view"`
+	ViewedAt    time.Time `json:"viewed_at" gorm:"not null;index"`
+	Item        Item      `json:"-" gorm:"foreignKey:ItemID"`
+}
+
+// ItemViewCount — сколько раз товар просматривали; используется для сортировки по популярности
+type ItemViewCount struct {
+	ItemID    int       `json:"item_id" gorm:"primaryKey;autoIncrement:false"`
+	Views     int64     `json:"views" gorm:"not null;default:0"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type RecentlyViewedOutput struct {
+	Item     ItemInfo  `json:"item"`
+	ViewedAt time.Time `json:"viewed_at"`
+}
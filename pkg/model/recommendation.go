@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// ItemCoPurchase — сколько заказов содержат оба товара. Таблица пересчитывается фоновой задачей
+// по истории заказов и хранит обе пары (A, B) и (B, A).
+type ItemCoPurchase struct {
+	ItemID        int       `json:"item_id" gorm:"primaryKey;autoIncrement:false"`
+	RelatedItemID int       `json:"related_item_id" gorm:"primaryKey;autoIncrement:false"`
+	Orders        int       `json:"orders" gorm:"not null"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// CategoryCoPurchase — сколько заказов содержат товары обеих категорий; по ней подбираются
+// сопутствующие товары «для вашего проекта» (гипсокартон → профили, саморезы, шпаклёвка)
+type CategoryCoPurchase struct {
+	CategoryID        int       `json:"category_id" gorm:"primaryKey;autoIncrement:false"`
+	RelatedCategoryID int       `json:"related_category_id" gorm:"primaryKey;autoIncrement:false"`
+	Orders            int       `json:"orders" gorm:"not null"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// RecommendationsOutput — рекомендации к товару или корзине; пустые подборки возвращаются пустыми списками
+type RecommendationsOutput struct {
+	BoughtTogether  []ItemInfo `json:"bought_together"`
+	Similar         []ItemInfo `json:"similar"`
+	CompleteProject []ItemInfo `json:"complete_project"`
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+const (
+	ReservationStatusActive    = "active"
+	ReservationStatusConverted = "converted"
+	ReservationStatusExpired   = "expired"
+	ReservationStatusReleased  = "released"
+)
+
+// Reservation удерживает товар за заказом от оформления до оплаты.
+// WarehouseID пуст для товаров без складских остатков — тогда резерв берётся из общего количества.
+type Reservation struct {
+	ID          int       `json:"id" gorm:"autoIncrement;primaryKey"`
+	OrderID     int       `json:"order_id" gorm:"not null;index"`
+	ItemID      int       `json:"item_id" gorm:"not null;index:idx_reservation_item_status"`
+	WarehouseID *int      `json:"warehouse_id"`
+	Quantity    int       `json:"quantity" gorm:"not null"`
+	Status      string    `json:"status" gorm:"not null;default:active;index:idx_reservation_item_status"`
+	ExpiresAt   time.Time `json:"expires_at" gorm:"not null;index"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// ReservedStock — количество товара, удерживаемое действующими резервами, всего и по складам
+type ReservedStock struct {
+	Total       int
+	ByWarehouse map[int]int
+}
+
+// Available возвращает количество, доступное к заказу: остаток за вычетом резерва
+func Available(onHand, reserved int) int {
+	return max(0, onHand-reserved)
+}
@@ -1,13 +1,17 @@
 package model
 
 type Seller struct {
-	ID       string  `json:"id" gorm:"primaryKey"`
-	Name     string  `json:"name" gorm:"not null"`
-	Email    string  `json:"email" gorm:"not null;unique"`
-	Password string  `json:"password" gorm:"not null"`
-	ShopName string  `json:"shop_name" gorm:"not null"`
-	Balance  float64 `json:"balance" gorm:"default:0"`
-	Items    []Item  `json:"items" gorm:"foreignKey:SellerID"`
+	ID       string `json:"id" gorm:"primaryKey"`
+	Name     string `json:"name" gorm:"not null"`
+	Email    string `json:"email" gorm:"not null;unique"`
+	Password string `json:"password" gorm:"not null"`
+	ShopName string `json:"shop_name" gorm:"not null"`
+	Balance  Money  `json:"balance" gorm:"default:0" swaggertype:"number"`
+	Currency string `json:"currency" gorm:"not null;default:RUB"`
+	Items    []Item `json:"items" gorm:"foreignKey:SellerID"`
+
+	// Порог низкого остатка по умолчанию для товаров без собственного порога; 0 — уведомления отключены
+	LowStockThreshold int `json:"low_stock_threshold" gorm:"not null;default:0"`
 }
 
 type SellerOutput struct {
@@ -15,7 +19,8 @@ type SellerOutput struct {
 	Name     string     `json:"name"`
 	Email    string     `json:"email"`
 	ShopName string     `json:"shop_name"`
-	Balance  float64    `json:"balance" gorm:"default:0"`
+	Balance  Money      `json:"balance" swaggertype:"number"`
+	Currency string     `json:"currency"`
 	Items    []ItemInfo `json:"items"`
 }
 
@@ -32,6 +37,6 @@ type SellerSignInResponse struct {
 }
 
 type Statistic struct {
-	CurrentWeek float64 `json:"current_week"`
-	LastWeek    float64 `json:"last_week"`
+	CurrentWeek Money `json:"current_week" swaggertype:"number"`
+	LastWeek    Money `json:"last_week" swaggertype:"number"`
 }
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Сущности каталога, у которых есть ЧПУ-адрес
+const (
+	SlugEntityItem     = "item"
+	SlugEntityCategory = "category"
+	SlugEntityBrand    = "brand"
+)
+
+// MaxSlugLength ограничивает длину сгенерированного адреса
+const MaxSlugLength = 80
+
+// SlugRedirect хранит прежний адрес сущности: после переименования или объединения
+// старые ссылки перенаправляются на актуальный адрес
+type SlugRedirect struct {
+	ID         int       `json:"id" gorm:"autoIncrement;primaryKey"`
+	EntityType string    `json:"entity_type" gorm:"not null;uniqueIndex:idx_slug_redirect"`
+	Slug       string    `json:"slug" gorm:"not null;uniqueIndex:idx_slug_redirect"`
+	EntityID   int       `json:"entity_id" gorm:"not null;index"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// transliteration — транслитерация кириллицы по правилам, близким к Яндексу
+var transliteration = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+}
+
+// Slugify переводит название в адрес: латиница в нижнем регистре, цифры и дефисы.
+// Кириллица транслитерируется, остальные символы заменяются дефисом.
+func Slugify(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		var part string
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			part = string(r)
+		case unicode.Is(unicode.Cyrillic, r):
+			var ok bool
+			if part, ok = transliteration[r]; !ok || part == "" {
+				continue
+			}
+		default:
+			hyphen = b.Len() > 0
+			continue
+		}
+		if hyphen {
+			b.WriteByte('-')
+			hyphen = false
+		}
+		b.WriteString(part)
+	}
+
+	slug := b.String()
+	if len(slug) > MaxSlugLength {
+		slug = slug[:MaxSlugLength]
+		// Обрезаем по границе слова, если она есть
+		if i := strings.LastIndexByte(slug, '-'); i > 0 {
+			slug = slug[:i]
+		}
+	}
+	return slug
+}
+
+// SlugBase возвращает основу адреса сущности; для названия без букв и цифр — её тип
+func SlugBase(entityType, name string) string {
+	if slug := Slugify(name); slug != "" {
+		return slug
+	}
+	return entityType
+}
+
+// SlugCandidate возвращает n-й вариант адреса: base, base-2, base-3…
+func SlugCandidate(base string, n int) string {
+	if n <= 1 {
+		return base
+	}
+	return fmt.Sprintf("%s-%d", base, n)
+}
+
+// Пути публичных страниц каталога; канонический адрес — адрес сайта и путь
+func ItemPath(item Item) string {
+	if item.Slug == "" {
+		return fmt.Sprintf("/item/%d", item.ID)
+	}
+	return "/item/" + item.Slug
+}
+
+func CategoryPath(category Category) string {
+	if category.Slug == "" {
+		return fmt.Sprintf("/category/%d", category.ID)
+	}
+	return "/category/" + category.Slug
+}
+
+func BrandPath(brand Brand) string {
+	if brand.Slug == "" {
+		return fmt.Sprintf("/brand/%d", brand.ID)
+	}
+	return "/brand/" + brand.Slug
+}
+
+// SlugRedirectOutput — ответ на запрос по прежнему адресу: актуальный адрес сущности
+type SlugRedirectOutput struct {
+	Slug         string `json:"slug"`
+	CanonicalURL string `json:"canonical_url"`
+}
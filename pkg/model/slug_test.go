@@ -0,0 +1,48 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	long := strings.Repeat("слово ", 20)
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "latin and digits", input: "Drill 750W", want: "drill-750w"},
+		{name: "cyrillic is transliterated", input: "Щётка для мытья", want: "shchyotka-dlya-mytya"},
+		{name: "hard and soft signs are dropped", input: "Подъезд", want: "podezd"},
+		{name: "separators collapse into one hyphen", input: "  Клей -- «Момент»!  ", want: "kley-moment"},
+		{name: "other scripts are treated as separators", input: "Cement 水泥 M500", want: "cement-m500"},
+		{name: "no letters or digits", input: "—!?", want: ""},
+		{name: "long name is cut at a word boundary", input: long, want: strings.TrimSuffix(strings.Repeat("slovo-", 13), "-")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Slugify(tt.input)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if len(got) > MaxSlugLength {
+				t.Errorf("slug is longer than %d: %d", MaxSlugLength, len(got))
+			}
+		})
+	}
+}
+
+func TestSlugBase(t *testing.T) {
+	if got := SlugBase(SlugEntityBrand, "Кнауф"); got != "knauf" {
+		t.Errorf("got %q, want %q", got, "knauf")
+	}
+	if got := SlugBase(SlugEntityItem, "???"); got != SlugEntityItem {
+		t.Errorf("got %q, want %q", got, SlugEntityItem)
+	}
+	if got := SlugCandidate("knauf", 3); got != "knauf-3" {
+		t.Errorf("got %q, want %q", got, "knauf-3")
+	}
+}
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// StockSubscription — подписка на поступление отсутствующего товара.
+// Покупатель подписывается из личного кабинета (BuyerID), гость — по адресу электронной почты (Email).
+// NotifiedAt заполняется при поступлении товара, EmailedAt — после отправки письма гостю;
+// повторная подписка на тот же товар сбрасывает NotifiedAt, а не создаёт дубликат.
+type StockSubscription struct {
+	ID         int        `json:"id" gorm:"autoIncrement;primaryKey"`
+	ItemID     int        `json:"item_id" gorm:"not null;uniqueIndex:idx_stock_subscription"`
+	BuyerID    string     `json:"buyer_id" gorm:"not null;default:'';uniqueIndex:idx_stock_subscription"`
+	Email      string     `json:"email" gorm:"not null;default:'';uniqueIndex:idx_stock_subscription"`
+	Token      string     `json:"-" gorm:"not null;uniqueIndex"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	NotifiedAt *time.Time `json:"notified_at"`
+	EmailedAt  *time.Time `json:"-"`
+	Item       Item       `json:"-" gorm:"foreignKey:ItemID"`
+}
+
+type StockSubscriptionInput struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type StockSubscriptionOutput struct {
+	ID         int        `json:"id"`
+	Item       ItemInfo   `json:"item"`
+	InStock    bool       `json:"in_stock"`
+	CreatedAt  time.Time  `json:"created_at"`
+	NotifiedAt *time.Time `json:"notified_at,omitempty"`
+}
+
+// GuestSubscriptionOutput — гостевая подписка, от которой гость отписывается по ссылке из письма
+type GuestSubscriptionOutput struct {
+	Email string   `json:"email"`
+	Item  ItemInfo `json:"item"`
+}
@@ -0,0 +1,37 @@
+package model
+
+const (
+	UnitPiece       = "pcs"
+	UnitSquareMeter = "m2"
+	UnitCubicMeter  = "m3"
+	UnitLinearMeter = "lm"
+	UnitKilogram    = "kg"
+	UnitLiter       = "l"
+	UnitPack        = "pack"
+	UnitPallet      = "pallet"
+	UnitBag         = "bag"
+	UnitRoll        = "roll"
+	UnitSheet       = "sheet"
+	UnitTon         = "t"
+)
+
+// UnitNames содержит отображаемые названия единиц измерения
+var UnitNames = map[string]string{
+	UnitPiece:       "шт",
+	UnitSquareMeter: "м²",
+	UnitCubicMeter:  "м³",
+	UnitLinearMeter: "пог. м",
+	UnitKilogram:    "кг",
+	UnitLiter:       "л",
+	UnitPack:        "упак.",
+	UnitPallet:      "поддон",
+	UnitBag:         "мешок",
+	UnitRoll:        "рулон",
+	UnitSheet:       "лист",
+	UnitTon:         "т",
+}
+
+func IsValidUnit(unit string) bool {
+	_, ok := UnitNames[unit]
+	return ok
+}
@@ -0,0 +1,112 @@
+package model
+
+import "math"
+
+// Warehouse — склад или площадка продавца, с которой отгружается товар
+type Warehouse struct {
+	ID              int     `json:"id" gorm:"autoIncrement;primaryKey"`
+	SellerID        string  `json:"seller_id" gorm:"not null;index"`
+	Name            string  `json:"name" gorm:"not null"`
+	Address         string  `json:"address" gorm:"not null"`
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
+	PickupAvailable bool    `json:"pickup_available" gorm:"not null;default:true"`
+
+	Stocks []WarehouseStock `json:"-" gorm:"foreignKey:WarehouseID;constraint:OnDelete:CASCADE"`
+}
+
+// WarehouseStock — остаток товара на конкретном складе.
+// Если у товара есть остатки по складам, Item.Quantity равен их сумме.
+type WarehouseStock struct {
+	ID          int `json:"-" gorm:"autoIncrement;primaryKey"`
+	WarehouseID int `json:"warehouse_id" gorm:"not null;uniqueIndex:idx_warehouse_item"`
+	ItemID      int `json:"item_id" gorm:"not null;uniqueIndex:idx_warehouse_item;index"`
+	Quantity    int `json:"quantity" gorm:"not null;default:0"`
+
+	Warehouse Warehouse `json:"-" gorm:"foreignKey:WarehouseID"`
+}
+
+type WarehouseInput struct {
+	Name            string  `json:"name"`
+	Address         string  `json:"address"`
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
+	PickupAvailable *bool   `json:"pickup_available"`
+}
+
+type WarehouseOutput struct {
+	ID              int     `json:"id"`
+	Name            string  `json:"name"`
+	Address         string  `json:"address"`
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
+	PickupAvailable bool    `json:"pickup_available"`
+}
+
+type WarehouseStockInput struct {
+	ItemID      int    `json:"item_id"`
+	WarehouseID int    `json:"warehouse_id"`
+	Quantity    int    `json:"quantity"`
+	Reason      string `json:"reason"`
+}
+
+// WarehouseAvailability — наличие товара на складе для карточки товара
+type WarehouseAvailability struct {
+	WarehouseID     int     `json:"warehouse_id"`
+	Name            string  `json:"name"`
+	Address         string  `json:"address"`
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
+	Quantity        int     `json:"quantity"`
+	PickupAvailable bool    `json:"pickup_available"`
+}
+
+// CheckoutInput — параметры оформления заказа.
+// WarehouseID задаёт склад отгрузки (для самовывоза — обязательно), координаты покупателя
+// позволяют выбрать ближайший склад для остальных позиций.
+type CheckoutInput struct {
+	WarehouseID *int     `json:"warehouse_id"`
+	Pickup      bool     `json:"pickup"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+}
+
+func ConvertWarehouseToOutput(warehouse Warehouse) WarehouseOutput {
+	return WarehouseOutput{
+		ID:              warehouse.ID,
+		Name:            warehouse.Name,
+		Address:         warehouse.Address,
+		Latitude:        warehouse.Latitude,
+		Longitude:       warehouse.Longitude,
+		PickupAvailable: warehouse.PickupAvailable,
+	}
+}
+
+func ConvertStocksToAvailability(stocks []WarehouseStock) []WarehouseAvailability {
+	var availability []WarehouseAvailability
+
+	for _, stock := range stocks {
+		availability = append(availability, WarehouseAvailability{
+			WarehouseID:     stock.WarehouseID,
+			Name:            stock.Warehouse.Name,
+			Address:         stock.Warehouse.Address,
+			Latitude:        stock.Warehouse.Latitude,
+			Longitude:       stock.Warehouse.Longitude,
+			Quantity:        stock.Quantity,
+			PickupAvailable: stock.Warehouse.PickupAvailable,
+		})
+	}
+
+	return availability
+}
+
+// DistanceKm возвращает расстояние между точками по поверхности Земли (формула гаверсинусов)
+func DistanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusKm = 6371
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"stroycity/pkg/model"
+)
+
+type AttributeRepository struct {
+	db *gorm.DB
+}
+
+func NewAttributeRepository(db *gorm.DB) *AttributeRepository {
+	return &AttributeRepository{db: db}
+}
+
+func (r *AttributeRepository) CreateAttribute(attribute model.Attribute) (int, error) {
+	if err := r.db.Create(&attribute).Error; err != nil {
+		return 0, err
+	}
+	return attribute.ID, nil
+}
+
+func (r *AttributeRepository) DeleteAttribute(id int) error {
+	return r.db.Delete(&model.Attribute{}, id).Error
+}
+
+func (r *AttributeRepository) GetAttributesByCategoryID(categoryID int) ([]model.Attribute, error) {
+	var attributes []model.Attribute
+	if err := r.db.Preload("Options").Where("category_id = ?", categoryID).Find(&attributes).Error; err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
@@ -13,12 +13,53 @@ func NewBrandRepository(db *gorm.DB) *BrandRepository {
 	return &BrandRepository{db: db}
 }
 
-func (r *BrandRepository) CreateBrand(brand model.Brand) error {
-	return r.db.Create(&brand).Error
+func (r *BrandRepository) CreateBrand(brand model.Brand) (int, error) {
+	if err := r.db.Create(&brand).Error; err != nil {
+		return 0, err
+	}
+	return brand.ID, nil
+}
+
+// GetBrandById возвращает бренд; если его нет, возвращается пустой бренд с нулевым ID
+func (r *BrandRepository) GetBrandById(id int) (model.Brand, error) {
+	var brand model.Brand
+	if err := r.db.Where("id = ?", id).Limit(1).Find(&brand).Error; err != nil {
+		return brand, err
+	}
+	return brand, nil
+}
+
+func (r *BrandRepository) UpdateBrand(brand model.Brand) error {
+	return r.db.Model(&model.Brand{}).Where("id = ?", brand.ID).Select("Name", "Description", "LogoKey", "MetaTitle", "MetaDescription").Updates(&brand).Error
+}
+
+func (r *BrandRepository) CountBrandItems(id int) (int64, error) {
+	return countItemsByColumn(r.db, "brand_id", id)
 }
 
 func (r *BrandRepository) DeleteBrand(id int) error {
-	return r.db.Delete(&model.Brand{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteSlugRedirects(tx, model.SlugEntityBrand, id); err != nil {
+			return err
+		}
+		return tx.Delete(&model.Brand{}, id).Error
+	})
+}
+
+// MergeBrands переносит товары и адреса брендов sourceIDs в бренд target, сохраняет target и удаляет дубликаты
+func (r *BrandRepository) MergeBrands(target model.Brand, sourceIDs []int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := reassignItems(tx, "brand_id", target.ID, sourceIDs); err != nil {
+			return err
+		}
+		if err := redirectMergedSlugs(tx, model.SlugEntityBrand, target.ID, sourceIDs); err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Brand{}).Where("id = ?", target.ID).Select("Name", "Description", "LogoKey", "MetaTitle", "MetaDescription").Updates(&target).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Brand{}, sourceIDs).Error
+	})
 }
 
 func (r *BrandRepository) GetBrandList() ([]model.Brand, error) {
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"stroycity/pkg/model"
+)
+
+type BundleRepository struct {
+	db *gorm.DB
+}
+
+func NewBundleRepository(db *gorm.DB) *BundleRepository {
+	return &BundleRepository{db: db}
+}
+
+// CreateBundle создаёт комплект; заявка на модерацию request, если задана, создаётся в той же транзакции
+func (r *BundleRepository) CreateBundle(bundle model.Bundle, request *model.ModerationRequest) (int, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&bundle).Error; err != nil {
+			return err
+		}
+		if request == nil {
+			return nil
+		}
+		request.BundleID = &bundle.ID
+		return tx.Omit("Item", "Bundle").Create(request).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return bundle.ID, nil
+}
+
+// preloadBundle загружает состав комплекта с товарами; удалённые товары не загружаются,
+// и такой компонент остаётся с нулевым Item
+func (r *BundleRepository) preloadBundle() *gorm.DB {
+	return r.db.Preload("Category").
+		Preload("Components", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Components.Item.Images")
+}
+
+// GetBundleById возвращает комплект с составом; если его нет, ID нулевой
+func (r *BundleRepository) GetBundleById(id int) (model.Bundle, error) {
+	var bundle model.Bundle
+	if err := r.preloadBundle().Where("id = ?", id).Limit(1).Find(&bundle).Error; err != nil {
+		return bundle, err
+	}
+	return bundle, nil
+}
+
+// GetBundles возвращает комплекты с составом; пустые фильтры не ограничивают выборку
+func (r *BundleRepository) GetBundles(categoryID int, sellerID, status string) ([]model.Bundle, error) {
+	query := r.preloadBundle()
+	if categoryID != 0 {
+		query = query.Where("category_id = ?", categoryID)
+	}
+	if sellerID != "" {
+		query = query.Where("seller_id = ?", sellerID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var bundles []model.Bundle
+	if err := query.Order("id").Find(&bundles).Error; err != nil {
+		return nil, err
+	}
+	return bundles, nil
+}
+
+// UpdateBundle сохраняет поля комплекта и заменяет его состав. Заявка на модерацию request, если задана,
+// сохраняется в той же транзакции; снятый с публикации комплект теряет ожидающую заявку.
+func (r *BundleRepository) UpdateBundle(bundle model.Bundle, request *model.ModerationRequest) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Bundle{}).Where("id = ?", bundle.ID).Updates(map[string]interface{}{
+			"name":        bundle.Name,
+			"description": bundle.Description,
+			"category_id": bundle.CategoryID,
+			"price":       bundle.Price,
+			"currency":    bundle.Currency,
+			"status":      bundle.Status,
+		}).Error; err != nil {
+			return err
+		}
+
+		if request != nil {
+			if err := tx.Omit("Item", "Bundle").Save(request).Error; err != nil {
+				return err
+			}
+		} else if bundle.Status != model.ItemStatusPublished && bundle.Status != model.ItemStatusModeration {
+			if err := withdrawBundleModeration(tx, bundle.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("bundle_id = ?", bundle.ID).Delete(&model.BundleComponent{}).Error; err != nil {
+			return err
+		}
+		for i := range bundle.Components {
+			bundle.Components[i].ID = 0
+			bundle.Components[i].BundleID = bundle.ID
+		}
+		return tx.Create(&bundle.Components).Error
+	})
+}
+
+// DeleteBundle удаляет комплект и убирает его из корзин. Состав сохраняется для истории заказов.
+func (r *BundleRepository) DeleteBundle(id int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("bundle_id = ?", id).Delete(&model.CartBundle{}).Error; err != nil {
+			return err
+		}
+		if err := withdrawBundleModeration(tx, id); err != nil {
+			return err
+		}
+		return tx.Delete(&model.Bundle{}, id).Error
+	})
+}
+
+// withdrawBundleModeration отзывает ожидающую заявку на модерацию комплекта
+func withdrawBundleModeration(tx *gorm.DB, bundleID int) error {
+	return tx.Model(&model.ModerationRequest{}).Where("bundle_id = ? AND status = ?", bundleID, model.ModerationStatusPending).
+		Update("status", model.ModerationStatusWithdrawn).Error
+}
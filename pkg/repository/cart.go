@@ -2,6 +2,7 @@ package repository
 
 import (
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"stroycity/pkg/model"
 )
 
@@ -38,8 +39,14 @@ func (r *CartRepository) RemoveFromCart(cartItemID int) error {
 	return r.db.Model(&model.CartItem{}).Delete(&model.CartItem{}, cartItemID).Error
 }
 
+// ClearCart удаляет из корзины и товары, и комплекты
 func (r *CartRepository) ClearCart(buyerID string) error {
-	return r.db.Model(&model.CartItem{}).Where("buyer_id = ?", buyerID).Delete(&model.CartItem{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("buyer_id = ?", buyerID).Delete(&model.CartBundle{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.CartItem{}).Where("buyer_id = ?", buyerID).Delete(&model.CartItem{}).Error
+	})
 }
 
 func (r *CartRepository) GetCartItemsByBuyerID(buyerID string) ([]model.CartItem, error) {
@@ -49,3 +56,27 @@ func (r *CartRepository) GetCartItemsByBuyerID(buyerID string) ([]model.CartItem
 	}
 	return cartItems, nil
 }
+
+// SaveCartBundle добавляет комплект в корзину или заменяет его количество, если он уже там есть
+func (r *CartRepository) SaveCartBundle(cartBundle model.CartBundle) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "buyer_id"}, {Name: "bundle_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"quantity"}),
+	}).Create(&cartBundle).Error
+}
+
+// GetCartBundles возвращает комплекты корзины с составом; у удалённого комплекта Bundle нулевой
+func (r *CartRepository) GetCartBundles(buyerID string) ([]model.CartBundle, error) {
+	var cartBundles []model.CartBundle
+	if err := r.db.Preload("Bundle.Components", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Where("buyer_id = ?", buyerID).Order("id").Find(&cartBundles).Error; err != nil {
+		return nil, err
+	}
+	return cartBundles, nil
+}
+
+// RemoveCartBundle удаляет комплект из корзины; false, если его там не было
+func (r *CartRepository) RemoveCartBundle(buyerID string, bundleID int) (bool, error) {
+	result := r.db.Where("buyer_id = ? AND bundle_id = ?", buyerID, bundleID).Delete(&model.CartBundle{})
+	return result.RowsAffected > 0, result.Error
+}
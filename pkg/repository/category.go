@@ -2,6 +2,7 @@ package repository
 
 import (
 	"gorm.io/gorm"
+	"strings"
 	"stroycity/pkg/model"
 )
 
@@ -13,12 +14,68 @@ func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
 	return &CategoryRepository{db: db}
 }
 
-func (r *CategoryRepository) CreateCategory(category model.Category) error {
-	return r.db.Create(&category).Error
+func (r *CategoryRepository) CreateCategory(category model.Category) (int, error) {
+	if err := r.db.Create(&category).Error; err != nil {
+		return 0, err
+	}
+	return category.ID, nil
+}
+
+// GetCategoryById возвращает категорию; если её нет, возвращается пустая категория с нулевым ID
+func (r *CategoryRepository) GetCategoryById(id int) (model.Category, error) {
+	var category model.Category
+	if err := r.db.Where("id = ?", id).Limit(1).Find(&category).Error; err != nil {
+		return category, err
+	}
+	return category, nil
+}
+
+func (r *CategoryRepository) UpdateCategory(category model.Category) error {
+	return r.db.Model(&model.Category{}).Where("id = ?", category.ID).Select("Name", "MetaTitle", "MetaDescription").Updates(&category).Error
 }
 
+func (r *CategoryRepository) CountCategoryItems(id int) (int64, error) {
+	return countItemsByColumn(r.db, "category_id", id)
+}
+
+// CountCategoryBundles возвращает число комплектов категории, включая удалённые: они тоже ссылаются на неё
+func (r *CategoryRepository) CountCategoryBundles(id int) (int64, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&model.Bundle{}).Where("category_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// DeleteCategory удаляет категорию вместе с её характеристиками
 func (r *CategoryRepository) DeleteCategory(id int) error {
-	return r.db.Delete(&model.Category{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("category_id = ?", id).Delete(&model.Attribute{}).Error; err != nil {
+			return err
+		}
+		if err := deleteSlugRedirects(tx, model.SlugEntityCategory, id); err != nil {
+			return err
+		}
+		return tx.Delete(&model.Category{}, id).Error
+	})
+}
+
+// MergeCategories переносит товары, комплекты, характеристики и адреса категорий sourceIDs в категорию targetID и удаляет дубликаты
+func (r *CategoryRepository) MergeCategories(targetID int, sourceIDs []int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := reassignItems(tx, "category_id", targetID, sourceIDs); err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&model.Bundle{}).Where("category_id IN ?", sourceIDs).
+			Update("category_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := mergeCategoryAttributes(tx, targetID, sourceIDs); err != nil {
+			return err
+		}
+		if err := redirectMergedSlugs(tx, model.SlugEntityCategory, targetID, sourceIDs); err != nil {
+			return err
+		}
+		return tx.Delete(&model.Category{}, sourceIDs).Error
+	})
 }
 
 func (r *CategoryRepository) GetCategoryList() ([]model.Category, error) {
@@ -28,3 +85,66 @@ func (r *CategoryRepository) GetCategoryList() ([]model.Category, error) {
 	}
 	return categories, nil
 }
+
+// mergeCategoryAttributes переносит характеристики категорий sourceIDs в категорию targetID.
+// Одноимённая характеристика того же типа объединяется с характеристикой целевой категории:
+// значения товаров переходят к ней, недостающие варианты перечисления добавляются.
+func mergeCategoryAttributes(tx *gorm.DB, targetID int, sourceIDs []int) error {
+	var targetAttributes, sourceAttributes []model.Attribute
+	if err := tx.Preload("Options").Where("category_id = ?", targetID).Find(&targetAttributes).Error; err != nil {
+		return err
+	}
+	if err := tx.Preload("Options").Where("category_id IN ?", sourceIDs).Order("id").Find(&sourceAttributes).Error; err != nil {
+		return err
+	}
+
+	attributeKey := func(attribute model.Attribute) string {
+		return strings.ToLower(strings.TrimSpace(attribute.Name)) + "|" + attribute.Type
+	}
+	existing := map[string]*model.Attribute{}
+	for i := range targetAttributes {
+		existing[attributeKey(targetAttributes[i])] = &targetAttributes[i]
+	}
+
+	for i := range sourceAttributes {
+		source := &sourceAttributes[i]
+		target, ok := existing[attributeKey(*source)]
+		if !ok {
+			if err := tx.Model(&model.Attribute{}).Where("id = ?", source.ID).Update("category_id", targetID).Error; err != nil {
+				return err
+			}
+			existing[attributeKey(*source)] = source
+			continue
+		}
+
+		options := map[string]bool{}
+		for _, option := range target.Options {
+			options[strings.ToLower(option.Value)] = true
+		}
+		for _, option := range source.Options {
+			if options[strings.ToLower(option.Value)] {
+				continue
+			}
+			added := model.AttributeOption{AttributeID: target.ID, Value: option.Value}
+			if err := tx.Create(&added).Error; err != nil {
+				return err
+			}
+			target.Options = append(target.Options, added)
+			options[strings.ToLower(option.Value)] = true
+		}
+
+		// Если у товара уже есть значение целевой характеристики, оно сохраняется
+		targetValues := tx.Model(&model.ItemAttribute{}).Select("item_id").Where("attribute_id = ?", target.ID)
+		if err := tx.Model(&model.ItemAttribute{}).Where("attribute_id = ? AND item_id NOT IN (?)", source.ID, targetValues).
+			Update("attribute_id", target.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("attribute_id = ?", source.ID).Delete(&model.ItemAttribute{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.Attribute{}, source.ID).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"stroycity/pkg/model"
+)
+
+type ComparisonRepository struct {
+	db *gorm.DB
+}
+
+func NewComparisonRepository(db *gorm.DB) *ComparisonRepository {
+	return &ComparisonRepository{db: db}
+}
+
+// AddComparisonItem добавляет товар в список сравнения; повторное добавление ничего не меняет
+func (r *ComparisonRepository) AddComparisonItem(item model.ComparisonItem) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&item).Error
+}
+
+// GetComparisonItems возвращает товары списка сравнения в порядке добавления; categoryID 0 — всех категорий.
+// Удалённые товары в список не попадают.
+func (r *ComparisonRepository) GetComparisonItems(buyerID, deviceToken string, categoryID int) ([]model.ComparisonItem, error) {
+	query := r.db.Preload("Item.Category").Preload("Item.Images").
+		Joins("JOIN items ON items.id = comparison_items.item_id AND items.deleted_at IS NULL").
+		Where("comparison_items.buyer_id = ? AND comparison_items.device_token = ?", buyerID, deviceToken)
+	if categoryID != 0 {
+		query = query.Where("items.category_id = ?", categoryID)
+	}
+
+	var items []model.ComparisonItem
+	if err := query.Order("comparison_items.created_at, comparison_items.id").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// RemoveComparisonItem удаляет товар из списка сравнения; false, если его там не было
+func (r *ComparisonRepository) RemoveComparisonItem(buyerID, deviceToken string, itemID int) (bool, error) {
+	result := r.db.Where("buyer_id = ? AND device_token = ? AND item_id = ?", buyerID, deviceToken, itemID).
+		Delete(&model.ComparisonItem{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ClearComparison очищает список сравнения категории; categoryID 0 — все списки
+func (r *ComparisonRepository) ClearComparison(buyerID, deviceToken string, categoryID int) error {
+	query := r.db.Where("buyer_id = ? AND device_token = ?", buyerID, deviceToken)
+	if categoryID != 0 {
+		query = query.Where("item_id IN (?)", r.db.Model(&model.Item{}).Unscoped().Select("id").Where("category_id = ?", categoryID))
+	}
+	return query.Delete(&model.ComparisonItem{}).Error
+}
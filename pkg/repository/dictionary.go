@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"stroycity/pkg/model"
+)
+
+// countItemsByColumn считает товары, ссылающиеся на запись справочника.
+// Удалённые товары тоже учитываются: на них ссылается история заказов.
+func countItemsByColumn(db *gorm.DB, column string, id int) (int64, error) {
+	var count int64
+	err := db.Unscoped().Model(&model.Item{}).Where(column+" = ?", id).Count(&count).Error
+	return count, err
+}
+
+// reassignItems переносит товары из записей sourceIDs в запись targetID и увеличивает их версию
+func reassignItems(tx *gorm.DB, column string, targetID int, sourceIDs []int) error {
+	return tx.Unscoped().Model(&model.Item{}).Where(column+" IN ?", sourceIDs).UpdateColumns(map[string]interface{}{
+		column:    targetID,
+		"version": gorm.Expr("version + 1"),
+	}).Error
+}
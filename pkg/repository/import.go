@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"stroycity/pkg/model"
+)
+
+type ImportRepository struct {
+	db *gorm.DB
+}
+
+func NewImportRepository(db *gorm.DB) *ImportRepository {
+	return &ImportRepository{db: db}
+}
+
+func (r *ImportRepository) CreateImportJob(job model.ImportJob) (int, error) {
+	if err := r.db.Create(&job).Error; err != nil {
+		return 0, err
+	}
+	return job.ID, nil
+}
+
+func (r *ImportRepository) GetImportJob(id int) (model.ImportJob, error) {
+	var job model.ImportJob
+	if err := r.db.First(&job, id).Error; err != nil {
+		return job, err
+	}
+	return job, nil
+}
+
+// UpdateImportJobProgress сохраняет счётчики и статус задачи вместе с накопленными ошибками строк
+func (r *ImportRepository) UpdateImportJobProgress(job model.ImportJob, rowErrors []model.ImportRowError) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Errors", "CreatedAt").Save(&job).Error; err != nil {
+			return err
+		}
+		if len(rowErrors) == 0 {
+			return nil
+		}
+		for i := range rowErrors {
+			rowErrors[i].JobID = job.ID
+		}
+		return tx.Create(&rowErrors).Error
+	})
+}
+
+func (r *ImportRepository) GetImportErrors(jobID int) ([]model.ImportRowError, error) {
+	var rowErrors []model.ImportRowError
+	if err := r.db.Where("job_id = ?", jobID).Order("row, id").Find(&rowErrors).Error; err != nil {
+		return nil, err
+	}
+	return rowErrors, nil
+}
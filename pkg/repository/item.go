@@ -1,10 +1,16 @@
 package repository
 
 import (
+	"errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"stroycity/pkg/model"
+	"time"
 )
 
+// ErrItemReserved возвращается при удалении товара, зарезервированного под неоплаченные заказы
+var ErrItemReserved = errors.New("item is reserved by unpaid orders")
+
 type ItemRepository struct {
 	db *gorm.DB
 }
@@ -13,8 +19,18 @@ func NewItemRepository(db *gorm.DB) *ItemRepository {
 	return &ItemRepository{db: db}
 }
 
-func (r *ItemRepository) CreateItem(item model.Item) (int, error) {
-	if err := r.db.Model(&model.Item{}).Create(&item).Error; err != nil {
+// CreateItem создаёт товар; начальный остаток записывается в журнал движений как change, цены — в историю цен
+func (r *ItemRepository) CreateItem(item model.Item, change model.StockChange) (int, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Item{}).Create(&item).Error; err != nil {
+			return err
+		}
+		if err := recordPriceChange(tx, item.ID, model.Item{}); err != nil {
+			return err
+		}
+		return recordStockMovements(tx, item.ID, []model.StockMovement{{Delta: item.Quantity}}, change)
+	})
+	if err != nil {
 		return 0, err
 	}
 	return item.ID, nil
@@ -22,20 +38,165 @@ func (r *ItemRepository) CreateItem(item model.Item) (int, error) {
 
 func (r *ItemRepository) GetItemById(itemID int) (model.Item, error) {
 	var item model.Item
-	if err := r.db.Preload("Brand").Preload("Seller").Preload("Category").Preload("Material").Preload("Images").First(&item, itemID).Error; err != nil {
+	if err := r.db.Preload("Brand").Preload("Seller").Preload("Category").Preload("Material").Preload("Images.Renditions").Preload("Attributes.Attribute").Preload("VariantValues").Preload("PriceTiers", func(db *gorm.DB) *gorm.DB { return db.Order("min_quantity") }).First(&item, itemID).Error; err != nil {
 		return item, err
 	}
 	return item, nil
 }
 
-func (r *ItemRepository) UpdateItem(item model.Item) error {
-	return r.db.Save(&item).Error
+// FindItemById возвращает товар без связанных записей; если товара нет, ID нулевой
+func (r *ItemRepository) FindItemById(itemID int) (model.Item, error) {
+	var item model.Item
+	if err := r.db.Where("id = ?", itemID).Limit(1).Find(&item).Error; err != nil {
+		return item, err
+	}
+	return item, nil
+}
+
+// GetItemByIdUnscoped возвращает товар, в том числе удалённый, — для истории заказов
+func (r *ItemRepository) GetItemByIdUnscoped(itemID int) (model.Item, error) {
+	var item model.Item
+	if err := r.db.Unscoped().First(&item, itemID).Error; err != nil {
+		return item, err
+	}
+	return item, nil
+}
+
+// SetItemStatus меняет статус товара и увеличивает его версию
+func (r *ItemRepository) SetItemStatus(itemID int, status string) error {
+	return r.db.Model(&model.Item{}).Where("id = ?", itemID).UpdateColumns(map[string]interface{}{
+		"status":  status,
+		"version": gorm.Expr("version + 1"),
+	}).Error
+}
+
+// DeleteItem мягко удаляет товар: запись остаётся для истории заказов, а из корзин товар убирается.
+// Товар с действующими резервами не удаляется, пока заказы не будут оплачены или отменены.
+func (r *ItemRepository) DeleteItem(itemID int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Блокировка товара не даёт оформить на него новый резерв между проверкой и удалением
+		var item model.Item
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", itemID).Limit(1).Find(&item).Error; err != nil {
+			return err
+		}
+		var reserved int64
+		if err := tx.Model(&model.Reservation{}).
+			Where("item_id = ? AND status = ? AND expires_at > ?", itemID, model.ReservationStatusActive, time.Now()).
+			Count(&reserved).Error; err != nil {
+			return err
+		}
+		if reserved > 0 {
+			return ErrItemReserved
+		}
+
+		if err := tx.Where("item_id = ?", itemID).Delete(&model.CartItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Item{}, itemID).Error
+	})
+}
+
+// GetItemByArticle ищет товар продавца по артикулу без связанных записей.
+// Если товар не найден, возвращается пустой товар с нулевым ID.
+func (r *ItemRepository) GetItemByArticle(sellerID, article string) (model.Item, error) {
+	var item model.Item
+	if err := r.db.Where("seller_id = ? AND article = ?", sellerID, article).Limit(1).Find(&item).Error; err != nil {
+		return item, err
+	}
+	return item, nil
+}
+
+// GetItemByExternalID ищет товар продавца по Ид из учётной системы (1С).
+// Если товар не найден, возвращается пустой товар с нулевым ID.
+func (r *ItemRepository) GetItemByExternalID(sellerID, externalID string) (model.Item, error) {
+	var item model.Item
+	if err := r.db.Where("seller_id = ? AND external_id = ?", sellerID, externalID).Limit(1).Find(&item).Error; err != nil {
+		return item, err
+	}
+	return item, nil
+}
+
+// UpdateItem сохраняет товар; изменение остатка записывается в журнал движений как change, изменение цен — в историю цен
+func (r *ItemRepository) UpdateItem(item model.Item, change model.StockChange, request *model.ModerationRequest) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var before model.Item
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "quantity", "price", "price_with_discount").Where("id = ?", item.ID).Limit(1).Find(&before).Error; err != nil {
+			return err
+		}
+
+		// Статус меняется только через SetItemStatus и DeleteItem, адрес — через SlugRepository
+		if err := tx.Omit("Attributes", "VariantValues", "PriceTiers", "Version", "Status", "DeletedAt", "Slug").Save(&item).Error; err != nil {
+			return err
+		}
+		// Любое изменение товара увеличивает версию для оптимистичной блокировки
+		if err := tx.Model(&model.Item{}).Where("id = ?", item.ID).UpdateColumn("version", gorm.Expr("version + 1")).Error; err != nil {
+			return err
+		}
+
+		// Характеристики, значения вариантов и оптовые цены заменяются целиком, только если они переданы
+		if item.Attributes != nil {
+			for i := range item.Attributes {
+				item.Attributes[i].ID = 0
+				item.Attributes[i].ItemID = item.ID
+			}
+			if err := replaceItemRecords(tx, item.ID, &model.ItemAttribute{}, &item.Attributes, len(item.Attributes), "Attribute"); err != nil {
+				return err
+			}
+		}
+
+		if item.VariantValues != nil {
+			for i := range item.VariantValues {
+				item.VariantValues[i].ID = 0
+				item.VariantValues[i].ItemID = item.ID
+			}
+			if err := replaceItemRecords(tx, item.ID, &model.VariantValue{}, &item.VariantValues, len(item.VariantValues), "Axis"); err != nil {
+				return err
+			}
+		}
+
+		if item.PriceTiers != nil {
+			for i := range item.PriceTiers {
+				item.PriceTiers[i].ID = 0
+				item.PriceTiers[i].ItemID = item.ID
+			}
+			if err := replaceItemRecords(tx, item.ID, &model.PriceTier{}, &item.PriceTiers, len(item.PriceTiers)); err != nil {
+				return err
+			}
+		}
+
+		// Заявка на модерацию правки сохраняется вместе с изменением товара
+		if request != nil {
+			if err := tx.Omit("Item", "Bundle").Save(request).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := recordPriceChange(tx, item.ID, before); err != nil {
+			return err
+		}
+		return recordStockMovements(tx, item.ID, []model.StockMovement{{Delta: item.Quantity - before.Quantity}}, change)
+	})
+}
+
+// replaceItemRecords удаляет дочерние записи товара из таблицы table и создаёт records
+func replaceItemRecords(tx *gorm.DB, itemID int, table interface{}, records interface{}, count int, omit ...string) error {
+	if err := tx.Where("item_id = ?", itemID).Delete(table).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return nil
+	}
+	if len(omit) > 0 {
+		tx = tx.Omit(omit...)
+	}
+	return tx.Create(records).Error
 }
 
-func (r *ItemRepository) GetItems(brandIDs, sellerIDs, categoryIDs, materialIDs []uint, minPrice, maxPrice float64, query string) ([]model.Item, error) {
+func (r *ItemRepository) GetItems(brandIDs, sellerIDs, categoryIDs, materialIDs []uint, minPrice, maxPrice model.Money, query string, attributes []model.AttributeFilter, sort string) ([]model.Item, error) {
 	var items []model.Item
 
-	params := r.db.Model(&model.Item{}).Preload("Brand").Preload("Seller").Preload("Category").Preload("Material").Preload("Images")
+	params := r.db.Model(&model.Item{}).Preload("Brand").Preload("Seller").Preload("Category").Preload("Material").Preload("Images").
+		Where("status = ?", model.ItemStatusPublished)
 
 	if len(brandIDs) > 0 {
 		params = params.Where("brand_id IN ?", brandIDs)
@@ -63,6 +224,22 @@ func (r *ItemRepository) GetItems(brandIDs, sellerIDs, categoryIDs, materialIDs
 		params = params.Where("name ILIKE ?", "%"+query+"%")
 	}
 
+	for _, attribute := range attributes {
+		params = params.Where("id IN (?)", r.attributeFilterQuery(attribute))
+	}
+
+	switch sort {
+	case model.ItemSortPopular:
+		// Популярность — число просмотров карточки товара
+		params = params.Order("(SELECT views FROM item_view_counts WHERE item_view_counts.item_id = items.id) DESC NULLS LAST, id")
+	case model.ItemSortPriceAsc:
+		params = params.Order("price_with_discount, id")
+	case model.ItemSortPriceDesc:
+		params = params.Order("price_with_discount DESC, id")
+	case model.ItemSortNewest:
+		params = params.Order("id DESC")
+	}
+
 	err := params.Find(&items).Error
 	if err != nil {
 		return nil, err
@@ -71,14 +248,145 @@ func (r *ItemRepository) GetItems(brandIDs, sellerIDs, categoryIDs, materialIDs
 	return items, nil
 }
 
+// attributeFilterQuery возвращает подзапрос с ID товаров, подходящих под условие по характеристике
+func (r *ItemRepository) attributeFilterQuery(filter model.AttributeFilter) *gorm.DB {
+	subQuery := r.db.Model(&model.ItemAttribute{}).Select("item_id").Where("attribute_id = ?", filter.AttributeID)
+
+	if filter.Min != nil {
+		subQuery = subQuery.Where("number_value >= ?", *filter.Min)
+	}
+	if filter.Max != nil {
+		subQuery = subQuery.Where("number_value <= ?", *filter.Max)
+	}
+	if len(filter.Values) > 0 {
+		subQuery = subQuery.Where("enum_value IN ?", filter.Values)
+	}
+	if filter.Bool != nil {
+		subQuery = subQuery.Where("bool_value = ?", *filter.Bool)
+	}
+
+	return subQuery
+}
+
 func (r *ItemRepository) GetAllItems() ([]model.Item, error) {
 	var items []model.Item
-	if err := r.db.Find(&items).Error; err != nil {
+	if err := r.db.Preload("Images").Where("status = ?", model.ItemStatusPublished).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetItemsForFeed возвращает опубликованные товары для выгрузки каталога; пустой sellerID — все товары площадки
+func (r *ItemRepository) GetItemsForFeed(sellerID string) ([]model.Item, error) {
+	var items []model.Item
+	query := r.db.Preload("Brand").Preload("Category").Preload("Material").Preload("Images").
+		Where("status = ?", model.ItemStatusPublished).Order("id")
+	if sellerID != "" {
+		query = query.Where("seller_id = ?", sellerID)
+	}
+	if err := query.Find(&items).Error; err != nil {
 		return nil, err
 	}
 	return items, nil
 }
 
-func (r *ItemRepository) SaveImage(image model.Image) error {
-	return r.db.Model(&model.Image{}).Create(&image).Error
+// GetItemsForSitemap возвращает ID и адреса опубликованных товаров
+func (r *ItemRepository) GetItemsForSitemap() ([]model.Item, error) {
+	var items []model.Item
+	if err := r.db.Select("id", "slug").Where("status = ?", model.ItemStatusPublished).Order("id").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *ItemRepository) SaveImage(image model.Image) (model.Image, error) {
+	if err := r.db.Model(&model.Image{}).Create(&image).Error; err != nil {
+		return image, err
+	}
+	return image, nil
+}
+
+// GetImagesByItemID возвращает изображения товара в порядке показа
+func (r *ItemRepository) GetImagesByItemID(itemID int) ([]model.Image, error) {
+	var images []model.Image
+	if err := r.db.Preload("Renditions").Where("item_id = ?", itemID).Order("is_primary DESC, position, id").Find(&images).Error; err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// UpdateImagePositions выставляет позиции изображений товара в порядке переданных ID
+func (r *ItemRepository) UpdateImagePositions(itemID int, imageIDs []int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for position, imageID := range imageIDs {
+			if err := tx.Model(&model.Image{}).Where("id = ? AND item_id = ?", imageID, itemID).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SetPrimaryImage делает изображение основным, снимая признак с остальных изображений товара
+func (r *ItemRepository) SetPrimaryImage(itemID, imageID int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Image{}).Where("item_id = ? AND id <> ?", itemID, imageID).Update("is_primary", false).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&model.Image{}).Where("id = ? AND item_id = ?", imageID, itemID).Update("is_primary", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// DeleteImage удаляет изображение товара вместе с копиями и возвращает его для очистки хранилища
+func (r *ItemRepository) DeleteImage(itemID, imageID int) (model.Image, error) {
+	var image model.Image
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Preload("Renditions").Where("item_id = ?", itemID).First(&image, imageID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("image_id = ?", image.ID).Delete(&model.ImageRendition{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&image).Error
+	})
+	return image, err
+}
+
+// UpdateItemInventory частично обновляет остаток и цены товара, если его версия не изменилась.
+// Возвращает false, если товар успели изменить с момента чтения.
+func (r *ItemRepository) UpdateItemInventory(itemID, version int, fields map[string]interface{}, change model.StockChange) (bool, error) {
+	updated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var before model.Item
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "quantity", "price", "price_with_discount").Where("id = ?", itemID).Limit(1).Find(&before).Error; err != nil {
+			return err
+		}
+
+		fields["version"] = gorm.Expr("version + 1")
+		result := tx.Model(&model.Item{}).Where("id = ? AND version = ?", itemID, version).UpdateColumns(fields)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return nil
+		}
+		updated = true
+
+		if err := recordPriceChange(tx, itemID, before); err != nil {
+			return err
+		}
+		quantity, ok := fields["quantity"].(int)
+		if !ok {
+			return nil
+		}
+		return recordStockMovements(tx, itemID, []model.StockMovement{{Delta: quantity - before.Quantity}}, change)
+	})
+	return updated, err
 }
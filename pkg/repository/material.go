@@ -17,10 +17,37 @@ func (r *MaterialRepository) CreateMaterial(material model.Material) error {
 	return r.db.Create(&material).Error
 }
 
+// GetMaterialById возвращает материал; если его нет, возвращается пустой материал с нулевым ID
+func (r *MaterialRepository) GetMaterialById(id int) (model.Material, error) {
+	var material model.Material
+	if err := r.db.Where("id = ?", id).Limit(1).Find(&material).Error; err != nil {
+		return material, err
+	}
+	return material, nil
+}
+
+func (r *MaterialRepository) UpdateMaterial(material model.Material) error {
+	return r.db.Model(&model.Material{}).Where("id = ?", material.ID).Update("name", material.Name).Error
+}
+
+func (r *MaterialRepository) CountMaterialItems(id int) (int64, error) {
+	return countItemsByColumn(r.db, "material_id", id)
+}
+
 func (r *MaterialRepository) DeleteMaterial(id int) error {
 	return r.db.Delete(&model.Material{}, id).Error
 }
 
+// MergeMaterials переносит товары материалов sourceIDs в материал targetID и удаляет дубликаты
+func (r *MaterialRepository) MergeMaterials(targetID int, sourceIDs []int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := reassignItems(tx, "material_id", targetID, sourceIDs); err != nil {
+			return err
+		}
+		return tx.Delete(&model.Material{}, sourceIDs).Error
+	})
+}
+
 func (r *MaterialRepository) GetMaterialList() ([]model.Material, error) {
 	var materials []model.Material
 	if err := r.db.Find(&materials).Error; err != nil {
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"stroycity/pkg/model"
+)
+
+// moneyColumns — денежные колонки, которые раньше хранились в рублях как float
+var moneyColumns = []struct {
+	Table  string
+	Column string
+}{
+	{"items", "price"},
+	{"items", "price_with_discount"},
+	{"price_tiers", "price"},
+	{"orders", "total"},
+	{"order_items", "unit_price"},
+	{"order_items", "total"},
+	{"sellers", "balance"},
+}
+
+// migrateMoneyToMinorUnits переводит денежные колонки из рублей (double precision)
+// в копейки (bigint) с округлением до копейки. Повторный запуск ничего не меняет.
+func migrateMoneyToMinorUnits(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, money := range moneyColumns {
+			var dataType string
+			err := tx.Raw(`SELECT data_type FROM information_schema.columns
+				WHERE table_schema = current_schema() AND table_name = ? AND column_name = ?`,
+				money.Table, money.Column).Scan(&dataType).Error
+			if err != nil {
+				return err
+			}
+
+			if dataType != "double precision" && dataType != "real" && dataType != "numeric" {
+				continue
+			}
+
+			query := fmt.Sprintf(`ALTER TABLE %q ALTER COLUMN %q TYPE bigint USING round(%q * 100)::bigint`,
+				money.Table, money.Column, money.Column)
+			if err := tx.Exec(query).Error; err != nil {
+				return fmt.Errorf("failed to migrate %s.%s to minor units: %w", money.Table, money.Column, err)
+			}
+		}
+		return nil
+	})
+}
+
+// migrateImageURLsToStorageKeys переименовывает колонку url изображений в storage_key
+// и убирает из старых записей префикс локального каталога uploads/, оставляя ключ объекта
+func migrateImageURLsToStorageKeys(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range []interface{}{&model.Image{}, &model.ImageRendition{}} {
+			migrator := tx.Migrator()
+			if !migrator.HasColumn(table, "url") || migrator.HasColumn(table, "storage_key") {
+				continue
+			}
+			if err := migrator.RenameColumn(table, "url", "storage_key"); err != nil {
+				return err
+			}
+			err := tx.Model(table).
+				Where("storage_key LIKE ?", "uploads/%").
+				Update("storage_key", gorm.Expr("substr(storage_key, length('uploads/') + 1)")).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// backfillSlugs генерирует адреса товарам, категориям и брендам, созданным до появления адресов.
+// Совпадающие адреса различаются числовым суффиксом. Повторный запуск ничего не меняет.
+func backfillSlugs(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, entityType := range []string{model.SlugEntityCategory, model.SlugEntityBrand, model.SlugEntityItem} {
+			table := slugTables[entityType]
+
+			var records []struct {
+				ID   int
+				Name string
+				Slug string
+			}
+			if err := tx.Unscoped().Model(table).Select("id", "name", "slug").Order("id").Scan(&records).Error; err != nil {
+				return err
+			}
+
+			var redirects []string
+			if err := tx.Model(&model.SlugRedirect{}).Where("entity_type = ?", entityType).Pluck("slug", &redirects).Error; err != nil {
+				return err
+			}
+			taken := map[string]bool{}
+			for _, slug := range redirects {
+				taken[slug] = true
+			}
+			for _, record := range records {
+				if record.Slug != "" {
+					taken[record.Slug] = true
+				}
+			}
+
+			for _, record := range records {
+				if record.Slug != "" {
+					continue
+				}
+				base := model.SlugBase(entityType, record.Name)
+				slug := base
+				for n := 2; taken[slug]; n++ {
+					slug = model.SlugCandidate(base, n)
+				}
+				taken[slug] = true
+				if err := tx.Unscoped().Model(table).Where("id = ?", record.ID).UpdateColumn("slug", slug).Error; err != nil {
+					return fmt.Errorf("failed to backfill %s slug: %w", entityType, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// allowBundleModerationRequests снимает NOT NULL с товара заявки на модерацию: у заявок на проверку
+// комплектов товара нет. Повторный запуск ничего не меняет.
+func allowBundleModerationRequests(db *gorm.DB) error {
+	return db.Exec("ALTER TABLE moderation_requests ALTER COLUMN item_id DROP NOT NULL").Error
+}
+
+// backfillPriceHistory записывает текущие цены товаров, у которых ещё нет истории цен,
+// чтобы график начинался с цены на момент появления истории. Повторный запуск ничего не меняет.
+func backfillPriceHistory(db *gorm.DB) error {
+	return db.Exec(`INSERT INTO price_changes (item_id, price, price_with_discount, created_at)
+		SELECT id, price, price_with_discount, now() FROM items
+		WHERE NOT EXISTS (SELECT 1 FROM price_changes WHERE price_changes.item_id = items.id)`).Error
+}
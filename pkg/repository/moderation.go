@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"math"
+	"stroycity/pkg/model"
+)
+
+type ModerationRepository struct {
+	db *gorm.DB
+}
+
+func NewModerationRepository(db *gorm.DB) *ModerationRepository {
+	return &ModerationRepository{db: db}
+}
+
+// SaveModerationRequest создаёт заявку или обновляет существующую
+func (r *ModerationRepository) SaveModerationRequest(request model.ModerationRequest) (int, error) {
+	if err := r.db.Omit("Item", "Bundle").Save(&request).Error; err != nil {
+		return 0, err
+	}
+	return request.ID, nil
+}
+
+// GetPendingModerationRequest возвращает непроверенную заявку товара.
+// Если её нет, возвращается пустая заявка с нулевым ID.
+func (r *ModerationRepository) GetPendingModerationRequest(itemID int) (model.ModerationRequest, error) {
+	var request model.ModerationRequest
+	if err := r.db.Where("item_id = ? AND status = ?", itemID, model.ModerationStatusPending).Limit(1).Find(&request).Error; err != nil {
+		return request, err
+	}
+	return request, nil
+}
+
+// GetPendingBundleModerationRequest возвращает непроверенную заявку комплекта.
+// Если её нет, возвращается пустая заявка с нулевым ID.
+func (r *ModerationRepository) GetPendingBundleModerationRequest(bundleID int) (model.ModerationRequest, error) {
+	var request model.ModerationRequest
+	if err := r.db.Where("bundle_id = ? AND status = ?", bundleID, model.ModerationStatusPending).Limit(1).Find(&request).Error; err != nil {
+		return request, err
+	}
+	return request, nil
+}
+
+func (r *ModerationRepository) GetModerationRequest(id int) (model.ModerationRequest, error) {
+	var request model.ModerationRequest
+	if err := r.db.Preload("Item").Preload("Bundle").First(&request, id).Error; err != nil {
+		return request, err
+	}
+	return request, nil
+}
+
+// GetModerationRequests возвращает заявки в статусе status вместе с товарами, комплектами и их изображениями
+func (r *ModerationRepository) GetModerationRequests(status string) ([]model.ModerationRequest, error) {
+	var requests []model.ModerationRequest
+	if err := r.db.Preload("Item.Images").Preload("Bundle.Components.Item.Images").Where("status = ?", status).Order("created_at, id").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// GetSellerModerationRequests возвращает заявки продавца, новые первыми; itemID ограничивает выборку одним товаром
+func (r *ModerationRepository) GetSellerModerationRequests(sellerID string, itemID int) ([]model.ModerationRequest, error) {
+	query := r.db.Preload("Item").Preload("Bundle").Where("seller_id = ?", sellerID)
+	if itemID != 0 {
+		query = query.Where("item_id = ?", itemID)
+	}
+
+	var requests []model.ModerationRequest
+	if err := query.Order("created_at DESC, id DESC").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// ReviewModerationRequest сохраняет решение по заявке, переводит товар или комплект в status и уведомляет продавца.
+// Одобренная правка опубликованного товара или комплекта применяется в той же транзакции.
+func (r *ModerationRepository) ReviewModerationRequest(request model.ModerationRequest, status string, notification model.Notification) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Item", "Bundle").Save(&request).Error; err != nil {
+			return err
+		}
+
+		if request.BundleID != nil {
+			columns := map[string]interface{}{}
+			if request.Status == model.ModerationStatusApproved && request.PendingBundle != nil {
+				columns = request.PendingBundle.Columns()
+			}
+			columns["status"] = status
+			if err := tx.Model(&model.Bundle{}).Where("id = ?", *request.BundleID).Updates(columns).Error; err != nil {
+				return err
+			}
+			return tx.Create(&notification).Error
+		}
+
+		columns := map[string]interface{}{}
+		if request.Status == model.ModerationStatusApproved && request.Pending != nil {
+			columns = request.Pending.Columns()
+		}
+		columns["status"] = status
+		columns["version"] = gorm.Expr("version + 1")
+		if err := tx.Model(&model.Item{}).Where("id = ?", *request.ItemID).UpdateColumns(columns).Error; err != nil {
+			return err
+		}
+		return tx.Create(&notification).Error
+	})
+}
+
+// GetCategoryMedianPrice возвращает медианную цену опубликованных товаров категории; 0, если их нет
+func (r *ModerationRepository) GetCategoryMedianPrice(categoryID int) (model.Money, error) {
+	var median *float64
+	err := r.db.Model(&model.Item{}).
+		Select("percentile_cont(0.5) WITHIN GROUP (ORDER BY price_with_discount)").
+		Where("category_id = ? AND status = ?", categoryID, model.ItemStatusPublished).
+		Scan(&median).Error
+	if err != nil || median == nil {
+		return 0, err
+	}
+	return model.Money(math.Round(*median)), nil
+}
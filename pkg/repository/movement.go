@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"stroycity/pkg/model"
+)
+
+const defaultMovementsLimit = 100
+
+type MovementRepository struct {
+	db *gorm.DB
+}
+
+func NewMovementRepository(db *gorm.DB) *MovementRepository {
+	return &MovementRepository{db: db}
+}
+
+// GetMovements возвращает движения остатков товаров продавца, новые первыми
+func (r *MovementRepository) GetMovements(sellerID string, filter model.StockMovementFilter) ([]model.StockMovement, error) {
+	query := r.db.Preload("Item").Where("seller_id = ?", sellerID)
+	if filter.ItemID != 0 {
+		query = query.Where("item_id = ?", filter.ItemID)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultMovementsLimit
+	}
+
+	var movements []model.StockMovement
+	if err := query.Order("created_at DESC, id DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&movements).Error; err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// SetLowStockThreshold задаёт порог низкого остатка товара или, если itemID пуст, порог продавца по умолчанию
+func (r *MovementRepository) SetLowStockThreshold(sellerID string, itemID *int, threshold int) error {
+	if itemID == nil {
+		return r.db.Model(&model.Seller{}).Where("id = ?", sellerID).UpdateColumn("low_stock_threshold", threshold).Error
+	}
+	return r.db.Model(&model.Item{}).Where("id = ? AND seller_id = ?", *itemID, sellerID).UpdateColumn("low_stock_threshold", threshold).Error
+}
+
+// recordStockMovements записывает в журнал уже применённые изменения остатка товара: по одному движению на склад
+// или одно движение общего остатка. Вызывается в транзакции изменения после пересчёта Item.Quantity.
+// Если общий остаток опустился до порога низкого остатка, продавец получает уведомление,
+// а если у товара снова появился свободный остаток — покупатели, добавившие его в избранное или подписавшиеся на поступление.
+// Движения удалённого товара (например, возврат по отмене заказа) тоже записываются, но без уведомлений.
+func recordStockMovements(tx *gorm.DB, itemID int, movements []model.StockMovement, change model.StockChange) error {
+	var item model.Item
+	if err := tx.Unscoped().Preload("Seller").Where("id = ?", itemID).Limit(1).Find(&item).Error; err != nil {
+		return err
+	}
+	if item.ID == 0 {
+		return nil
+	}
+
+	total := 0
+	for _, movement := range movements {
+		if movement.Delta == 0 {
+			continue
+		}
+		total += movement.Delta
+
+		movement.ItemID = item.ID
+		movement.SellerID = item.SellerID
+		movement.Type = change.Type
+		movement.ActorID = change.ActorID
+		movement.Reason = change.Reason
+		movement.OrderID = change.OrderID
+		movement.QuantityAfter = item.Quantity
+		if movement.WarehouseID != nil {
+			var stock model.WarehouseStock
+			if err := tx.Where("item_id = ? AND warehouse_id = ?", item.ID, *movement.WarehouseID).Limit(1).Find(&stock).Error; err != nil {
+				return err
+			}
+			movement.QuantityAfter = stock.Quantity
+		}
+		if err := tx.Create(&movement).Error; err != nil {
+			return err
+		}
+	}
+	if item.DeletedAt.Valid {
+		return nil
+	}
+
+	// Покупатели, добавившие товар в избранное или подписавшиеся на него, узнают о его поступлении.
+	// В наличии считается только остаток сверх действующих резервов.
+	before := item.Quantity - total
+	if item.Status == model.ItemStatusPublished && total > 0 {
+		reserved, err := reservedQuantity(tx, item.ID)
+		if err != nil {
+			return err
+		}
+		if model.Available(before, reserved) <= 0 && model.Available(item.Quantity, reserved) > 0 {
+			if err := notifyBackInStock(tx, item, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	threshold := item.LowStockThreshold
+	if threshold == 0 {
+		threshold = item.Seller.LowStockThreshold
+	}
+	// Уведомляем только при переходе через порог, а не при каждом движении ниже него
+	if threshold <= 0 || total >= 0 || item.Quantity > threshold || before <= threshold {
+		return nil
+	}
+	notification := model.Notification{
+		RecipientID: item.SellerID,
+		Type:        model.NotificationTypeLowStock,
+		Message:     fmt.Sprintf("Товар «%s» (артикул %s) заканчивается: осталось %d при пороге %d", item.Name, item.Article, item.Quantity, threshold),
+		ItemID:      &item.ID,
+	}
+	return tx.Create(&notification).Error
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"stroycity/pkg/model"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+func (r *NotificationRepository) CreateNotification(notification model.Notification) error {
+	return r.db.Create(&notification).Error
+}
+
+func (r *NotificationRepository) GetNotifications(recipientID string, unreadOnly bool) ([]model.Notification, error) {
+	query := r.db.Where("recipient_id = ?", recipientID)
+	if unreadOnly {
+		query = query.Where("read = ?", false)
+	}
+
+	var notifications []model.Notification
+	if err := query.Order("created_at DESC, id DESC").Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// MarkNotificationsRead отмечает уведомления прочитанными; без ids — все уведомления получателя
+func (r *NotificationRepository) MarkNotificationsRead(recipientID string, ids []int) error {
+	query := r.db.Model(&model.Notification{}).Where("recipient_id = ?", recipientID)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	return query.Update("read", true).Error
+}
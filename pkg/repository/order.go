@@ -1,10 +1,18 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sort"
 	"stroycity/pkg/model"
+	"time"
 )
 
+// ErrOrderNotCancellable возвращается при отмене уже отменённого заказа или оплаченного заказа без возврата
+var ErrOrderNotCancellable = errors.New("order cannot be cancelled")
+
 type OrderRepository struct {
 	db *gorm.DB
 }
@@ -13,16 +21,198 @@ func NewOrderRepository(db *gorm.DB) *OrderRepository {
 	return &OrderRepository{db: db}
 }
 
-func (r *OrderRepository) CreateOrder(order model.Order) error {
-	order.Status = "Processing"
-	return r.db.Create(&order).Error
+// CreateOrder сохраняет заказ в ожидании оплаты и резервирует его товары до order.ReservedUntil.
+// Если какой-то позиции уже не хватает, заказ не создаётся и возвращается ErrNotEnoughStock.
+func (r *OrderRepository) CreateOrder(order model.Order) (int, error) {
+	order.Status = model.OrderStatusAwaitingPayment
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&order).Error; err != nil {
+			return err
+		}
+		if order.ReservedUntil == nil {
+			return nil
+		}
+
+		for _, orderItem := range order.OrderItems {
+			reservation := model.Reservation{
+				OrderID:   order.ID,
+				ItemID:    orderItem.ItemID,
+				Quantity:  orderItem.Quantity,
+				Status:    model.ReservationStatusActive,
+				ExpiresAt: *order.ReservedUntil,
+			}
+			if len(orderItem.Allocations) == 0 {
+				if err := reserveStock(tx, reservation); err != nil {
+					return err
+				}
+				continue
+			}
+			for _, allocation := range orderItem.Allocations {
+				warehouseID := allocation.WarehouseID
+				reservation.WarehouseID = &warehouseID
+				reservation.Quantity = allocation.Quantity
+				if err := reserveStock(tx, reservation); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return order.ID, nil
 }
 
 func (r *OrderRepository) GetOrderById(orderID int) (model.Order, error) {
 	var order model.Order
-	err := r.db.Preload("OrderItems").Preload("OrderItems.Item").Where("id = ?", orderID).First(&order).Error
+	// Позиции заказа ссылаются и на удалённые с тех пор товары
+	err := r.db.Preload("OrderItems").
+		Preload("OrderItems.Item", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("id = ?", orderID).First(&order).Error
 	if err != nil {
 		return order, err
 	}
 	return order, nil
 }
+
+// PayOrder переводит резервы заказа в продажу: списывает остатки, записывает продажу в журнал движений
+// и переводит заказ в обработку.
+// Если резерв уже истёк, возвращается ErrReservationExpired.
+func (r *OrderRepository) PayOrder(orderID int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var order model.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("OrderItems").Preload("OrderItems.Allocations").
+			First(&order, orderID).Error; err != nil {
+			return err
+		}
+		switch order.Status {
+		case model.OrderStatusAwaitingPayment:
+		case model.OrderStatusCancelled:
+			return ErrReservationExpired
+		default:
+			return ErrOrderAlreadyPaid
+		}
+
+		if order.ReservedUntil != nil {
+			result := tx.Model(&model.Reservation{}).
+				Where("order_id = ? AND status = ? AND expires_at > ?", orderID, model.ReservationStatusActive, time.Now()).
+				Update("status", model.ReservationStatusConverted)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return ErrReservationExpired
+			}
+		}
+
+		change := model.StockChange{
+			Type:    model.MovementTypeSale,
+			ActorID: order.BuyerID,
+			Reason:  fmt.Sprintf("order #%d", order.ID),
+			OrderID: &order.ID,
+		}
+		for _, orderItem := range order.OrderItems {
+			if err := decreaseStock(tx, orderItem.ItemID, orderItem.Quantity, orderItem.Allocations); err != nil {
+				return err
+			}
+			if err := recordStockMovements(tx, orderItem.ItemID, orderItemMovements(orderItem, -1), change); err != nil {
+				return err
+			}
+		}
+
+		// Оплата зачисляется продавцам в той же транзакции, что и списание товара
+		if err := adjustSellerBalances(tx, order.OrderItems, 1); err != nil {
+			return err
+		}
+
+		return tx.Model(&model.Order{}).Where("id = ?", orderID).Update("status", model.OrderStatusProcessing).Error
+	})
+}
+
+// CancelOrder отменяет заказ. У неоплаченного заказа снимается резерв.
+// Оплаченный заказ отменяется только при refund: проданный товар возвращается на склады с записью отмены
+// в журнал движений, а оплата списывается с балансов продавцов в той же транзакции.
+func (r *OrderRepository) CancelOrder(orderID int, change model.StockChange, refund bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var order model.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("OrderItems").Preload("OrderItems.Allocations").
+			First(&order, orderID).Error; err != nil {
+			return err
+		}
+
+		switch order.Status {
+		case model.OrderStatusAwaitingPayment:
+			if err := tx.Model(&model.Reservation{}).
+				Where("order_id = ? AND status = ?", orderID, model.ReservationStatusActive).
+				Update("status", model.ReservationStatusReleased).Error; err != nil {
+				return err
+			}
+			itemIDs := make([]int, 0, len(order.OrderItems))
+			for _, orderItem := range order.OrderItems {
+				itemIDs = append(itemIDs, orderItem.ItemID)
+			}
+			if err := notifyReleasedStock(tx, itemIDs); err != nil {
+				return err
+			}
+		case model.OrderStatusCancelled:
+			return ErrOrderNotCancellable
+		default:
+			if !refund {
+				return ErrOrderNotCancellable
+			}
+			change.OrderID = &order.ID
+			for _, orderItem := range order.OrderItems {
+				if err := increaseStock(tx, orderItem.ItemID, orderItem.Quantity, orderItem.Allocations); err != nil {
+					return err
+				}
+				if err := recordStockMovements(tx, orderItem.ItemID, orderItemMovements(orderItem, 1), change); err != nil {
+					return err
+				}
+			}
+			if err := adjustSellerBalances(tx, order.OrderItems, -1); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&model.Order{}).Where("id = ?", orderID).Update("status", model.OrderStatusCancelled).Error
+	})
+}
+
+// adjustSellerBalances изменяет балансы продавцов на суммы их позиций заказа: sign 1 — зачисление, -1 — списание.
+// Баланс меняется атомарным UPDATE, а продавцы обходятся в одном порядке, чтобы параллельные заказы не блокировали друг друга.
+func adjustSellerBalances(tx *gorm.DB, orderItems []model.OrderItem, sign int) error {
+	amounts := map[string]model.Money{}
+	for _, orderItem := range orderItems {
+		amounts[orderItem.SellerId] += orderItem.Total
+	}
+	sellerIDs := make([]string, 0, len(amounts))
+	for sellerID := range amounts {
+		sellerIDs = append(sellerIDs, sellerID)
+	}
+	sort.Strings(sellerIDs)
+
+	for _, sellerID := range sellerIDs {
+		if err := tx.Model(&model.Seller{}).Where("id = ?", sellerID).
+			UpdateColumn("balance", gorm.Expr("balance + ?", model.Money(sign)*amounts[sellerID])).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// orderItemMovements возвращает движения позиции заказа по складам; sign задаёт направление: -1 списание, 1 возврат
+func orderItemMovements(orderItem model.OrderItem, sign int) []model.StockMovement {
+	if len(orderItem.Allocations) == 0 {
+		return []model.StockMovement{{Delta: sign * orderItem.Quantity}}
+	}
+
+	movements := make([]model.StockMovement, 0, len(orderItem.Allocations))
+	for _, allocation := range orderItem.Allocations {
+		warehouseID := allocation.WarehouseID
+		movements = append(movements, model.StockMovement{WarehouseID: &warehouseID, Delta: sign * allocation.Quantity})
+	}
+	return movements
+}
@@ -24,6 +24,13 @@ func NewPostgresDB(cfg Config) (*gorm.DB, error) {
 		return nil, err
 	}
 
+	if err = migrateMoneyToMinorUnits(db); err != nil {
+		return nil, err
+	}
+	if err = migrateImageURLsToStorageKeys(db); err != nil {
+		return nil, err
+	}
+
 	err = db.AutoMigrate(
 		&model.Item{},
 		&model.Brand{},
@@ -34,12 +41,50 @@ func NewPostgresDB(cfg Config) (*gorm.DB, error) {
 		&model.Buyer{},
 		&model.OrderItem{},
 		&model.Image{},
+		&model.ImageRendition{},
 		&model.Admin{},
 		&model.CartItem{},
 		&model.Review{},
+		&model.Attribute{},
+		&model.AttributeOption{},
+		&model.ItemAttribute{},
+		&model.Product{},
+		&model.VariantAxis{},
+		&model.VariantValue{},
+		&model.PriceTier{},
+		&model.ImportJob{},
+		&model.ImportRowError{},
+		&model.Warehouse{},
+		&model.WarehouseStock{},
+		&model.Reservation{},
+		&model.StockMovement{},
+		&model.Notification{},
+		&model.ModerationRequest{},
+		&model.OrderItemAllocation{},
+		&model.SlugRedirect{},
+		&model.PriceChange{},
+		&model.StockSubscription{},
+		&model.ComparisonItem{},
+		&model.ItemCoPurchase{},
+		&model.CategoryCoPurchase{},
+		&model.ItemView{},
+		&model.ItemViewCount{},
+		&model.Bundle{},
+		&model.BundleComponent{},
+		&model.CartBundle{},
 	)
 	if err != nil {
 		return nil, err
 	}
+
+	if err = allowBundleModerationRequests(db); err != nil {
+		return nil, err
+	}
+	if err = backfillSlugs(db); err != nil {
+		return nil, err
+	}
+	if err = backfillPriceHistory(db); err != nil {
+		return nil, err
+	}
 	return db, nil
 }
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"stroycity/pkg/model"
+	"time"
+)
+
+type PriceHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceHistoryRepository(db *gorm.DB) *PriceHistoryRepository {
+	return &PriceHistoryRepository{db: db}
+}
+
+// GetPriceHistory возвращает изменения цены товара за период по возрастанию времени; пустые границы не ограничивают период
+func (r *PriceHistoryRepository) GetPriceHistory(itemID int, from, to *time.Time) ([]model.PriceChange, error) {
+	query := r.db.Where("item_id = ?", itemID)
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+
+	var changes []model.PriceChange
+	if err := query.Order("created_at, id").Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// GetPriceAt возвращает цену, действовавшую на момент at; если её не было, ID записи нулевой
+func (r *PriceHistoryRepository) GetPriceAt(itemID int, at time.Time) (model.PriceChange, error) {
+	var change model.PriceChange
+	if err := r.db.Where("item_id = ? AND created_at < ?", itemID, at).Order("created_at DESC, id DESC").Limit(1).Find(&change).Error; err != nil {
+		return change, err
+	}
+	return change, nil
+}
+
+// recordPriceChange записывает в историю цены товара, если они отличаются от before.
+// При снижении цены опубликованного товара покупатели, добавившие его в избранное, получают уведомление.
+// Вызывается в транзакции изменения товара.
+func recordPriceChange(tx *gorm.DB, itemID int, before model.Item) error {
+	var item model.Item
+	if err := tx.Select("id", "name", "price", "price_with_discount", "currency", "status").Where("id = ?", itemID).Limit(1).Find(&item).Error; err != nil {
+		return err
+	}
+	if item.ID == 0 || item.Price == before.Price && item.PriceWithDiscount == before.PriceWithDiscount {
+		return nil
+	}
+
+	change := model.PriceChange{ItemID: item.ID, Price: item.Price, PriceWithDiscount: item.PriceWithDiscount}
+	if err := tx.Create(&change).Error; err != nil {
+		return err
+	}
+
+	if before.PriceWithDiscount <= 0 || item.PriceWithDiscount >= before.PriceWithDiscount || item.Status != model.ItemStatusPublished {
+		return nil
+	}
+	message := fmt.Sprintf("Цена на «%s» из избранного снизилась: %s → %s", item.Name,
+		before.PriceWithDiscount.Format(item.Currency), item.PriceWithDiscount.Format(item.Currency))
+	return notifyFavorites(tx, item.ID, model.NotificationTypePriceDrop, message)
+}
+
+// notifyFavorites создаёт уведомление каждому покупателю, добавившему товар в избранное
+func notifyFavorites(tx *gorm.DB, itemID int, notificationType, message string) error {
+	var buyerIDs []string
+	if err := tx.Table("buyer_favorites").Where("item_id = ?", itemID).Pluck("buyer_id", &buyerIDs).Error; err != nil {
+		return err
+	}
+	if len(buyerIDs) == 0 {
+		return nil
+	}
+
+	notifications := make([]model.Notification, 0, len(buyerIDs))
+	for _, buyerID := range buyerIDs {
+		notifications = append(notifications, model.Notification{
+			RecipientID: buyerID,
+			Type:        notificationType,
+			Message:     message,
+			ItemID:      &itemID,
+		})
+	}
+	return tx.Create(&notifications).Error
+}
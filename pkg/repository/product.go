@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"stroycity/pkg/model"
+)
+
+type ProductRepository struct {
+	db *gorm.DB
+}
+
+func NewProductRepository(db *gorm.DB) *ProductRepository {
+	return &ProductRepository{db: db}
+}
+
+func (r *ProductRepository) CreateProduct(product model.Product) (int, error) {
+	if err := r.db.Create(&product).Error; err != nil {
+		return 0, err
+	}
+	return product.ID, nil
+}
+
+func (r *ProductRepository) GetProductById(productID int) (model.Product, error) {
+	var product model.Product
+	err := r.db.
+		Preload("Axes", func(db *gorm.DB) *gorm.DB { return db.Order("position, id") }).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Items.VariantValues").
+		Preload("Items.Images").
+		First(&product, productID).Error
+	if err != nil {
+		return product, err
+	}
+	return product, nil
+}
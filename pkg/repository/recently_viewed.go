@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"stroycity/pkg/model"
+	"time"
+)
+
+type RecentlyViewedRepository struct {
+	db *gorm.DB
+}
+
+func NewRecentlyViewedRepository(db *gorm.DB) *RecentlyViewedRepository {
+	return &RecentlyViewedRepository{db: db}
+}
+
+// RecordView сохраняет просмотр товара в истории посетителя и, если countable, увеличивает счётчик просмотров.
+// Повторный просмотр тем же посетителем позже countAfter счётчик не увеличивает.
+// История ограничена MaxRecentlyViewed записями.
+func (r *RecentlyViewedRepository) RecordView(buyerID, deviceToken string, itemID int, countable bool, countAfter time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var previous model.ItemView
+		if err := tx.Where("buyer_id = ? AND device_token = ? AND item_id = ?", buyerID, deviceToken, itemID).
+			Limit(1).Find(&previous).Error; err != nil {
+			return err
+		}
+
+		view := model.ItemView{BuyerID: buyerID, DeviceToken: deviceToken, ItemID: itemID, ViewedAt: time.Now()}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "buyer_id"}, {Name: "device_token"}, {Name: "item_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"viewed_at"}),
+		}).Create(&view).Error; err != nil {
+			return err
+		}
+
+		recent := tx.Model(&model.ItemView{}).Select("id").
+			Where("buyer_id = ? AND device_token = ?", buyerID, deviceToken).
+			Order("viewed_at DESC, id DESC").Limit(model.MaxRecentlyViewed)
+		if err := tx.Where("buyer_id = ? AND device_token = ? AND id NOT IN (?)", buyerID, deviceToken, recent).
+			Delete(&model.ItemView{}).Error; err != nil {
+			return err
+		}
+		if !countable || previous.ID != 0 && !previous.ViewedAt.Before(countAfter) {
+			return nil
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "item_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"views":      gorm.Expr("item_view_counts.views + 1"),
+				"updated_at": time.Now(),
+			}),
+		}).Create(&model.ItemViewCount{ItemID: itemID, Views: 1}).Error
+	})
+}
+
+// GetRecentlyViewed возвращает опубликованные товары из истории посетителя, начиная с последнего просмотренного
+func (r *RecentlyViewedRepository) GetRecentlyViewed(buyerID, deviceToken string) ([]model.ItemView, error) {
+	var views []model.ItemView
+	if err := r.db.Preload("Item.Images").
+		Joins("JOIN items ON items.id = item_views.item_id AND items.deleted_at IS NULL AND items.status = ?", model.ItemStatusPublished).
+		Where("item_views.buyer_id = ? AND item_views.device_token = ?", buyerID, deviceToken).
+		Order("item_views.viewed_at DESC, item_views.id DESC").Find(&views).Error; err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+func (r *RecentlyViewedRepository) ClearRecentlyViewed(buyerID, deviceToken string) error {
+	return r.db.Where("buyer_id = ? AND device_token = ?", buyerID, deviceToken).Delete(&model.ItemView{}).Error
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"stroycity/pkg/model"
+)
+
+type RecommendationRepository struct {
+	db *gorm.DB
+}
+
+func NewRecommendationRepository(db *gorm.DB) *RecommendationRepository {
+	return &RecommendationRepository{db: db}
+}
+
+// RefreshCoPurchases пересчитывает совместные покупки товаров и категорий по неотменённым заказам.
+// Пары, встретившиеся реже minOrders раз, не сохраняются.
+func (r *RecommendationRepository) RefreshCoPurchases(minOrders int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&model.ItemCoPurchase{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`
+			INSERT INTO item_co_purchases (item_id, related_item_id, orders, updated_at)
+			SELECT a.item_id, b.item_id, COUNT(DISTINCT a.order_id), NOW()
+			FROM order_items a
+			JOIN order_items b ON b.order_id = a.order_id AND b.item_id <> a.item_id
+			JOIN orders o ON o.id = a.order_id AND o.status <> ?
+			GROUP BY a.item_id, b.item_id
+			HAVING COUNT(DISTINCT a.order_id) >= ?`,
+			model.OrderStatusCancelled, minOrders).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("1 = 1").Delete(&model.CategoryCoPurchase{}).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`
+			INSERT INTO category_co_purchases (category_id, related_category_id, orders, updated_at)
+			SELECT ia.category_id, ib.category_id, COUNT(DISTINCT a.order_id), NOW()
+			FROM order_items a
+			JOIN order_items b ON b.order_id = a.order_id AND b.item_id <> a.item_id
+			JOIN orders o ON o.id = a.order_id AND o.status <> ?
+			JOIN items ia ON ia.id = a.item_id
+			JOIN items ib ON ib.id = b.item_id
+			WHERE ia.category_id <> ib.category_id
+			GROUP BY ia.category_id, ib.category_id
+			HAVING COUNT(DISTINCT a.order_id) >= ?`,
+			model.OrderStatusCancelled, minOrders).Error
+	})
+}
+
+// recommendable ограничивает выборку товарами, которые можно купить: опубликованными и в наличии
+func (r *RecommendationRepository) recommendable() *gorm.DB {
+	return r.db.Model(&model.Item{}).Preload("Images").
+		Where("items.status = ? AND items.quantity > 0", model.ItemStatusPublished)
+}
+
+// GetBoughtTogether возвращает товары, чаще всего покупаемые вместе с itemIDs, кроме них самих
+func (r *RecommendationRepository) GetBoughtTogether(itemIDs []int, limit int) ([]model.Item, error) {
+	var items []model.Item
+	err := r.recommendable().Select("items.*").
+		Joins("JOIN item_co_purchases cp ON cp.related_item_id = items.id").
+		Where("cp.item_id IN ? AND items.id NOT IN ?", itemIDs, itemIDs).
+		Group("items.id").Order("SUM(cp.orders) DESC, items.id").Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetSimilarItems возвращает товары той же категории с ценой в пределах priceBand от цены товара:
+// сначала из того же материала, затем ближайшие по цене. Другие варианты той же карточки не включаются.
+func (r *RecommendationRepository) GetSimilarItems(item model.Item, priceBand float64, limit int) ([]model.Item, error) {
+	price := int64(item.PriceWithDiscount)
+	delta := int64(float64(price) * priceBand)
+
+	query := r.recommendable().
+		Where("items.category_id = ? AND items.id <> ?", item.CategoryID, item.ID).
+		Where("items.price_with_discount BETWEEN ? AND ?", price-delta, price+delta)
+	if item.ProductID != nil {
+		query = query.Where("items.product_id IS NULL OR items.product_id <> ?", *item.ProductID)
+	}
+
+	var items []model.Item
+	err := query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:                "items.material_id = ? DESC, ABS(items.price_with_discount - ?), items.id",
+		Vars:               []interface{}{item.MaterialID, price},
+		WithoutParentheses: true,
+	}}).
+		Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetComplementaryItems подбирает к товарам itemIDs сопутствующие товары: по одному самому
+// покупаемому товару из каждой категории, которую чаще всего берут вместе с их категориями
+func (r *RecommendationRepository) GetComplementaryItems(itemIDs []int, limit int) ([]model.Item, error) {
+	sourceCategories := r.db.Model(&model.Item{}).Unscoped().Select("category_id").Where("id IN ?", itemIDs)
+
+	var categoryIDs []int
+	err := r.db.Model(&model.CategoryCoPurchase{}).
+		Where("category_id IN (?) AND related_category_id NOT IN (?)", sourceCategories, sourceCategories).
+		Group("related_category_id").Order("SUM(orders) DESC, related_category_id").Limit(limit).
+		Pluck("related_category_id", &categoryIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	items := []model.Item{}
+	for _, categoryID := range categoryIDs {
+		var item model.Item
+		err := r.recommendable().
+			Where("items.category_id = ? AND items.id NOT IN ?", categoryID, itemIDs).
+			Order("(SELECT COUNT(*) FROM order_items oi WHERE oi.item_id = items.id) DESC, items.id").
+			Limit(1).Find(&item).Error
+		if err != nil {
+			return nil, err
+		}
+		if item.ID != 0 {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
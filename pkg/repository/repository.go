@@ -3,6 +3,7 @@ package repository
 import (
 	"gorm.io/gorm"
 	"stroycity/pkg/model"
+	"time"
 )
 
 type Repository struct {
@@ -16,56 +17,113 @@ type Repository struct {
 	Admin
 	Cart
 	Review
+	Attribute
+	Product
+	Import
+	Warehouse
+	Reservation
+	Movement
+	Notification
+	Moderation
+	Slug
+	PriceHistory
+	StockSubscription
+	Comparison
+	Recommendation
+	RecentlyViewed
+	Bundle
 }
 
 func NewRepository(db *gorm.DB) *Repository {
 	return &Repository{
-		Category: NewCategoryRepository(db),
-		Brand:    NewBrandRepository(db),
-		Material: NewMaterialRepository(db),
-		Seller:   NewSellerRepository(db),
-		Item:     NewItemRepository(db),
-		Buyer:    NewBuyerRepository(db),
-		Order:    NewOrderRepository(db),
-		Admin:    NewAdminRepository(db),
-		Cart:     NewCartRepository(db),
-		Review:   NewReviewRepository(db),
+		Category:          NewCategoryRepository(db),
+		Brand:             NewBrandRepository(db),
+		Material:          NewMaterialRepository(db),
+		Seller:            NewSellerRepository(db),
+		Item:              NewItemRepository(db),
+		Buyer:             NewBuyerRepository(db),
+		Order:             NewOrderRepository(db),
+		Admin:             NewAdminRepository(db),
+		Cart:              NewCartRepository(db),
+		Review:            NewReviewRepository(db),
+		Attribute:         NewAttributeRepository(db),
+		Product:           NewProductRepository(db),
+		Import:            NewImportRepository(db),
+		Warehouse:         NewWarehouseRepository(db),
+		Reservation:       NewReservationRepository(db),
+		Movement:          NewMovementRepository(db),
+		Notification:      NewNotificationRepository(db),
+		Moderation:        NewModerationRepository(db),
+		Slug:              NewSlugRepository(db),
+		PriceHistory:      NewPriceHistoryRepository(db),
+		StockSubscription: NewStockSubscriptionRepository(db),
+		Comparison:        NewComparisonRepository(db),
+		Recommendation:    NewRecommendationRepository(db),
+		RecentlyViewed:    NewRecentlyViewedRepository(db),
+		Bundle:            NewBundleRepository(db),
 	}
 }
 
 type Category interface {
-	CreateCategory(category model.Category) error
+	CreateCategory(category model.Category) (int, error)
+	GetCategoryById(id int) (model.Category, error)
+	UpdateCategory(category model.Category) error
+	CountCategoryItems(id int) (int64, error)
+	CountCategoryBundles(id int) (int64, error)
 	DeleteCategory(id int) error
+	MergeCategories(targetID int, sourceIDs []int) error
 	GetCategoryList() ([]model.Category, error)
 }
 
 type Brand interface {
-	CreateBrand(brand model.Brand) error
+	CreateBrand(brand model.Brand) (int, error)
+	GetBrandById(id int) (model.Brand, error)
+	UpdateBrand(brand model.Brand) error
+	CountBrandItems(id int) (int64, error)
 	DeleteBrand(id int) error
+	MergeBrands(target model.Brand, sourceIDs []int) error
 	GetBrandList() ([]model.Brand, error)
 }
 
 type Material interface {
 	CreateMaterial(material model.Material) error
+	GetMaterialById(id int) (model.Material, error)
+	UpdateMaterial(material model.Material) error
+	CountMaterialItems(id int) (int64, error)
 	DeleteMaterial(id int) error
+	MergeMaterials(targetID int, sourceIDs []int) error
 	GetMaterialList() ([]model.Material, error)
 }
 
 type Seller interface {
 	SellerSignUp(seller model.Seller) error
 	GetSeller(id string) (model.Seller, error)
+	FindSeller(id string) (model.Seller, error)
 	UpdateSeller(seller model.Seller) error
 	SellerSignIn(mail, password string) (model.Seller, error)
-	GetSellerEarnings(sellerID string) (float64, float64, error)
+	GetSellerEarnings(sellerID string) (model.Money, model.Money, error)
 }
 
 type Item interface {
-	CreateItem(item model.Item) (int, error)
+	CreateItem(item model.Item, change model.StockChange) (int, error)
 	GetItemById(itemID int) (model.Item, error)
-	UpdateItem(item model.Item) error
-	GetItems(brandIDs, sellerIDs, categoryIDs, materialIDs []uint, minPrice, maxPrice float64, query string) ([]model.Item, error)
+	FindItemById(itemID int) (model.Item, error)
+	UpdateItem(item model.Item, change model.StockChange, request *model.ModerationRequest) error
+	GetItemByIdUnscoped(itemID int) (model.Item, error)
+	SetItemStatus(itemID int, status string) error
+	DeleteItem(itemID int) error
+	GetItems(brandIDs, sellerIDs, categoryIDs, materialIDs []uint, minPrice, maxPrice model.Money, query string, attributes []model.AttributeFilter, sort string) ([]model.Item, error)
 	GetAllItems() ([]model.Item, error)
-	SaveImage(image model.Image) error
+	SaveImage(image model.Image) (model.Image, error)
+	GetImagesByItemID(itemID int) ([]model.Image, error)
+	UpdateImagePositions(itemID int, imageIDs []int) error
+	SetPrimaryImage(itemID, imageID int) error
+	DeleteImage(itemID, imageID int) (model.Image, error)
+	GetItemByArticle(sellerID, article string) (model.Item, error)
+	GetItemByExternalID(sellerID, externalID string) (model.Item, error)
+	GetItemsForFeed(sellerID string) ([]model.Item, error)
+	GetItemsForSitemap() ([]model.Item, error)
+	UpdateItemInventory(itemID, version int, fields map[string]interface{}, change model.StockChange) (bool, error)
 }
 
 type Buyer interface {
@@ -78,8 +136,10 @@ type Buyer interface {
 }
 
 type Order interface {
-	CreateOrder(order model.Order) error
+	CreateOrder(order model.Order) (int, error)
 	GetOrderById(orderID int) (model.Order, error)
+	PayOrder(orderID int) error
+	CancelOrder(orderID int, change model.StockChange, refund bool) error
 }
 
 type Admin interface {
@@ -95,9 +155,118 @@ type Cart interface {
 	RemoveFromCart(cartItemID int) error
 	ClearCart(buyerID string) error
 	GetCartItemsByBuyerID(buyerID string) ([]model.CartItem, error)
+	SaveCartBundle(cartBundle model.CartBundle) error
+	GetCartBundles(buyerID string) ([]model.CartBundle, error)
+	RemoveCartBundle(buyerID string, bundleID int) (bool, error)
 }
 
 type Review interface {
 	CreateReview(review model.Review) error
 	GetReviewsByItemID(itemID int) ([]model.Review, error)
 }
+
+type Attribute interface {
+	CreateAttribute(attribute model.Attribute) (int, error)
+	DeleteAttribute(id int) error
+	GetAttributesByCategoryID(categoryID int) ([]model.Attribute, error)
+}
+
+type Product interface {
+	CreateProduct(product model.Product) (int, error)
+	GetProductById(productID int) (model.Product, error)
+}
+
+type Import interface {
+	CreateImportJob(job model.ImportJob) (int, error)
+	GetImportJob(id int) (model.ImportJob, error)
+	UpdateImportJobProgress(job model.ImportJob, rowErrors []model.ImportRowError) error
+	GetImportErrors(jobID int) ([]model.ImportRowError, error)
+}
+
+type Warehouse interface {
+	CreateWarehouse(warehouse model.Warehouse) (int, error)
+	UpdateWarehouse(warehouse model.Warehouse) error
+	DeleteWarehouse(id int, change model.StockChange) error
+	GetWarehouseById(id int) (model.Warehouse, error)
+	GetWarehouses(sellerID string) ([]model.Warehouse, error)
+	GetItemStocks(itemID int) ([]model.WarehouseStock, error)
+	SetStock(itemID, warehouseID, quantity int, change model.StockChange) error
+}
+
+type Reservation interface {
+	GetReservedStock(itemIDs []int) (map[int]model.ReservedStock, error)
+	ExpireReservations(now time.Time) (int, error)
+}
+
+type Movement interface {
+	GetMovements(sellerID string, filter model.StockMovementFilter) ([]model.StockMovement, error)
+	SetLowStockThreshold(sellerID string, itemID *int, threshold int) error
+}
+
+type Notification interface {
+	CreateNotification(notification model.Notification) error
+	GetNotifications(recipientID string, unreadOnly bool) ([]model.Notification, error)
+	MarkNotificationsRead(recipientID string, ids []int) error
+}
+
+type Moderation interface {
+	SaveModerationRequest(request model.ModerationRequest) (int, error)
+	GetPendingModerationRequest(itemID int) (model.ModerationRequest, error)
+	GetPendingBundleModerationRequest(bundleID int) (model.ModerationRequest, error)
+	GetModerationRequest(id int) (model.ModerationRequest, error)
+	GetModerationRequests(status string) ([]model.ModerationRequest, error)
+	GetSellerModerationRequests(sellerID string, itemID int) ([]model.ModerationRequest, error)
+	ReviewModerationRequest(request model.ModerationRequest, status string, notification model.Notification) error
+	GetCategoryMedianPrice(categoryID int) (model.Money, error)
+}
+
+type Slug interface {
+	IsSlugTaken(entityType, slug string, entityID int) (bool, error)
+	SetSlug(entityType string, entityID int, slug string) error
+	GetEntityIDBySlug(entityType, slug string) (int, error)
+	GetSlugRedirect(entityType, slug string) (model.SlugRedirect, error)
+}
+
+type PriceHistory interface {
+	GetPriceHistory(itemID int, from, to *time.Time) ([]model.PriceChange, error)
+	GetPriceAt(itemID int, at time.Time) (model.PriceChange, error)
+}
+
+type StockSubscription interface {
+	Subscribe(subscription model.StockSubscription) (model.StockSubscription, error)
+	GetGuestSubscription(itemID int, email string) (model.StockSubscription, error)
+	GetSubscriptionByToken(token string) (model.StockSubscription, error)
+	GetBuyerSubscriptions(buyerID string) ([]model.StockSubscription, error)
+	DeleteBuyerSubscription(buyerID string, itemID int) (bool, error)
+	DeleteSubscriptionByToken(token string) (bool, error)
+	GetUnsentEmails(limit int) ([]model.StockSubscription, error)
+	MarkEmailed(id int) error
+}
+
+type Comparison interface {
+	AddComparisonItem(item model.ComparisonItem) error
+	GetComparisonItems(buyerID, deviceToken string, categoryID int) ([]model.ComparisonItem, error)
+	RemoveComparisonItem(buyerID, deviceToken string, itemID int) (bool, error)
+	ClearComparison(buyerID, deviceToken string, categoryID int) error
+}
+
+type Recommendation interface {
+	RefreshCoPurchases(minOrders int) error
+	GetBoughtTogether(itemIDs []int, limit int) ([]model.Item, error)
+	GetSimilarItems(item model.Item, priceBand float64, limit int) ([]model.Item, error)
+	GetComplementaryItems(itemIDs []int, limit int) ([]model.Item, error)
+}
+
+type RecentlyViewed interface {
+	RecordView(buyerID, deviceToken string, itemID int, countable bool, countAfter time.Time) error
+	GetRecentlyViewed(buyerID, deviceToken string) ([]model.ItemView, error)
+	ClearRecentlyViewed(buyerID, deviceToken string) error
+}
+
+type Bundle interface {
+	CreateBundle(bundle model.Bundle, request *model.ModerationRequest) (int, error)
+	GetBundleById(id int) (model.Bundle, error)
+	GetBundles(categoryID int, sellerID, status string) ([]model.Bundle, error)
+	UpdateBundle(bundle model.Bundle, request *model.ModerationRequest) error
+	DeleteBundle(id int) error
+}
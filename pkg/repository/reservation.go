@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"stroycity/pkg/model"
+	"time"
+)
+
+var (
+	// ErrReservationExpired возвращается при оплате заказа, резерв которого уже истёк
+	ErrReservationExpired = errors.New("reservation expired")
+	// ErrOrderAlreadyPaid возвращается при повторной оплате заказа
+	ErrOrderAlreadyPaid = errors.New("order already paid")
+)
+
+type ReservationRepository struct {
+	db *gorm.DB
+}
+
+func NewReservationRepository(db *gorm.DB) *ReservationRepository {
+	return &ReservationRepository{db: db}
+}
+
+// GetReservedStock возвращает действующие резервы по товарам
+func (r *ReservationRepository) GetReservedStock(itemIDs []int) (map[int]model.ReservedStock, error) {
+	reserved := map[int]model.ReservedStock{}
+	if len(itemIDs) == 0 {
+		return reserved, nil
+	}
+
+	var rows []struct {
+		ItemID      int
+		WarehouseID *int
+		Quantity    int
+	}
+	err := r.db.Model(&model.Reservation{}).
+		Select("item_id, warehouse_id, SUM(quantity) AS quantity").
+		Where("item_id IN ? AND status = ? AND expires_at > ?", itemIDs, model.ReservationStatusActive, time.Now()).
+		Group("item_id, warehouse_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		stock := reserved[row.ItemID]
+		stock.Total += row.Quantity
+		if row.WarehouseID != nil {
+			if stock.ByWarehouse == nil {
+				stock.ByWarehouse = map[int]int{}
+			}
+			stock.ByWarehouse[*row.WarehouseID] += row.Quantity
+		}
+		reserved[row.ItemID] = stock
+	}
+	return reserved, nil
+}
+
+// ExpireReservations снимает просроченные резервы и отменяет неоплаченные заказы, к которым они относились
+func (r *ReservationRepository) ExpireReservations(now time.Time) (int, error) {
+	var orderIDs []int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Reservation{}).
+			Where("status = ? AND expires_at <= ?", model.ReservationStatusActive, now).
+			Distinct().Pluck("order_id", &orderIDs).Error; err != nil {
+			return err
+		}
+		if len(orderIDs) == 0 {
+			return nil
+		}
+
+		var itemIDs []int
+		if err := tx.Model(&model.Reservation{}).
+			Where("order_id IN ? AND status = ?", orderIDs, model.ReservationStatusActive).
+			Distinct().Pluck("item_id", &itemIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Reservation{}).
+			Where("order_id IN ? AND status = ?", orderIDs, model.ReservationStatusActive).
+			Update("status", model.ReservationStatusExpired).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Order{}).
+			Where("id IN ? AND status = ?", orderIDs, model.OrderStatusAwaitingPayment).
+			Update("status", model.OrderStatusCancelled).Error; err != nil {
+			return err
+		}
+		return notifyReleasedStock(tx, itemIDs)
+	})
+	return len(orderIDs), err
+}
+
+// reservedQuantity возвращает количество товара под действующими резервами по всем складам
+func reservedQuantity(tx *gorm.DB, itemID int) (int, error) {
+	var reserved int
+	err := tx.Model(&model.Reservation{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("item_id = ? AND status = ? AND expires_at > ?", itemID, model.ReservationStatusActive, time.Now()).
+		Scan(&reserved).Error
+	return reserved, err
+}
+
+// reserveStock резервирует товар под заказ. Строки товара и остатка блокируются до конца транзакции,
+// чтобы параллельные оформления не зарезервировали одно и то же количество, а удаление товара
+// (DeleteItem) дождалось оформления и увидело новый резерв. Удалённый товар не резервируется.
+func reserveStock(tx *gorm.DB, reservation model.Reservation) error {
+	var item model.Item
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "quantity").
+		Where("id = ?", reservation.ItemID).Limit(1).Find(&item).Error; err != nil {
+		return err
+	}
+	if item.ID == 0 {
+		return ErrNotEnoughStock
+	}
+
+	onHand := item.Quantity
+	if reservation.WarehouseID != nil {
+		var stock model.WarehouseStock
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("item_id = ? AND warehouse_id = ?", reservation.ItemID, *reservation.WarehouseID).
+			Limit(1).Find(&stock).Error; err != nil {
+			return err
+		}
+		onHand = stock.Quantity
+	}
+
+	reservedQuery := tx.Model(&model.Reservation{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("item_id = ? AND status = ? AND expires_at > ?", reservation.ItemID, model.ReservationStatusActive, time.Now())
+	if reservation.WarehouseID != nil {
+		reservedQuery = reservedQuery.Where("warehouse_id = ?", *reservation.WarehouseID)
+	} else {
+		reservedQuery = reservedQuery.Where("warehouse_id IS NULL")
+	}
+	var reserved int
+	if err := reservedQuery.Scan(&reserved).Error; err != nil {
+		return err
+	}
+
+	if model.Available(onHand, reserved) < reservation.Quantity {
+		return ErrNotEnoughStock
+	}
+	return tx.Create(&reservation).Error
+}
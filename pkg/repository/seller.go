@@ -27,6 +27,15 @@ func (r *SellerRepository) GetSeller(id string) (model.Seller, error) {
 	return seller, nil
 }
 
+// FindSeller возвращает продавца без связанных товаров; если продавца нет, ID пустой
+func (r *SellerRepository) FindSeller(id string) (model.Seller, error) {
+	var seller model.Seller
+	if err := r.db.Where("id = ?", id).Limit(1).Find(&seller).Error; err != nil {
+		return seller, err
+	}
+	return seller, nil
+}
+
 func (r *SellerRepository) UpdateSeller(seller model.Seller) error {
 	return r.db.Save(&seller).Error
 }
@@ -44,19 +53,26 @@ func (r *SellerRepository) SellerSignIn(mail, password string) (model.Seller, er
 	return seller, nil
 }
 
-func (r *SellerRepository) GetSellerEarnings(sellerID string) (float64, float64, error) {
+// GetSellerEarnings возвращает выручку продавца за текущую и прошлую неделю.
+// Учитываются только оплаченные заказы: неоплаченные и отменённые выручкой не считаются.
+func (r *SellerRepository) GetSellerEarnings(sellerID string) (model.Money, model.Money, error) {
 	orderItems := []model.OrderItem{}
-	if err := r.db.Model(&model.OrderItem{}).Where("seller_id = ?", sellerID).Find(&orderItems).Error; err != nil {
+	currentTime := time.Now()
+	weekAgo := currentTime.Add(-7 * 24 * time.Hour)
+	twoWeeksAgo := currentTime.Add(-14 * 24 * time.Hour)
+	if err := r.db.Model(&model.OrderItem{}).Select("order_items.*").
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where("order_items.seller_id = ? AND order_items.created_at >= ? AND orders.status IN ?", sellerID, twoWeeksAgo, model.PaidOrderStatuses).
+		Find(&orderItems).Error; err != nil {
 		return 0, 0, err
 	}
-	lastWeek, currentWeek := 0.0, 0.0
-	currentTime := time.Now()
+	var lastWeek, currentWeek model.Money
 	for _, orderItem := range orderItems {
-		if currentTime.Before(orderItem.CreatedAt) && currentTime.Add(-7*24*time.Hour).After(orderItem.CreatedAt) {
+		if !orderItem.CreatedAt.Before(weekAgo) {
 			currentWeek += orderItem.Total
-		} else if currentTime.Add(-7*24*time.Hour).Before(orderItem.CreatedAt) && currentTime.Add(-14*24*time.Hour).After(orderItem.CreatedAt) {
+		} else {
 			lastWeek += orderItem.Total
 		}
 	}
-	return currentWeek, lastWeek, errors.New("")
+	return currentWeek, lastWeek, nil
 }
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"stroycity/pkg/model"
+)
+
+// slugTables — таблицы сущностей с адресами
+var slugTables = map[string]interface{}{
+	model.SlugEntityItem:     &model.Item{},
+	model.SlugEntityCategory: &model.Category{},
+	model.SlugEntityBrand:    &model.Brand{},
+}
+
+// ErrSlugTaken возвращается, если адрес одновременно занят другой сущностью того же типа
+var ErrSlugTaken = errors.New("slug is already taken")
+
+type SlugRepository struct {
+	db *gorm.DB
+}
+
+func NewSlugRepository(db *gorm.DB) *SlugRepository {
+	return &SlugRepository{db: db}
+}
+
+// IsSlugTaken проверяет, занят ли адрес другой сущностью того же типа — текущим адресом или прежним,
+// с которого настроено перенаправление. Адреса удалённых товаров тоже считаются занятыми.
+func (r *SlugRepository) IsSlugTaken(entityType, slug string, entityID int) (bool, error) {
+	table, err := slugTable(entityType)
+	if err != nil {
+		return false, err
+	}
+
+	var count int64
+	if err := r.db.Unscoped().Model(table).Where("slug = ? AND id <> ?", slug, entityID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+	if err := r.db.Model(&model.SlugRedirect{}).Where("entity_type = ? AND slug = ? AND entity_id <> ?", entityType, slug, entityID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SetSlug меняет адрес сущности; прежний адрес сохраняется для перенаправления.
+// Уникальность текущих адресов обеспечивает индекс: при гонке возвращается ErrSlugTaken.
+func (r *SlugRepository) SetSlug(entityType string, entityID int, slug string) error {
+	table, err := slugTable(entityType)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var current string
+		if err := tx.Unscoped().Model(table).Select("slug").Where("id = ?", entityID).Scan(&current).Error; err != nil {
+			return err
+		}
+		if current == slug {
+			return nil
+		}
+
+		if err := tx.Unscoped().Model(table).Where("id = ?", entityID).UpdateColumn("slug", slug).Error; err != nil {
+			return err
+		}
+		if current != "" {
+			redirect := model.SlugRedirect{EntityType: entityType, Slug: current, EntityID: entityID}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "entity_type"}, {Name: "slug"}},
+				DoUpdates: clause.AssignmentColumns([]string{"entity_id"}),
+			}).Create(&redirect).Error; err != nil {
+				return err
+			}
+		}
+		// Возврат к прежнему адресу отменяет перенаправление с него
+		return tx.Where("entity_type = ? AND slug = ?", entityType, slug).Delete(&model.SlugRedirect{}).Error
+	})
+	if translator, ok := r.db.Dialector.(gorm.ErrorTranslator); ok && errors.Is(translator.Translate(err), gorm.ErrDuplicatedKey) {
+		return ErrSlugTaken
+	}
+	return err
+}
+
+// GetEntityIDBySlug возвращает ID сущности с текущим адресом slug; 0, если её нет
+func (r *SlugRepository) GetEntityIDBySlug(entityType, slug string) (int, error) {
+	table, err := slugTable(entityType)
+	if err != nil {
+		return 0, err
+	}
+
+	var ids []int
+	if err := r.db.Model(table).Where("slug = ?", slug).Limit(1).Pluck("id", &ids).Error; err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+// GetSlugRedirect возвращает перенаправление с прежнего адреса; если его нет, ID перенаправления нулевой
+func (r *SlugRepository) GetSlugRedirect(entityType, slug string) (model.SlugRedirect, error) {
+	var redirect model.SlugRedirect
+	if err := r.db.Where("entity_type = ? AND slug = ?", entityType, slug).Limit(1).Find(&redirect).Error; err != nil {
+		return redirect, err
+	}
+	return redirect, nil
+}
+
+func slugTable(entityType string) (interface{}, error) {
+	table, ok := slugTables[entityType]
+	if !ok {
+		return nil, fmt.Errorf("unknown slug entity %q", entityType)
+	}
+	return table, nil
+}
+
+// redirectMergedSlugs перенаправляет текущие и прежние адреса объединяемых записей sourceIDs на запись targetID
+func redirectMergedSlugs(tx *gorm.DB, entityType string, targetID int, sourceIDs []int) error {
+	table, err := slugTable(entityType)
+	if err != nil {
+		return err
+	}
+
+	var slugs []string
+	if err := tx.Model(table).Where("id IN ? AND slug <> ''", sourceIDs).Pluck("slug", &slugs).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&model.SlugRedirect{}).Where("entity_type = ? AND entity_id IN ?", entityType, sourceIDs).
+		Update("entity_id", targetID).Error; err != nil {
+		return err
+	}
+	for _, slug := range slugs {
+		redirect := model.SlugRedirect{EntityType: entityType, Slug: slug, EntityID: targetID}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&redirect).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteSlugRedirects удаляет перенаправления на удаляемую запись
+func deleteSlugRedirects(tx *gorm.DB, entityType string, entityID int) error {
+	return tx.Where("entity_type = ? AND entity_id = ?", entityType, entityID).Delete(&model.SlugRedirect{}).Error
+}
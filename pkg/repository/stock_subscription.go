@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"stroycity/pkg/model"
+	"time"
+)
+
+type StockSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewStockSubscriptionRepository(db *gorm.DB) *StockSubscriptionRepository {
+	return &StockSubscriptionRepository{db: db}
+}
+
+// Subscribe сохраняет подписку на поступление товара. Повторная подписка того же покупателя
+// или адреса не создаёт дубликат, а снова ждёт поступления; возвращается сохранённая подписка с её токеном.
+func (r *StockSubscriptionRepository) Subscribe(subscription model.StockSubscription) (model.StockSubscription, error) {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "item_id"}, {Name: "buyer_id"}, {Name: "email"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"notified_at": nil,
+			"emailed_at":  nil,
+		}),
+	}).Create(&subscription).Error
+	if err != nil {
+		return subscription, err
+	}
+
+	var saved model.StockSubscription
+	err = r.db.Where("item_id = ? AND buyer_id = ? AND email = ?", subscription.ItemID, subscription.BuyerID, subscription.Email).
+		Limit(1).Find(&saved).Error
+	return saved, err
+}
+
+// GetGuestSubscription возвращает подписку гостя на товар; если её нет, ID подписки нулевой
+func (r *StockSubscriptionRepository) GetGuestSubscription(itemID int, email string) (model.StockSubscription, error) {
+	var subscription model.StockSubscription
+	err := r.db.Where("item_id = ? AND buyer_id = '' AND email = ?", itemID, email).Limit(1).Find(&subscription).Error
+	return subscription, err
+}
+
+// GetSubscriptionByToken возвращает подписку с товаром по токену из ссылки отписки; если её нет, ID подписки нулевой
+func (r *StockSubscriptionRepository) GetSubscriptionByToken(token string) (model.StockSubscription, error) {
+	var subscription model.StockSubscription
+	err := r.db.Preload("Item.Images").
+		Where("token = ?", token).Limit(1).Find(&subscription).Error
+	return subscription, err
+}
+
+func (r *StockSubscriptionRepository) GetBuyerSubscriptions(buyerID string) ([]model.StockSubscription, error) {
+	var subscriptions []model.StockSubscription
+	if err := r.db.Preload("Item.Images").Joins("JOIN items ON items.id = stock_subscriptions.item_id AND items.deleted_at IS NULL").
+		Where("stock_subscriptions.buyer_id = ?", buyerID).
+		Order("stock_subscriptions.created_at DESC").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// DeleteBuyerSubscription удаляет подписку покупателя; false, если её не было
+func (r *StockSubscriptionRepository) DeleteBuyerSubscription(buyerID string, itemID int) (bool, error) {
+	result := r.db.Where("buyer_id = ? AND item_id = ?", buyerID, itemID).Delete(&model.StockSubscription{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// DeleteSubscriptionByToken удаляет подписку по токену из ссылки отписки; false, если токен неизвестен
+func (r *StockSubscriptionRepository) DeleteSubscriptionByToken(token string) (bool, error) {
+	result := r.db.Where("token = ?", token).Delete(&model.StockSubscription{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetUnsentEmails возвращает гостевые подписки, по которым товар поступил, а письмо ещё не отправлено
+func (r *StockSubscriptionRepository) GetUnsentEmails(limit int) ([]model.StockSubscription, error) {
+	var subscriptions []model.StockSubscription
+	if err := r.db.Preload("Item").Where("email <> '' AND notified_at IS NOT NULL AND emailed_at IS NULL").
+		Order("notified_at, id").Limit(limit).Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *StockSubscriptionRepository) MarkEmailed(id int) error {
+	return r.db.Model(&model.StockSubscription{}).Where("id = ?", id).Update("emailed_at", time.Now()).Error
+}
+
+// notifyBackInStock сообщает о поступлении товара подписчикам, ещё не получившим уведомление,
+// а при favorites — и покупателям, добавившим его в избранное. Каждый покупатель получает одно уведомление,
+// даже если товар и в избранном, и в подписках; гостям письмо отправляет фоновая рассылка.
+// Вызывается в транзакции изменения остатков или резервов.
+func notifyBackInStock(tx *gorm.DB, item model.Item, favorites bool) error {
+	var favoriteIDs []string
+	if favorites {
+		if err := tx.Table("buyer_favorites").Where("item_id = ?", item.ID).Pluck("buyer_id", &favoriteIDs).Error; err != nil {
+			return err
+		}
+	}
+	var subscriberIDs []string
+	if err := tx.Model(&model.StockSubscription{}).Where("item_id = ? AND buyer_id <> '' AND notified_at IS NULL", item.ID).
+		Pluck("buyer_id", &subscriberIDs).Error; err != nil {
+		return err
+	}
+
+	notified := make(map[string]bool, len(favoriteIDs)+len(subscriberIDs))
+	var notifications []model.Notification
+	for _, buyerID := range favoriteIDs {
+		if notified[buyerID] {
+			continue
+		}
+		notified[buyerID] = true
+		notifications = append(notifications, model.Notification{
+			RecipientID: buyerID,
+			Type:        model.NotificationTypeBackInStock,
+			Message:     fmt.Sprintf("Товар «%s» из избранного снова в наличии", item.Name),
+			ItemID:      &item.ID,
+		})
+	}
+	for _, buyerID := range subscriberIDs {
+		if notified[buyerID] {
+			continue
+		}
+		notified[buyerID] = true
+		notifications = append(notifications, model.Notification{
+			RecipientID: buyerID,
+			Type:        model.NotificationTypeBackInStock,
+			Message:     fmt.Sprintf("Товар «%s», на поступление которого вы подписались, снова в наличии", item.Name),
+			ItemID:      &item.ID,
+		})
+	}
+	if len(notifications) > 0 {
+		if err := tx.Create(&notifications).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Model(&model.StockSubscription{}).Where("item_id = ? AND notified_at IS NULL", item.ID).
+		Update("notified_at", time.Now()).Error
+}
+
+// notifyReleasedStock сообщает подписчикам о товарах, у которых после снятия резервов появился свободный остаток.
+// Остаток на складе при этом не меняется, поэтому покупатели из избранного, видевшие товар в наличии, не уведомляются.
+func notifyReleasedStock(tx *gorm.DB, itemIDs []int) error {
+	var pendingIDs []int
+	if err := tx.Model(&model.StockSubscription{}).Where("item_id IN ? AND notified_at IS NULL", itemIDs).
+		Distinct().Pluck("item_id", &pendingIDs).Error; err != nil {
+		return err
+	}
+
+	for _, itemID := range pendingIDs {
+		var item model.Item
+		if err := tx.Select("id", "name", "quantity", "status").Where("id = ?", itemID).Limit(1).Find(&item).Error; err != nil {
+			return err
+		}
+		if item.ID == 0 || item.Status != model.ItemStatusPublished {
+			continue
+		}
+		reserved, err := reservedQuantity(tx, item.ID)
+		if err != nil {
+			return err
+		}
+		if model.Available(item.Quantity, reserved) > 0 {
+			if err := notifyBackInStock(tx, item, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
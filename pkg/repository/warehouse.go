@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"stroycity/pkg/model"
+)
+
+// ErrNotEnoughStock возвращается, если на складе меньше товара, чем нужно списать
+var ErrNotEnoughStock = errors.New("not enough stock")
+
+type WarehouseRepository struct {
+	db *gorm.DB
+}
+
+func NewWarehouseRepository(db *gorm.DB) *WarehouseRepository {
+	return &WarehouseRepository{db: db}
+}
+
+func (r *WarehouseRepository) CreateWarehouse(warehouse model.Warehouse) (int, error) {
+	if err := r.db.Create(&warehouse).Error; err != nil {
+		return 0, err
+	}
+	return warehouse.ID, nil
+}
+
+func (r *WarehouseRepository) UpdateWarehouse(warehouse model.Warehouse) error {
+	return r.db.Omit("Stocks").Save(&warehouse).Error
+}
+
+// DeleteWarehouse удаляет склад вместе с остатками и пересчитывает общее количество затронутых товаров.
+// Списанные со склада остатки записываются в журнал движений как change.
+func (r *WarehouseRepository) DeleteWarehouse(id int, change model.StockChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var stocks []model.WarehouseStock
+		if err := tx.Where("warehouse_id = ?", id).Find(&stocks).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("warehouse_id = ?", id).Delete(&model.WarehouseStock{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.Warehouse{}, id).Error; err != nil {
+			return err
+		}
+		for _, stock := range stocks {
+			if err := syncItemQuantity(tx, stock.ItemID); err != nil {
+				return err
+			}
+			movement := model.StockMovement{WarehouseID: &id, Delta: -stock.Quantity}
+			if err := recordStockMovements(tx, stock.ItemID, []model.StockMovement{movement}, change); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *WarehouseRepository) GetWarehouseById(id int) (model.Warehouse, error) {
+	var warehouse model.Warehouse
+	if err := r.db.First(&warehouse, id).Error; err != nil {
+		return warehouse, err
+	}
+	return warehouse, nil
+}
+
+func (r *WarehouseRepository) GetWarehouses(sellerID string) ([]model.Warehouse, error) {
+	var warehouses []model.Warehouse
+	if err := r.db.Where("seller_id = ?", sellerID).Order("id").Find(&warehouses).Error; err != nil {
+		return nil, err
+	}
+	return warehouses, nil
+}
+
+// GetItemStocks возвращает остатки товара по складам вместе с данными складов
+func (r *WarehouseRepository) GetItemStocks(itemID int) ([]model.WarehouseStock, error) {
+	var stocks []model.WarehouseStock
+	if err := r.db.Preload("Warehouse").Where("item_id = ?", itemID).Order("warehouse_id").Find(&stocks).Error; err != nil {
+		return nil, err
+	}
+	return stocks, nil
+}
+
+// SetStock задаёт остаток товара на складе и пересчитывает общее количество товара.
+// Изменение остатка записывается в журнал движений как change.
+func (r *WarehouseRepository) SetStock(itemID, warehouseID, quantity int, change model.StockChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var before model.WarehouseStock
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("item_id = ? AND warehouse_id = ?", itemID, warehouseID).Limit(1).Find(&before).Error; err != nil {
+			return err
+		}
+
+		stock := model.WarehouseStock{WarehouseID: warehouseID, ItemID: itemID, Quantity: quantity}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "warehouse_id"}, {Name: "item_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"quantity"}),
+		}).Create(&stock).Error; err != nil {
+			return err
+		}
+		if err := syncItemQuantity(tx, itemID); err != nil {
+			return err
+		}
+		movement := model.StockMovement{WarehouseID: &warehouseID, Delta: quantity - before.Quantity}
+		return recordStockMovements(tx, itemID, []model.StockMovement{movement}, change)
+	})
+}
+
+// decreaseStock списывает проданное количество: по складам, если заданы распределения, иначе с общего остатка.
+// Списание не уводит остаток в минус — при нехватке возвращается ErrNotEnoughStock.
+// Остатки меняются и у удалённых товаров: заказ, оформленный до удаления, должен оплачиваться и отменяться.
+func decreaseStock(tx *gorm.DB, itemID, quantity int, allocations []model.OrderItemAllocation) error {
+	if len(allocations) == 0 {
+		result := tx.Unscoped().Model(&model.Item{}).Where("id = ? AND quantity >= ?", itemID, quantity).
+			UpdateColumns(map[string]interface{}{
+				"quantity": gorm.Expr("quantity - ?", quantity),
+				"version":  gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotEnoughStock
+		}
+		return nil
+	}
+
+	for _, allocation := range allocations {
+		result := tx.Model(&model.WarehouseStock{}).
+			Where("item_id = ? AND warehouse_id = ? AND quantity >= ?", itemID, allocation.WarehouseID, allocation.Quantity).
+			UpdateColumn("quantity", gorm.Expr("quantity - ?", allocation.Quantity))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotEnoughStock
+		}
+	}
+	return syncItemQuantity(tx, itemID)
+}
+
+// increaseStock возвращает товар на склады, с которых он был списан, или в общий остаток
+func increaseStock(tx *gorm.DB, itemID, quantity int, allocations []model.OrderItemAllocation) error {
+	if len(allocations) == 0 {
+		return tx.Unscoped().Model(&model.Item{}).Where("id = ?", itemID).
+			UpdateColumns(map[string]interface{}{
+				"quantity": gorm.Expr("quantity + ?", quantity),
+				"version":  gorm.Expr("version + 1"),
+			}).Error
+	}
+
+	for _, allocation := range allocations {
+		stock := model.WarehouseStock{WarehouseID: allocation.WarehouseID, ItemID: itemID, Quantity: allocation.Quantity}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "warehouse_id"}, {Name: "item_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"quantity": gorm.Expr("warehouse_stocks.quantity + ?", allocation.Quantity)}),
+		}).Create(&stock).Error; err != nil {
+			return err
+		}
+	}
+	return syncItemQuantity(tx, itemID)
+}
+
+// syncItemQuantity записывает в Item.Quantity сумму остатков по складам.
+// Вызывается только для товаров, учитываемых по складам: если удалён последний склад с товаром,
+// остаток обнуляется, а не остаётся прежним.
+func syncItemQuantity(tx *gorm.DB, itemID int) error {
+	var total int
+	if err := tx.Model(&model.WarehouseStock{}).Where("item_id = ?", itemID).Select("COALESCE(SUM(quantity), 0)").Scan(&total).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Model(&model.Item{}).Where("id = ?", itemID).UpdateColumns(map[string]interface{}{
+		"quantity": total,
+		"version":  gorm.Expr("version + 1"),
+	}).Error
+}
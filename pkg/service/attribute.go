@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+)
+
+type AttributeService struct {
+	repo repository.Attribute
+}
+
+func NewAttributeService(repo repository.Attribute) *AttributeService {
+	return &AttributeService{repo: repo}
+}
+
+func (s *AttributeService) CreateAttribute(input model.AttributeInput) (int, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return 0, errors.New("attribute name is required")
+	}
+	if input.CategoryID == 0 {
+		return 0, errors.New("category_id is required")
+	}
+
+	attribute := model.Attribute{
+		CategoryID: input.CategoryID,
+		Name:       name,
+		Type:       input.Type,
+		Unit:       strings.TrimSpace(input.Unit),
+	}
+
+	switch input.Type {
+	case model.AttributeTypeNumber:
+	case model.AttributeTypeEnum:
+		seen := map[string]bool{}
+		for _, option := range input.Options {
+			option = strings.TrimSpace(option)
+			if option == "" || seen[option] {
+				continue
+			}
+			seen[option] = true
+			attribute.Options = append(attribute.Options, model.AttributeOption{Value: option})
+		}
+		if len(attribute.Options) == 0 {
+			return 0, errors.New("enum attribute must have at least one option")
+		}
+	case model.AttributeTypeBoolean:
+		attribute.Unit = ""
+	default:
+		return 0, fmt.Errorf("unknown attribute type: %s", input.Type)
+	}
+
+	return s.repo.CreateAttribute(attribute)
+}
+
+func (s *AttributeService) DeleteAttribute(id int) error {
+	return s.repo.DeleteAttribute(id)
+}
+
+func (s *AttributeService) GetAttributesByCategoryID(categoryID int) ([]model.AttributeOutput, error) {
+	attributes, err := s.repo.GetAttributesByCategoryID(categoryID)
+	if err != nil {
+		return nil, err
+	}
+	var attributesInfo []model.AttributeOutput
+	for _, attribute := range attributes {
+		attributeInfo := model.AttributeOutput{
+			ID:         attribute.ID,
+			CategoryID: attribute.CategoryID,
+			Name:       attribute.Name,
+			Type:       attribute.Type,
+			Unit:       attribute.Unit,
+		}
+		for _, option := range attribute.Options {
+			attributeInfo.Options = append(attributeInfo.Options, option.Value)
+		}
+		attributesInfo = append(attributesInfo, attributeInfo)
+	}
+	return attributesInfo, nil
+}
@@ -1,24 +1,169 @@
 package service
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
 	"stroycity/pkg/model"
 	"stroycity/pkg/repository"
+	"stroycity/pkg/storage"
+	"time"
 )
 
+// brandLogoRendition — копия изображения, которая сохраняется как логотип бренда
+const brandLogoRendition = "medium"
+
 type BrandService struct {
-	repo repository.Brand
+	repo     repository.Brand
+	slugRepo repository.Slug
+	storage  storage.BlobStorage
+	seo      SEOConfig
 }
 
-func NewBrandService(repo repository.Brand) *BrandService {
-	return &BrandService{repo: repo}
+func NewBrandService(repo repository.Brand, slugRepo repository.Slug, storage storage.BlobStorage, seo SEOConfig) *BrandService {
+	return &BrandService{repo: repo, slugRepo: slugRepo, storage: storage, seo: seo}
 }
 
 func (s *BrandService) CreateBrand(brand model.Brand) error {
-	return s.repo.CreateBrand(brand)
+	brand.Slug = ""
+	brand.LogoKey = ""
+	id, err := s.repo.CreateBrand(brand)
+	if err != nil {
+		return err
+	}
+	return assignSlug(s.slugRepo, model.SlugEntityBrand, id, brand.Name)
+}
+
+// UpdateBrand меняет название, описание бренда и описание для поисковиков.
+// При переименовании бренд получает новый адрес, старый перенаправляется на него.
+func (s *BrandService) UpdateBrand(id int, input model.BrandInput) error {
+	brand, err := s.getBrand(id)
+	if err != nil {
+		return err
+	}
+	oldName := brand.Name
+	if brand.Name, err = dictionaryName(input.Name); err != nil {
+		return err
+	}
+	brand.Description = input.Description
+	brand.MetaTitle = input.MetaTitle
+	brand.MetaDescription = input.MetaDescription
+	if err := s.repo.UpdateBrand(brand); err != nil {
+		return err
+	}
+
+	if brand.Name != oldName || brand.Slug == "" {
+		return assignSlug(s.slugRepo, model.SlugEntityBrand, id, brand.Name)
+	}
+	return nil
+}
+
+// UploadBrandLogo заменяет логотип бренда и возвращает его адрес
+func (s *BrandService) UploadBrandLogo(id int, fileHeader *multipart.FileHeader) (string, error) {
+	brand, err := s.getBrand(id)
+	if err != nil {
+		return "", err
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %v", fileHeader.Filename, err)
+	}
+	defer file.Close()
+	renditions, err := processImage(file)
+	if err != nil {
+		return "", err
+	}
+
+	logo := renditions[len(renditions)-1]
+	for _, rendition := range renditions {
+		if rendition.Name == brandLogoRendition {
+			logo = rendition
+		}
+	}
+
+	ctx := context.Background()
+	key := fmt.Sprintf("brands/%d/%d_logo%s", brand.ID, time.Now().UnixNano(), logo.Ext)
+	if err := s.storage.Put(ctx, key, bytes.NewReader(logo.Data), int64(len(logo.Data)), logo.ContentType); err != nil {
+		return "", err
+	}
+
+	oldKey := brand.LogoKey
+	brand.LogoKey = key
+	if err := s.repo.UpdateBrand(brand); err != nil {
+		_ = s.storage.Delete(ctx, key)
+		return "", err
+	}
+	if oldKey != "" {
+		_ = s.storage.Delete(ctx, oldKey)
+	}
+	return s.storage.URL(key), nil
+}
+
+// DeleteBrand удаляет бренд. Если у бренда есть товары, удаление возможно только
+// с переносом товаров в бренд reassignTo.
+func (s *BrandService) DeleteBrand(id, reassignTo int) error {
+	brand, err := s.getBrand(id)
+	if err != nil {
+		return err
+	}
+	if reassignTo != 0 {
+		return s.MergeBrands(model.MergeInput{TargetID: reassignTo, SourceIDs: []int{id}})
+	}
+
+	count, err := s.repo.CountBrandItems(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d items", ErrReferencedByItems, count)
+	}
+	if err := s.repo.DeleteBrand(id); err != nil {
+		return err
+	}
+	if brand.LogoKey != "" {
+		_ = s.storage.Delete(context.Background(), brand.LogoKey)
+	}
+	return nil
 }
 
-func (s *BrandService) DeleteBrand(id int) error {
-	return s.repo.DeleteBrand(id)
+// MergeBrands объединяет дубликаты («Knauf» и «КНАУФ») с каноническим брендом.
+// Если у канонического бренда нет описания или логотипа, они берутся у первого дубликата, где они заполнены.
+func (s *BrandService) MergeBrands(input model.MergeInput) error {
+	sourceIDs, err := mergeSources(input)
+	if err != nil {
+		return err
+	}
+	target, err := s.getBrand(input.TargetID)
+	if err != nil {
+		return err
+	}
+
+	var sources []model.Brand
+	for _, id := range sourceIDs {
+		source, err := s.getBrand(id)
+		if err != nil {
+			return err
+		}
+		if target.Description == "" {
+			target.Description = source.Description
+		}
+		if target.LogoKey == "" {
+			target.LogoKey = source.LogoKey
+		}
+		sources = append(sources, source)
+	}
+
+	if err := s.repo.MergeBrands(target, sourceIDs); err != nil {
+		return err
+	}
+	for _, source := range sources {
+		if source.LogoKey != "" && source.LogoKey != target.LogoKey {
+			_ = s.storage.Delete(context.Background(), source.LogoKey)
+		}
+	}
+	return nil
 }
 
 func (s *BrandService) GetBrandList() ([]model.BrandOutput, error) {
@@ -28,10 +173,48 @@ func (s *BrandService) GetBrandList() ([]model.BrandOutput, error) {
 	}
 	var brandsInfo []model.BrandOutput
 	for _, brand := range brands {
-		brandsInfo = append(brandsInfo, model.BrandOutput{
-			ID:   brand.ID,
-			Name: brand.Name,
-		})
+		brandsInfo = append(brandsInfo, s.brandOutput(brand))
 	}
 	return brandsInfo, nil
 }
+
+// GetBrandBySlug возвращает бренд по текущему или прежнему адресу.
+// Если адрес прежний, Slug результата отличается от запрошенного.
+func (s *BrandService) GetBrandBySlug(slug string) (model.BrandOutput, error) {
+	id, err := resolveSlug(s.slugRepo, model.SlugEntityBrand, slug)
+	if err != nil {
+		return model.BrandOutput{}, err
+	}
+	brand, err := s.getBrand(id)
+	if err != nil {
+		return model.BrandOutput{}, err
+	}
+	return s.brandOutput(brand), nil
+}
+
+func (s *BrandService) brandOutput(brand model.Brand) model.BrandOutput {
+	brandInfo := model.BrandOutput{
+		ID:              brand.ID,
+		Name:            brand.Name,
+		Description:     brand.Description,
+		Slug:            brand.Slug,
+		CanonicalURL:    s.seo.canonicalURL(model.BrandPath(brand)),
+		MetaTitle:       metaTitle(brand.MetaTitle, brand.Name),
+		MetaDescription: metaDescription(brand.MetaDescription, brand.Description),
+	}
+	if brand.LogoKey != "" {
+		brandInfo.LogoURL = s.storage.URL(brand.LogoKey)
+	}
+	return brandInfo
+}
+
+func (s *BrandService) getBrand(id int) (model.Brand, error) {
+	brand, err := s.repo.GetBrandById(id)
+	if err != nil {
+		return brand, err
+	}
+	if brand.ID == 0 {
+		return brand, fmt.Errorf("%w: %d", ErrBrandNotFound, id)
+	}
+	return brand, nil
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+	"stroycity/pkg/storage"
+)
+
+var (
+	ErrBundleNotFound = errors.New("bundle not found")
+	ErrInvalidBundle  = errors.New("invalid bundle")
+)
+
+type BundleService struct {
+	repo            repository.Bundle
+	itemRepo        repository.Item
+	categoryRepo    repository.Category
+	reservationRepo repository.Reservation
+	moderationRepo  repository.Moderation
+	storage         storage.BlobStorage
+}
+
+func NewBundleService(repo repository.Bundle, itemRepo repository.Item, categoryRepo repository.Category, reservationRepo repository.Reservation, moderationRepo repository.Moderation, storage storage.BlobStorage) *BundleService {
+	return &BundleService{repo: repo, itemRepo: itemRepo, categoryRepo: categoryRepo, reservationRepo: reservationRepo, moderationRepo: moderationRepo, storage: storage}
+}
+
+// CreateBundle создаёт комплект. Публикация, как и у товаров, проходит через модерацию:
+// комплект получает статус moderation и заявку в очереди.
+func (s *BundleService) CreateBundle(sellerID string, input model.BundleInput) (int, error) {
+	bundle, err := s.bundleFromInput(sellerID, input)
+	if err != nil {
+		return 0, err
+	}
+
+	var request *model.ModerationRequest
+	if bundle.Status == model.ItemStatusPublished {
+		bundle.Status = model.ItemStatusModeration
+		request = &model.ModerationRequest{
+			SellerID: sellerID,
+			Kind:     model.ModerationKindNew,
+			Status:   model.ModerationStatusPending,
+			Changes:  bundleModerationDiff(model.Bundle{}, bundle, bundleModerationFields),
+		}
+	}
+	return s.repo.CreateBundle(bundle, request)
+}
+
+// UpdateBundle обновляет комплект. Изменение названия, описания или категории опубликованного комплекта
+// проходит модерацию: до одобрения покупатели видят прежнюю версию, а правка хранится в заявке.
+// Цена и состав меняются сразу.
+func (s *BundleService) UpdateBundle(sellerID string, id int, input model.BundleInput) error {
+	before, err := s.sellerBundle(sellerID, id)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := s.bundleFromInput(sellerID, input)
+	if err != nil {
+		return err
+	}
+	bundle.ID = id
+	if bundle.Status != model.ItemStatusPublished {
+		return s.repo.UpdateBundle(bundle, nil)
+	}
+
+	request, err := s.moderationRepo.GetPendingBundleModerationRequest(id)
+	if err != nil {
+		return err
+	}
+	switch before.Status {
+	case model.ItemStatusPublished:
+		if len(bundleModerationDiff(before, bundle, bundleModerationTriggerFields)) == 0 {
+			return s.repo.UpdateBundle(bundle, nil)
+		}
+		if request.ID == 0 {
+			request = model.ModerationRequest{BundleID: &id, SellerID: sellerID, Kind: model.ModerationKindEdit, Status: model.ModerationStatusPending}
+		}
+		request.Changes = mergeModerationChanges(request.Changes, bundleModerationDiff(before, bundle, bundleModerationFields))
+		pending := model.BundleContentOf(bundle)
+		request.PendingBundle = &pending
+		model.BundleContentOf(before).Apply(&bundle)
+	default:
+		// Новый комплект ждёт проверки целиком: модератор видит все поля в актуальном состоянии
+		bundle.Status = model.ItemStatusModeration
+		if request.ID == 0 {
+			request = model.ModerationRequest{BundleID: &id, SellerID: sellerID, Kind: model.ModerationKindNew, Status: model.ModerationStatusPending}
+		}
+		request.Changes = bundleModerationDiff(model.Bundle{}, bundle, bundleModerationFields)
+	}
+	return s.repo.UpdateBundle(bundle, &request)
+}
+
+func (s *BundleService) DeleteBundle(sellerID string, id int) error {
+	if _, err := s.sellerBundle(sellerID, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteBundle(id)
+}
+
+// GetSellerBundles возвращает все комплекты продавца независимо от статуса
+func (s *BundleService) GetSellerBundles(sellerID string) ([]model.BundleInfo, error) {
+	bundles, err := s.repo.GetBundles(0, sellerID, "")
+	if err != nil {
+		return nil, err
+	}
+	return s.convertBundles(bundles)
+}
+
+// GetBundles возвращает опубликованные комплекты для каталога; categoryID 0 — всех категорий
+func (s *BundleService) GetBundles(categoryID int) ([]model.BundleInfo, error) {
+	bundles, err := s.repo.GetBundles(categoryID, "", model.ItemStatusPublished)
+	if err != nil {
+		return nil, err
+	}
+	return s.convertBundles(bundles)
+}
+
+func (s *BundleService) GetBundleById(id int) (model.BundleInfo, error) {
+	bundle, err := s.repo.GetBundleById(id)
+	if err != nil {
+		return model.BundleInfo{}, err
+	}
+	if bundle.ID == 0 || bundle.Status != model.ItemStatusPublished {
+		return model.BundleInfo{}, ErrBundleNotFound
+	}
+
+	infos, err := s.convertBundles([]model.Bundle{bundle})
+	if err != nil {
+		return model.BundleInfo{}, err
+	}
+	return infos[0], nil
+}
+
+// sellerBundle возвращает комплект продавца; чужой комплект считается ненайденным
+func (s *BundleService) sellerBundle(sellerID string, id int) (model.Bundle, error) {
+	bundle, err := s.repo.GetBundleById(id)
+	if err != nil {
+		return bundle, err
+	}
+	if bundle.ID == 0 || bundle.SellerID != sellerID {
+		return bundle, ErrBundleNotFound
+	}
+	return bundle, nil
+}
+
+// bundleFromInput проверяет комплект: в нём не меньше двух разных товаров продавца в одной валюте,
+// количество каждого учитывает минимальный заказ и кратность товара,
+// а опубликовать можно только комплект из опубликованных товаров
+func (s *BundleService) bundleFromInput(sellerID string, input model.BundleInput) (model.Bundle, error) {
+	bundle := model.Bundle{
+		SellerID:    sellerID,
+		CategoryID:  input.CategoryID,
+		Name:        strings.TrimSpace(input.Name),
+		Description: input.Description,
+		Price:       input.Price,
+		Status:      input.Status,
+	}
+	if bundle.Name == "" {
+		return bundle, ErrNameRequired
+	}
+	if bundle.Price <= 0 {
+		return bundle, fmt.Errorf("%w: price must be positive", ErrInvalidBundle)
+	}
+	switch bundle.Status {
+	case "":
+		bundle.Status = model.ItemStatusPublished
+	case model.ItemStatusDraft, model.ItemStatusPublished, model.ItemStatusArchived:
+	default:
+		return bundle, fmt.Errorf("%w: unknown status %s", ErrInvalidBundle, bundle.Status)
+	}
+
+	category, err := s.categoryRepo.GetCategoryById(bundle.CategoryID)
+	if err != nil {
+		return bundle, err
+	}
+	if category.ID == 0 {
+		return bundle, ErrCategoryNotFound
+	}
+
+	if len(input.Components) < 2 {
+		return bundle, fmt.Errorf("%w: at least two items are required", ErrInvalidBundle)
+	}
+	seen := map[int]bool{}
+	for _, component := range input.Components {
+		if seen[component.ItemID] {
+			return bundle, fmt.Errorf("%w: item %d is listed twice", ErrInvalidBundle, component.ItemID)
+		}
+		seen[component.ItemID] = true
+
+		item, err := s.itemRepo.GetItemById(component.ItemID)
+		if err != nil || item.SellerID != sellerID {
+			return bundle, fmt.Errorf("%w: item %d not found", ErrInvalidBundle, component.ItemID)
+		}
+		if err := validateOrderQuantity(item, component.Quantity); err != nil {
+			return bundle, fmt.Errorf("%w: %s", ErrInvalidBundle, err.Error())
+		}
+		if bundle.Status == model.ItemStatusPublished && item.Status != model.ItemStatusPublished {
+			return bundle, fmt.Errorf("%w: item %s is not published", ErrInvalidBundle, item.Name)
+		}
+		if bundle.Currency == "" {
+			bundle.Currency = item.Currency
+		} else if item.Currency != bundle.Currency {
+			return bundle, fmt.Errorf("%w: item %s has currency %s, bundle currency is %s", ErrInvalidBundle, item.Name, item.Currency, bundle.Currency)
+		}
+
+		bundle.Components = append(bundle.Components, model.BundleComponent{ItemID: item.ID, Quantity: component.Quantity})
+	}
+
+	return bundle, nil
+}
+
+// convertBundles формирует комплекты для каталога с доступным количеством за вычетом резервов компонентов
+func (s *BundleService) convertBundles(bundles []model.Bundle) ([]model.BundleInfo, error) {
+	var itemIDs []int
+	for _, bundle := range bundles {
+		for _, component := range bundle.Components {
+			itemIDs = append(itemIDs, component.ItemID)
+		}
+	}
+	reserved, err := s.reservationRepo.GetReservedStock(itemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := []model.BundleInfo{}
+	for _, bundle := range bundles {
+		info := model.BundleInfo{
+			ID:          bundle.ID,
+			Name:        bundle.Name,
+			Description: bundle.Description,
+			Price:       bundle.Price,
+			Currency:    bundle.Currency,
+			Status:      bundle.Status,
+			CategoryID:  bundle.CategoryID,
+			Category:    bundle.Category.Name,
+			SellerID:    bundle.SellerID,
+			Images:      []string{},
+			Components:  []model.BundleComponentInfo{},
+		}
+
+		// Комплектов можно собрать столько, сколько позволяет самый дефицитный компонент
+		info.Available = -1
+		for _, component := range bundle.Components {
+			item := component.Item
+			available := 0
+			if item.ID != 0 && item.Status == model.ItemStatusPublished {
+				available = model.Available(item.Quantity, reserved[item.ID].Total)
+			}
+			if info.Available < 0 || available/component.Quantity < info.Available {
+				info.Available = available / component.Quantity
+			}
+
+			info.ComponentsPrice += item.PriceWithDiscount.Mul(component.Quantity)
+			if images := model.SortImages(item.Images); len(images) > 0 {
+				info.Images = append(info.Images, s.storage.URL(images[0].StorageKey))
+			}
+			info.Components = append(info.Components, model.BundleComponentInfo{
+				ItemID:    component.ItemID,
+				Name:      item.Name,
+				Slug:      item.Slug,
+				Quantity:  component.Quantity,
+				Price:     item.PriceWithDiscount,
+				Unit:      item.Unit,
+				Available: available,
+			})
+		}
+		info.Available = max(0, info.Available)
+		info.Savings = max(0, info.ComponentsPrice-info.Price)
+
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// splitBundlePrice распределяет цену комплекта между компонентами пропорционально их стоимости weights.
+// Доли в копейках в сумме точно равны цене: остаток от округления получают компоненты с наибольшей дробной частью.
+func splitBundlePrice(price model.Money, weights []model.Money) []model.Money {
+	shares := make([]model.Money, len(weights))
+	if len(weights) == 0 {
+		return shares
+	}
+
+	var total int64
+	for _, weight := range weights {
+		total += int64(weight)
+	}
+	if total <= 0 {
+		// Без цен компонентов делим поровну
+		weights = make([]model.Money, len(weights))
+		for i := range weights {
+			weights[i] = 1
+		}
+		total = int64(len(weights))
+	}
+
+	remainders := make([]int64, len(weights))
+	var distributed int64
+	for i, weight := range weights {
+		product := int64(price) * int64(weight)
+		shares[i] = model.Money(product / total)
+		remainders[i] = product % total
+		distributed += int64(shares[i])
+	}
+	for left := int64(price) - distributed; left > 0; left-- {
+		largest := 0
+		for i := range remainders {
+			if remainders[i] > remainders[largest] {
+				largest = i
+			}
+		}
+		shares[largest]++
+		remainders[largest] = -1
+	}
+	return shares
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"reflect"
+	"stroycity/pkg/model"
+	"testing"
+)
+
+func TestSplitBundlePrice(t *testing.T) {
+	tests := []struct {
+		name    string
+		price   model.Money
+		weights []model.Money
+		want    []model.Money
+	}{
+		{name: "proportional to component prices", price: 900, weights: []model.Money{200, 400, 600}, want: []model.Money{150, 300, 450}},
+		{name: "rounding remainder goes to the largest fractions", price: 1000, weights: []model.Money{1, 1, 1}, want: []model.Money{334, 333, 333}},
+		{name: "remainder follows the fractional part, not the order", price: 100, weights: []model.Money{10, 20}, want: []model.Money{33, 67}},
+		{name: "components without prices split evenly", price: 10, weights: []model.Money{0, 0, 0}, want: []model.Money{4, 3, 3}},
+		{name: "single component gets the whole price", price: 12345, weights: []model.Money{999}, want: []model.Money{12345}},
+		{name: "no components", price: 100, weights: nil, want: []model.Money{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitBundlePrice(tt.price, tt.weights)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			var total model.Money
+			for _, share := range got {
+				total += share
+			}
+			if len(got) > 0 && total != tt.price {
+				t.Errorf("shares sum to %v, want %v", total, tt.price)
+			}
+		})
+	}
+}
@@ -4,15 +4,17 @@ import (
 	"github.com/gofrs/uuid"
 	"stroycity/pkg/model"
 	"stroycity/pkg/repository"
+	"stroycity/pkg/storage"
 )
 
 type BuyerService struct {
 	repo     repository.Buyer
 	itemRepo repository.Item
+	storage  storage.BlobStorage
 }
 
-func NewBuyerService(repo repository.Buyer, itemRepo repository.Item) *BuyerService {
-	return &BuyerService{repo: repo, itemRepo: itemRepo}
+func NewBuyerService(repo repository.Buyer, itemRepo repository.Item, storage storage.BlobStorage) *BuyerService {
+	return &BuyerService{repo: repo, itemRepo: itemRepo, storage: storage}
 }
 
 func (s *BuyerService) BuyerSignUp(buyerInput model.BuyerInput) error {
@@ -37,7 +39,7 @@ func (s *BuyerService) GetBuyer(id string) (model.BuyerOutput, error) {
 		orders = append(orders, s.orderToOrderOutput(order))
 	}
 
-	favorites := model.ConvertItemsToItemInfo(buyer.Favorites)
+	favorites := model.ConvertItemsToItemInfo(buyer.Favorites, s.storage.URL)
 	buyerOutput := model.BuyerOutput{
 		ID:        buyer.ID,
 		Name:      buyer.Name,
@@ -71,7 +73,7 @@ func (s *BuyerService) BuyerSignIn(mail, password string) (model.BuyerSignInResp
 	token := CreateToken(buyer.ID, "buyer")
 
 	signInResponse.Token = token
-	favorites := model.ConvertItemsToItemInfo(buyer.Favorites)
+	favorites := model.ConvertItemsToItemInfo(buyer.Favorites, s.storage.URL)
 
 	orders := []model.OrderOutput{}
 	for _, order := range buyer.Orders {
@@ -100,16 +102,19 @@ func (s *BuyerService) RemoveFromFavorites(buyerID string, itemID int) error {
 func (s *BuyerService) orderToOrderOutput(order model.Order) model.OrderOutput {
 	orderOutput := model.OrderOutput{}
 	orderOutput.Total = order.Total
+	orderOutput.Currency = order.Currency
 	orderOutput.Status = order.Status
 	orderOutput.BuyerID = order.BuyerID
 	for _, orderItem := range order.OrderItems {
-		itemInfo, _ := s.itemRepo.GetItemById(orderItem.ID)
+		// Удалённые товары остаются в истории заказов
+		itemInfo, _ := s.itemRepo.GetItemByIdUnscoped(orderItem.ItemID)
 
 		orderOutput.Items = append(orderOutput.Items, model.OrderItemInfo{
-			ID:       orderItem.ID,
+			ID:       orderItem.ItemID,
 			Name:     itemInfo.Name,
-			Price:    itemInfo.Price,
-			Quantity: itemInfo.Quantity,
+			Price:    orderItem.UnitPrice,
+			Quantity: orderItem.Quantity,
+			Total:    orderItem.Total,
 		})
 	}
 	return orderOutput
@@ -2,20 +2,30 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"stroycity/pkg/model"
 	"stroycity/pkg/repository"
 )
 
 type CartService struct {
-	repo     repository.Cart
-	itemRepo repository.Item
+	repo       repository.Cart
+	itemRepo   repository.Item
+	bundleRepo repository.Bundle
 }
 
-func NewCartService(repo repository.Cart, itemRepo repository.Item) *CartService {
-	return &CartService{repo: repo, itemRepo: itemRepo}
+func NewCartService(repo repository.Cart, itemRepo repository.Item, bundleRepo repository.Bundle) *CartService {
+	return &CartService{repo: repo, itemRepo: itemRepo, bundleRepo: bundleRepo}
 }
 
 func (s *CartService) AddToCart(buyerID string, itemID int, quantity int) error {
+	item, err := s.itemRepo.GetItemById(itemID)
+	if err != nil {
+		return err
+	}
+	if err := validateQuantity(item, quantity); err != nil {
+		return err
+	}
+
 	usersCart, err := s.repo.GetCartByBuyerID(buyerID)
 	if err != nil {
 		return err
@@ -45,23 +55,118 @@ func (s *CartService) GetCart(buyerID string) (model.CartOutput, error) {
 	}
 	cartOutput := model.CartOutput{}
 	cartOutput.BuyerID = cart.BuyerID
+	cartOutput.Currency = model.DefaultCurrency
 	for _, cartItem := range cart.CartItems {
 		itemInfo, _ := s.itemRepo.GetItemById(cartItem.ItemID)
 
-		cartOutput.Items = append(cartOutput.Items, model.CartItemInfo{
+		unitPrice := model.UnitPriceForQuantity(itemInfo, cartItem.Quantity)
+		cartItemInfo := model.CartItemInfo{
 			ID:       itemInfo.ID,
 			Name:     itemInfo.Name,
-			Price:    itemInfo.Price,
+			Price:    unitPrice,
 			Quantity: cartItem.Quantity,
-		})
+			Total:    unitPrice.Mul(cartItem.Quantity),
+			Unit:     itemInfo.Unit,
+		}
+		if itemInfo.BaseUnit != "" {
+			cartItemInfo.BaseUnit = itemInfo.BaseUnit
+			cartItemInfo.BaseQuantity = float64(cartItem.Quantity) * itemInfo.UnitRatio
+		}
+		cartOutput.Items = append(cartOutput.Items, cartItemInfo)
+		cartOutput.Total += cartItemInfo.Total
+		if itemInfo.Currency != "" {
+			cartOutput.Currency = itemInfo.Currency
+		}
+	}
+
+	cartBundles, err := s.repo.GetCartBundles(buyerID)
+	if err != nil {
+		return model.CartOutput{}, err
+	}
+	for _, cartBundle := range cartBundles {
+		bundle := cartBundle.Bundle
+		if bundle.ID == 0 {
+			continue
+		}
+		cartBundleInfo := model.CartBundleInfo{
+			ID:       bundle.ID,
+			Name:     bundle.Name,
+			Price:    bundle.Price,
+			Quantity: cartBundle.Quantity,
+			Total:    bundle.Price.Mul(cartBundle.Quantity),
+		}
+		cartOutput.Bundles = append(cartOutput.Bundles, cartBundleInfo)
+		cartOutput.Total += cartBundleInfo.Total
+		cartOutput.Currency = bundle.Currency
 	}
 	return cartOutput, nil
 }
 
+// AddBundleToCart кладёт комплект в корзину; если он уже там, количество заменяется.
+// Остатки компонентов проверяются при оформлении заказа, как и для обычных товаров.
+func (s *CartService) AddBundleToCart(buyerID string, bundleID int, quantity int) error {
+	bundle, err := s.bundleRepo.GetBundleById(bundleID)
+	if err != nil {
+		return err
+	}
+	if bundle.ID == 0 || bundle.Status != model.ItemStatusPublished {
+		return ErrBundleNotFound
+	}
+	if quantity <= 0 {
+		return fmt.Errorf("%w: quantity must be positive", ErrInvalidBundle)
+	}
+
+	return s.repo.SaveCartBundle(model.CartBundle{BuyerID: buyerID, BundleID: bundleID, Quantity: quantity})
+}
+
+func (s *CartService) RemoveBundleFromCart(buyerID string, bundleID int) error {
+	removed, err := s.repo.RemoveCartBundle(buyerID, bundleID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrBundleNotFound
+	}
+	return nil
+}
+
 func (s *CartService) UpdateCartItem(cartItemID int, quantity int) error {
+	cartItem, err := s.repo.GetCartItemByID(cartItemID)
+	if err != nil {
+		return err
+	}
+	item, err := s.itemRepo.GetItemById(cartItem.ItemID)
+	if err != nil {
+		return err
+	}
+	if err := validateQuantity(item, quantity); err != nil {
+		return err
+	}
 	return s.repo.UpdateCartItem(cartItemID, quantity)
 }
 
+// validateQuantity проверяет, что товар продаётся, а также минимальное количество и кратность заказа
+func validateQuantity(item model.Item, quantity int) error {
+	if item.Status != model.ItemStatusPublished {
+		return fmt.Errorf("item %s is not on sale", item.Name)
+	}
+	return validateOrderQuantity(item, quantity)
+}
+
+// validateOrderQuantity проверяет минимальное количество и кратность заказа товара
+func validateOrderQuantity(item model.Item, quantity int) error {
+	if quantity <= 0 {
+		return errors.New("quantity must be positive")
+	}
+	if item.MinQuantity > 0 && quantity < item.MinQuantity {
+		return fmt.Errorf("minimum order quantity for %s is %d %s", item.Name, item.MinQuantity, model.UnitNames[item.Unit])
+	}
+	if item.QuantityStep > 1 && quantity%item.QuantityStep != 0 {
+		return fmt.Errorf("quantity for %s must be a multiple of %d %s", item.Name, item.QuantityStep, model.UnitNames[item.Unit])
+	}
+	return nil
+}
+
 func (s *CartService) RemoveFromCart(userID string, itemID int) error {
 	cart, err := s.repo.GetCartByBuyerID(userID)
 	if err != nil {
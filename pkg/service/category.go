@@ -1,26 +1,94 @@
 package service
 
 import (
+	"fmt"
 	"stroycity/pkg/model"
 	"stroycity/pkg/repository"
 )
 
 type CategoryService struct {
-	repo repository.Category
+	repo     repository.Category
+	slugRepo repository.Slug
+	seo      SEOConfig
 }
 
-func NewCategoryService(repo repository.Category) *CategoryService {
-	return &CategoryService{repo: repo}
+func NewCategoryService(repo repository.Category, slugRepo repository.Slug, seo SEOConfig) *CategoryService {
+	return &CategoryService{repo: repo, slugRepo: slugRepo, seo: seo}
 }
 
 func (s *CategoryService) CreateCategory(category model.Category) error {
-	return s.repo.CreateCategory(category)
+	category.Slug = ""
+	id, err := s.repo.CreateCategory(category)
+	if err != nil {
+		return err
+	}
+	return assignSlug(s.slugRepo, model.SlugEntityCategory, id, category.Name)
+}
+
+// UpdateCategory переименовывает категорию и меняет её описание для поисковиков.
+// При переименовании категория получает новый адрес, старый перенаправляется на него.
+func (s *CategoryService) UpdateCategory(id int, input model.CategoryInput) error {
+	category, err := s.getCategory(id)
+	if err != nil {
+		return err
+	}
+	oldName := category.Name
+	if category.Name, err = dictionaryName(input.Name); err != nil {
+		return err
+	}
+	category.MetaTitle = input.MetaTitle
+	category.MetaDescription = input.MetaDescription
+	if err := s.repo.UpdateCategory(category); err != nil {
+		return err
+	}
+
+	if category.Name != oldName || category.Slug == "" {
+		return assignSlug(s.slugRepo, model.SlugEntityCategory, id, category.Name)
+	}
+	return nil
 }
 
-func (s *CategoryService) DeleteCategory(id int) error {
+// DeleteCategory удаляет категорию. Если в ней есть товары или комплекты, удаление возможно только
+// с переносом их и характеристик в категорию reassignTo.
+func (s *CategoryService) DeleteCategory(id, reassignTo int) error {
+	if _, err := s.getCategory(id); err != nil {
+		return err
+	}
+	if reassignTo != 0 {
+		return s.MergeCategories(model.MergeInput{TargetID: reassignTo, SourceIDs: []int{id}})
+	}
+
+	count, err := s.repo.CountCategoryItems(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d items", ErrReferencedByItems, count)
+	}
+	bundles, err := s.repo.CountCategoryBundles(id)
+	if err != nil {
+		return err
+	}
+	if bundles > 0 {
+		return fmt.Errorf("%w: %d bundles", ErrReferencedByBundles, bundles)
+	}
 	return s.repo.DeleteCategory(id)
 }
 
+// MergeCategories объединяет дубликаты с канонической категорией
+func (s *CategoryService) MergeCategories(input model.MergeInput) error {
+	sourceIDs, err := mergeSources(input)
+	if err != nil {
+		return err
+	}
+	for _, id := range append([]int{input.TargetID}, sourceIDs...) {
+		if _, err := s.getCategory(id); err != nil {
+			return err
+		}
+	}
+	return s.repo.MergeCategories(input.TargetID, sourceIDs)
+}
+
 func (s *CategoryService) GetCategoryList() ([]model.CategoryOutput, error) {
 	categories, err := s.repo.GetCategoryList()
 	if err != nil {
@@ -28,10 +96,43 @@ func (s *CategoryService) GetCategoryList() ([]model.CategoryOutput, error) {
 	}
 	var categoriesInfo []model.CategoryOutput
 	for _, category := range categories {
-		categoriesInfo = append(categoriesInfo, model.CategoryOutput{
-			Id:   category.ID,
-			Name: category.Name,
-		})
+		categoriesInfo = append(categoriesInfo, s.categoryOutput(category))
 	}
 	return categoriesInfo, nil
 }
+
+// GetCategoryBySlug возвращает категорию по текущему или прежнему адресу.
+// Если адрес прежний, Slug результата отличается от запрошенного.
+func (s *CategoryService) GetCategoryBySlug(slug string) (model.CategoryOutput, error) {
+	id, err := resolveSlug(s.slugRepo, model.SlugEntityCategory, slug)
+	if err != nil {
+		return model.CategoryOutput{}, err
+	}
+	category, err := s.getCategory(id)
+	if err != nil {
+		return model.CategoryOutput{}, err
+	}
+	return s.categoryOutput(category), nil
+}
+
+func (s *CategoryService) categoryOutput(category model.Category) model.CategoryOutput {
+	return model.CategoryOutput{
+		Id:              category.ID,
+		Name:            category.Name,
+		Slug:            category.Slug,
+		CanonicalURL:    s.seo.canonicalURL(model.CategoryPath(category)),
+		MetaTitle:       metaTitle(category.MetaTitle, category.Name),
+		MetaDescription: category.MetaDescription,
+	}
+}
+
+func (s *CategoryService) getCategory(id int) (model.Category, error) {
+	category, err := s.repo.GetCategoryById(id)
+	if err != nil {
+		return category, err
+	}
+	if category.ID == 0 {
+		return category, fmt.Errorf("%w: %d", ErrCategoryNotFound, id)
+	}
+	return category, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+	"testing"
+)
+
+// categoryRepoStub — категории в памяти; неиспользуемые методы repository.Category не реализованы
+type categoryRepoStub struct {
+	repository.Category
+	categories map[int]bool
+	items      map[int]int64
+	bundles    map[int]int64
+	deleted    []int
+	merged     map[int][]int
+}
+
+func (r *categoryRepoStub) GetCategoryById(id int) (model.Category, error) {
+	if !r.categories[id] {
+		return model.Category{}, nil
+	}
+	return model.Category{ID: id}, nil
+}
+
+func (r *categoryRepoStub) CountCategoryItems(id int) (int64, error) {
+	return r.items[id], nil
+}
+
+func (r *categoryRepoStub) CountCategoryBundles(id int) (int64, error) {
+	return r.bundles[id], nil
+}
+
+func (r *categoryRepoStub) DeleteCategory(id int) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+func (r *categoryRepoStub) MergeCategories(targetID int, sourceIDs []int) error {
+	r.merged[targetID] = append(r.merged[targetID], sourceIDs...)
+	return nil
+}
+
+func TestDeleteCategory(t *testing.T) {
+	tests := []struct {
+		name        string
+		items       int64
+		bundles     int64
+		reassignTo  int
+		wantErr     error
+		wantDeleted []int
+		wantMerged  map[int][]int
+	}{
+		{name: "empty category is deleted", wantDeleted: []int{1}, wantMerged: map[int][]int{}},
+		{name: "category with items is refused", items: 3, wantErr: ErrReferencedByItems, wantMerged: map[int][]int{}},
+		{name: "category with a bundle is refused", bundles: 1, wantErr: ErrReferencedByBundles, wantMerged: map[int][]int{}},
+		{name: "category with a bundle is merged into reassign_to", bundles: 1, reassignTo: 2, wantMerged: map[int][]int{2: {1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &categoryRepoStub{
+				categories: map[int]bool{1: true, 2: true},
+				items:      map[int]int64{1: tt.items},
+				bundles:    map[int]int64{1: tt.bundles},
+				merged:     map[int][]int{},
+			}
+			err := NewCategoryService(repo, nil, SEOConfig{}).DeleteCategory(1, tt.reassignTo)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(repo.deleted, tt.wantDeleted) {
+				t.Errorf("deleted %v, want %v", repo.deleted, tt.wantDeleted)
+			}
+			if !reflect.DeepEqual(repo.merged, tt.wantMerged) {
+				t.Errorf("merged %v, want %v", repo.merged, tt.wantMerged)
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"golang.org/x/text/encoding/charmap"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+)
+
+const (
+	// CommerceMLFileLimit — максимальный размер одной части файла, который 1С присылает за запрос
+	CommerceMLFileLimit = 50 << 20 // 50 МБ
+
+	defaultCommerceMLPriceType         = "Розничная"
+	defaultCommerceMLDiscountPriceType = "Со скидкой"
+	maxCommerceMLErrors                = 20
+)
+
+// ErrInvalidExchangeFile возвращается для недопустимого имени или содержимого файла обмена
+var ErrInvalidExchangeFile = errors.New("invalid exchange file")
+
+// CommerceMLConfig — параметры обмена с 1С.
+// PriceType и DiscountPriceType — названия типов цен 1С, которые попадают в Price и PriceWithDiscount.
+type CommerceMLConfig struct {
+	ExchangeDir       string
+	PriceType         string
+	DiscountPriceType string
+}
+
+type CommerceMLService struct {
+	itemRepo repository.Item
+	items    Item
+	config   CommerceMLConfig
+}
+
+func NewCommerceMLService(itemRepo repository.Item, items Item, config CommerceMLConfig) *CommerceMLService {
+	if config.ExchangeDir == "" {
+		config.ExchangeDir = filepath.Join(os.TempDir(), "stroycity-1c")
+	}
+	if config.PriceType == "" {
+		config.PriceType = defaultCommerceMLPriceType
+	}
+	if config.DiscountPriceType == "" {
+		config.DiscountPriceType = defaultCommerceMLDiscountPriceType
+	}
+	return &CommerceMLService{itemRepo: itemRepo, items: items, config: config}
+}
+
+// cmlProduct — элемент Товар из import.xml
+type cmlProduct struct {
+	ID      string `xml:"Ид"`
+	Article string `xml:"Артикул"`
+}
+
+// cmlOffer — элемент Предложение из offers.xml
+type cmlOffer struct {
+	ID       string     `xml:"Ид"`
+	Article  string     `xml:"Артикул"`
+	Prices   []cmlPrice `xml:"Цены>Цена"`
+	Quantity string     `xml:"Количество"`
+	Stocks   []cmlStock `xml:"Склад"`
+	Rests    []string   `xml:"Остатки>Остаток>Количество"`
+}
+
+type cmlPrice struct {
+	PriceTypeID string `xml:"ИдТипаЦены"`
+	Value       string `xml:"ЦенаЗаЕдиницу"`
+}
+
+type cmlStock struct {
+	Quantity string `xml:"КоличествоНаСкладе,attr"`
+}
+
+type cmlPriceType struct {
+	ID   string `xml:"Ид"`
+	Name string `xml:"Наименование"`
+}
+
+// CommerceMLInit начинает новый сеанс обмена, удаляя файлы предыдущего
+func (s *CommerceMLService) CommerceMLInit(sellerID string) error {
+	dir := s.sellerDir(sellerID)
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	return os.MkdirAll(dir, 0o755)
+}
+
+// CommerceMLSaveFile дописывает очередную часть файла: 1С делит большие файлы на части по file_limit
+func (s *CommerceMLService) CommerceMLSaveFile(sellerID, filename string, data io.Reader) error {
+	path, err := s.filePath(sellerID, filename)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, data)
+	return err
+}
+
+// CommerceMLImport разбирает загруженный файл: import.xml связывает товары 1С с товарами продавца по артикулу,
+// offers.xml обновляет цены и остатки
+func (s *CommerceMLService) CommerceMLImport(sellerID, filename string) (model.CommerceMLResult, error) {
+	var result model.CommerceMLResult
+
+	path, err := s.filePath(sellerID, filename)
+	if err != nil {
+		return result, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return result, fmt.Errorf("%w: %s was not uploaded", ErrInvalidExchangeFile, filename)
+	}
+	defer file.Close()
+
+	decoder := xml.NewDecoder(file)
+	// Старые конфигурации 1С выгружают файлы в windows-1251
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		if strings.EqualFold(charset, "windows-1251") {
+			return charmap.Windows1251.NewDecoder().Reader(input), nil
+		}
+		return nil, fmt.Errorf("unsupported charset %s", charset)
+	}
+
+	change := model.StockChange{Type: model.MovementTypeImport, ActorID: sellerID, Reason: "1C exchange: " + filepath.Base(path)}
+	priceTypes := map[string]string{}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return result, fmt.Errorf("%w: %v", ErrInvalidExchangeFile, err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		// Элементы разбираются по одному, чтобы не держать в памяти весь каталог
+		switch start.Name.Local {
+		case "ТипЦены":
+			var priceType cmlPriceType
+			if err := decoder.DecodeElement(&priceType, &start); err != nil {
+				return result, fmt.Errorf("%w: %v", ErrInvalidExchangeFile, err)
+			}
+			priceTypes[priceType.ID] = priceType.Name
+		case "Товар":
+			var product cmlProduct
+			if err := decoder.DecodeElement(&product, &start); err != nil {
+				return result, fmt.Errorf("%w: %v", ErrInvalidExchangeFile, err)
+			}
+			s.linkProduct(sellerID, product, change, &result)
+		case "Предложение":
+			var offer cmlOffer
+			if err := decoder.DecodeElement(&offer, &start); err != nil {
+				return result, fmt.Errorf("%w: %v", ErrInvalidExchangeFile, err)
+			}
+			s.applyOffer(sellerID, offer, priceTypes, change, &result)
+		}
+	}
+
+	return result, nil
+}
+
+// linkProduct запоминает Ид товара 1С у товара продавца с тем же артикулом
+func (s *CommerceMLService) linkProduct(sellerID string, product cmlProduct, change model.StockChange, result *model.CommerceMLResult) {
+	article := strings.TrimSpace(product.Article)
+	if article == "" {
+		result.NotFound++
+		return
+	}
+
+	item, err := s.itemRepo.GetItemByArticle(sellerID, article)
+	if err != nil {
+		addCommerceMLError(result, article, err)
+		return
+	}
+	if item.ID == 0 {
+		result.NotFound++
+		return
+	}
+	if item.ExternalID == product.ID {
+		result.Linked++
+		return
+	}
+
+	item.ExternalID = product.ID
+	if err := s.items.UpdateItem(item, change); err != nil {
+		addCommerceMLError(result, article, err)
+		return
+	}
+	result.Linked++
+}
+
+// applyOffer обновляет Price, PriceWithDiscount и Quantity товара по предложению.
+// Товар ищется по артикулу, а если его нет в предложении — по Ид из import.xml.
+func (s *CommerceMLService) applyOffer(sellerID string, offer cmlOffer, priceTypes map[string]string, change model.StockChange, result *model.CommerceMLResult) {
+	article := strings.TrimSpace(offer.Article)
+
+	var item model.Item
+	var err error
+	if article != "" {
+		item, err = s.itemRepo.GetItemByArticle(sellerID, article)
+	} else {
+		// Ид характеристики приходит в виде "ИдТовара#ИдХарактеристики"
+		productID, _, _ := strings.Cut(offer.ID, "#")
+		item, err = s.itemRepo.GetItemByExternalID(sellerID, productID)
+	}
+	if err != nil {
+		addCommerceMLError(result, offer.ID, err)
+		return
+	}
+	if item.ID == 0 {
+		result.NotFound++
+		return
+	}
+
+	var price, discountPrice *model.Money
+	for i, offerPrice := range offer.Prices {
+		value, err := model.ParseMoney(offerPrice.Value)
+		if err != nil {
+			addCommerceMLError(result, offer.ID, err)
+			return
+		}
+		switch name := priceTypes[offerPrice.PriceTypeID]; {
+		case strings.EqualFold(name, s.config.PriceType):
+			price = &value
+		case strings.EqualFold(name, s.config.DiscountPriceType):
+			discountPrice = &value
+		case i == 0 && price == nil:
+			// Если типы цен не совпали с настройками, основной считается первая цена
+			price = &value
+		}
+	}
+	if price != nil {
+		item.Price = *price
+		// Без цены со скидкой она сбрасывается и при сохранении приравнивается к основной
+		item.PriceWithDiscount = 0
+	}
+	if discountPrice != nil {
+		item.PriceWithDiscount = *discountPrice
+	}
+
+	if quantity, ok := offerQuantity(offer); ok {
+		item.Quantity = quantity
+	}
+
+	if err := s.items.UpdateItem(item, change); err != nil {
+		addCommerceMLError(result, offer.ID, err)
+		return
+	}
+	result.Updated++
+}
+
+// offerQuantity возвращает остаток: общий, иначе сумму по складам
+func offerQuantity(offer cmlOffer) (int, bool) {
+	values := []string{offer.Quantity}
+	if strings.TrimSpace(offer.Quantity) == "" {
+		values = offer.Rests
+		for _, stock := range offer.Stocks {
+			values = append(values, stock.Quantity)
+		}
+	}
+
+	total, found := 0.0, false
+	for _, value := range values {
+		quantity, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(value), ",", "."), 64)
+		if err != nil {
+			continue
+		}
+		total += quantity
+		found = true
+	}
+	if !found {
+		return 0, false
+	}
+	// Дробные и отрицательные остатки 1С приводим к целому количеству в наличии
+	return int(math.Max(0, math.Floor(total))), true
+}
+
+func addCommerceMLError(result *model.CommerceMLResult, id string, err error) {
+	result.Failed++
+	if len(result.Errors) < maxCommerceMLErrors {
+		result.Errors = append(result.Errors, fmt.Sprintf("%s: %s", id, err.Error()))
+	}
+}
+
+func (s *CommerceMLService) sellerDir(sellerID string) string {
+	return filepath.Join(s.config.ExchangeDir, filepath.Base(sellerID))
+}
+
+// filePath возвращает путь к файлу обмена внутри каталога продавца, не допуская выхода за его пределы
+func (s *CommerceMLService) filePath(sellerID, filename string) (string, error) {
+	name := filepath.Clean("/" + strings.ReplaceAll(filename, "\\", "/"))
+	if name == "/" {
+		return "", fmt.Errorf("%w: filename is required", ErrInvalidExchangeFile)
+	}
+	return filepath.Join(s.sellerDir(sellerID), name), nil
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+	"stroycity/pkg/storage"
+)
+
+var (
+	// ErrVisitorRequired возвращается, если гость не передал токен устройства
+	ErrVisitorRequired          = errors.New("device token is required for guests")
+	ErrComparisonFull           = errors.New("comparison list of the category is full")
+	ErrComparisonItemNotFound   = errors.New("item is not in the comparison list")
+	ErrComparisonCategoryNeeded = errors.New("category_id is required")
+)
+
+// comparisonField — строка таблицы сравнения, значение которой берётся из карточки товара
+type comparisonField struct {
+	key   string
+	label string
+	group string
+	value func(item model.CurrentItemInfo) string
+}
+
+var comparisonFields = []comparisonField{
+	{"price", "Цена", model.ComparisonGroupMain, func(item model.CurrentItemInfo) string {
+		return comparisonMoney(item.Price, item.Currency)
+	}},
+	{"price_with_discount", "Цена со скидкой", model.ComparisonGroupMain, func(item model.CurrentItemInfo) string {
+		return comparisonMoney(item.PriceWithDiscount, item.Currency)
+	}},
+	{"price_with_discount_per_base_unit", "Цена за базовую единицу", model.ComparisonGroupMain, func(item model.CurrentItemInfo) string {
+		if item.BaseUnit == "" {
+			return ""
+		}
+		return comparisonMoney(item.PriceWithDiscountPerBaseUnit, item.Currency) + "/" + item.BaseUnit
+	}},
+	{"available", "В наличии", model.ComparisonGroupMain, func(item model.CurrentItemInfo) string {
+		return strconv.Itoa(item.Available)
+	}},
+	{"pickup_available", "Самовывоз", model.ComparisonGroupMain, func(item model.CurrentItemInfo) string {
+		return comparisonBool(item.PickupAvailable)
+	}},
+	{"brand", "Бренд", model.ComparisonGroupMain, func(item model.CurrentItemInfo) string { return item.Brand }},
+	{"material", "Материал", model.ComparisonGroupMain, func(item model.CurrentItemInfo) string { return item.Material }},
+	{"seller", "Продавец", model.ComparisonGroupMain, func(item model.CurrentItemInfo) string { return item.Seller }},
+	{"article", "Артикул", model.ComparisonGroupMain, func(item model.CurrentItemInfo) string { return item.Article }},
+	{"unit", "Единица продажи", model.ComparisonGroupMain, func(item model.CurrentItemInfo) string { return item.Unit }},
+	{"min_quantity", "Минимальный заказ", model.ComparisonGroupMain, func(item model.CurrentItemInfo) string {
+		return strconv.Itoa(item.MinQuantity)
+	}},
+	{"quantity_step", "Шаг количества", model.ComparisonGroupMain, func(item model.CurrentItemInfo) string {
+		return strconv.Itoa(item.QuantityStep)
+	}},
+	{"length", "Длина", model.ComparisonGroupDimensions, func(item model.CurrentItemInfo) string { return comparisonSize(item.Length) }},
+	{"width", "Ширина", model.ComparisonGroupDimensions, func(item model.CurrentItemInfo) string { return comparisonSize(item.Width) }},
+	{"height", "Высота", model.ComparisonGroupDimensions, func(item model.CurrentItemInfo) string { return comparisonSize(item.Height) }},
+	{"weight", "Вес", model.ComparisonGroupDimensions, func(item model.CurrentItemInfo) string { return comparisonSize(item.Weight) }},
+}
+
+type ComparisonService struct {
+	repo     repository.Comparison
+	itemRepo repository.Item
+	items    Item
+	storage  storage.BlobStorage
+}
+
+func NewComparisonService(repo repository.Comparison, itemRepo repository.Item, items Item, storage storage.BlobStorage) *ComparisonService {
+	return &ComparisonService{repo: repo, itemRepo: itemRepo, items: items, storage: storage}
+}
+
+// AddToComparison добавляет опубликованный товар в список сравнения его категории.
+// Списки покупателя и гостя ведутся раздельно: гость определяется токеном устройства.
+func (s *ComparisonService) AddToComparison(buyerID, deviceToken string, itemID int) error {
+	buyerID, deviceToken, err := visitor(buyerID, deviceToken)
+	if err != nil {
+		return err
+	}
+
+	item, err := s.itemRepo.GetItemById(itemID)
+	if err != nil || item.Status != model.ItemStatusPublished {
+		return ErrItemNotFound
+	}
+
+	compared, err := s.repo.GetComparisonItems(buyerID, deviceToken, item.CategoryID)
+	if err != nil {
+		return err
+	}
+	for _, comparisonItem := range compared {
+		if comparisonItem.ItemID == itemID {
+			return nil
+		}
+	}
+	if len(compared) >= model.MaxComparisonItems {
+		return ErrComparisonFull
+	}
+
+	return s.repo.AddComparisonItem(model.ComparisonItem{BuyerID: buyerID, DeviceToken: deviceToken, ItemID: itemID})
+}
+
+func (s *ComparisonService) RemoveFromComparison(buyerID, deviceToken string, itemID int) error {
+	buyerID, deviceToken, err := visitor(buyerID, deviceToken)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.repo.RemoveComparisonItem(buyerID, deviceToken, itemID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrComparisonItemNotFound
+	}
+	return nil
+}
+
+// ClearComparison очищает список сравнения категории или, при categoryID 0, все списки
+func (s *ComparisonService) ClearComparison(buyerID, deviceToken string, categoryID int) error {
+	buyerID, deviceToken, err := visitor(buyerID, deviceToken)
+	if err != nil {
+		return err
+	}
+	return s.repo.ClearComparison(buyerID, deviceToken, categoryID)
+}
+
+// GetComparison возвращает списки сравнения по категориям в порядке добавления первого товара категории
+func (s *ComparisonService) GetComparison(buyerID, deviceToken string) ([]model.ComparisonCategory, error) {
+	buyerID, deviceToken, err := visitor(buyerID, deviceToken)
+	if err != nil {
+		return nil, err
+	}
+
+	compared, err := s.repo.GetComparisonItems(buyerID, deviceToken, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := []model.ComparisonCategory{}
+	positions := map[int]int{}
+	for _, comparisonItem := range compared {
+		item := comparisonItem.Item
+		position, ok := positions[item.CategoryID]
+		if !ok {
+			position = len(categories)
+			positions[item.CategoryID] = position
+			categories = append(categories, model.ComparisonCategory{CategoryID: item.CategoryID, Category: item.Category.Name})
+		}
+		categories[position].Items = append(categories[position].Items, model.ConvertItemsToItemInfo([]model.Item{item}, s.storage.URL)...)
+	}
+	return categories, nil
+}
+
+// GetComparisonTable строит таблицу сравнения товаров категории: основные поля карточки, габариты
+// и характеристики категории. Строки, где значения товаров различаются, отмечены differs;
+// при onlyDifferences остальные строки не возвращаются.
+func (s *ComparisonService) GetComparisonTable(buyerID, deviceToken string, categoryID int, onlyDifferences bool) (model.ComparisonTable, error) {
+	buyerID, deviceToken, err := visitor(buyerID, deviceToken)
+	if err != nil {
+		return model.ComparisonTable{}, err
+	}
+	if categoryID == 0 {
+		return model.ComparisonTable{}, ErrComparisonCategoryNeeded
+	}
+
+	compared, err := s.repo.GetComparisonItems(buyerID, deviceToken, categoryID)
+	if err != nil {
+		return model.ComparisonTable{}, err
+	}
+
+	table := model.ComparisonTable{CategoryID: categoryID, Items: []model.ItemInfo{}, Rows: []model.ComparisonRow{}}
+	cards := make([]model.CurrentItemInfo, 0, len(compared))
+	for _, comparisonItem := range compared {
+		card, err := s.items.GetItemById(comparisonItem.ItemID)
+		if err != nil {
+			return model.ComparisonTable{}, err
+		}
+		cards = append(cards, card)
+
+		info := model.ConvertItemsToItemInfo([]model.Item{comparisonItem.Item}, s.storage.URL)[0]
+		info.Available = card.Available
+		table.Items = append(table.Items, info)
+		table.Category = comparisonItem.Item.Category.Name
+	}
+
+	for _, field := range comparisonFields {
+		values := make([]string, len(cards))
+		for i, card := range cards {
+			values[i] = field.value(card)
+		}
+		table.Rows = appendComparisonRow(table.Rows, model.ComparisonRow{Key: field.key, Label: field.label, Group: field.group, Values: values}, onlyDifferences)
+	}
+
+	// Характеристики — в порядке первого появления; у товара без характеристики значение пустое
+	var attributeRows []model.ComparisonRow
+	positions := map[int]int{}
+	for i, card := range cards {
+		for _, attribute := range card.Attributes {
+			position, ok := positions[attribute.AttributeID]
+			if !ok {
+				position = len(attributeRows)
+				positions[attribute.AttributeID] = position
+				label := attribute.Name
+				if attribute.Unit != "" {
+					label += ", " + attribute.Unit
+				}
+				attributeRows = append(attributeRows, model.ComparisonRow{
+					Key:    fmt.Sprintf("attribute:%d", attribute.AttributeID),
+					Label:  label,
+					Group:  model.ComparisonGroupAttributes,
+					Values: make([]string, len(cards)),
+				})
+			}
+			attributeRows[position].Values[i] = comparisonAttribute(attribute)
+		}
+	}
+	for _, row := range attributeRows {
+		table.Rows = appendComparisonRow(table.Rows, row, onlyDifferences)
+	}
+
+	return table, nil
+}
+
+// appendComparisonRow отмечает, различаются ли значения строки, и добавляет её в таблицу
+func appendComparisonRow(rows []model.ComparisonRow, row model.ComparisonRow, onlyDifferences bool) []model.ComparisonRow {
+	for _, value := range row.Values {
+		if value != row.Values[0] {
+			row.Differs = true
+			break
+		}
+	}
+	if onlyDifferences && !row.Differs {
+		return rows
+	}
+	return append(rows, row)
+}
+
+func comparisonMoney(amount model.Money, currency string) string {
+	return amount.String() + " " + currency
+}
+
+func comparisonSize(size int) string {
+	if size == 0 {
+		return ""
+	}
+	return strconv.Itoa(size)
+}
+
+func comparisonBool(value bool) string {
+	if value {
+		return "да"
+	}
+	return "нет"
+}
+
+func comparisonAttribute(attribute model.ItemAttributeInfo) string {
+	switch {
+	case attribute.NumberValue != nil:
+		return strconv.FormatFloat(*attribute.NumberValue, 'f', -1, 64)
+	case attribute.BoolValue != nil:
+		return comparisonBool(*attribute.BoolValue)
+	default:
+		return attribute.EnumValue
+	}
+}
+
+// visitor определяет владельца личных списков: покупателя, а без авторизации — устройство гостя
+func visitor(buyerID, deviceToken string) (string, string, error) {
+	if buyerID != "" {
+		return buyerID, "", nil
+	}
+	if deviceToken == "" {
+		return "", "", ErrVisitorRequired
+	}
+	return "", deviceToken, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"stroycity/pkg/model"
+)
+
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrBrandNotFound    = errors.New("brand not found")
+	ErrMaterialNotFound = errors.New("material not found")
+	// ErrNameRequired возвращается при сохранении записи справочника без названия
+	ErrNameRequired = errors.New("name is required")
+	// ErrReferencedByItems возвращается при удалении записи справочника, на которую ссылаются товары
+	ErrReferencedByItems = errors.New("record is used by items, reassign them to another record")
+	// ErrReferencedByBundles возвращается при удалении категории, на которую ссылаются комплекты
+	ErrReferencedByBundles = errors.New("category is used by bundles, reassign them to another category")
+	// ErrInvalidMerge возвращается, если список дубликатов пуст или содержит каноническую запись
+	ErrInvalidMerge = errors.New("invalid merge")
+)
+
+// dictionaryName проверяет и нормализует название записи справочника
+func dictionaryName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrNameRequired
+	}
+	return name, nil
+}
+
+// mergeSources проверяет запрос на объединение и возвращает дубликаты без повторов
+func mergeSources(input model.MergeInput) ([]int, error) {
+	if input.TargetID == 0 {
+		return nil, fmt.Errorf("%w: target_id is required", ErrInvalidMerge)
+	}
+
+	seen := map[int]bool{}
+	var sourceIDs []int
+	for _, id := range input.SourceIDs {
+		if id == input.TargetID {
+			return nil, fmt.Errorf("%w: source_ids must not contain target_id", ErrInvalidMerge)
+		}
+		if !seen[id] {
+			seen[id] = true
+			sourceIDs = append(sourceIDs, id)
+		}
+	}
+	if len(sourceIDs) == 0 {
+		return nil, fmt.Errorf("%w: source_ids are required", ErrInvalidMerge)
+	}
+	return sourceIDs, nil
+}
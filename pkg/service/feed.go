@@ -0,0 +1,369 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"sort"
+	"strconv"
+	"strings"
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+	"stroycity/pkg/storage"
+	"sync"
+	"time"
+)
+
+const defaultFeedRefreshInterval = time.Hour
+
+var (
+	// ErrUnknownFeedFormat возвращается для неподдерживаемого формата выгрузки
+	ErrUnknownFeedFormat = errors.New("unknown feed format")
+	// ErrFeedSellerNotFound возвращается при запросе выгрузки несуществующего продавца
+	ErrFeedSellerNotFound = errors.New("seller not found")
+)
+
+// FeedConfig — параметры выгрузок каталога
+type FeedConfig struct {
+	ShopName        string
+	Company         string
+	SiteURL         string
+	RefreshInterval time.Duration
+}
+
+type FeedService struct {
+	itemRepo     repository.Item
+	categoryRepo repository.Category
+	sellerRepo   repository.Seller
+	storage      storage.BlobStorage
+	config       FeedConfig
+
+	mu    sync.Mutex
+	feeds map[feedKey]cachedFeed
+}
+
+type feedKey struct {
+	Format   string
+	SellerID string
+}
+
+type cachedFeed struct {
+	Feed        model.Feed
+	GeneratedAt time.Time
+}
+
+func NewFeedService(itemRepo repository.Item, categoryRepo repository.Category, sellerRepo repository.Seller, storage storage.BlobStorage, config FeedConfig) *FeedService {
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = defaultFeedRefreshInterval
+	}
+	config.SiteURL = strings.TrimRight(config.SiteURL, "/")
+	return &FeedService{
+		itemRepo:     itemRepo,
+		categoryRepo: categoryRepo,
+		sellerRepo:   sellerRepo,
+		storage:      storage,
+		config:       config,
+		feeds:        map[feedKey]cachedFeed{},
+	}
+}
+
+// GetFeed возвращает выгрузку из кэша; устаревшая или отсутствующая выгрузка генерируется заново.
+// Пустой sellerID означает выгрузку всей площадки.
+func (s *FeedService) GetFeed(format, sellerID string) (model.Feed, error) {
+	key := feedKey{Format: format, SellerID: sellerID}
+
+	s.mu.Lock()
+	cached, ok := s.feeds[key]
+	s.mu.Unlock()
+	if ok && time.Since(cached.GeneratedAt) < s.config.RefreshInterval {
+		return cached.Feed, nil
+	}
+
+	return s.refreshFeed(key)
+}
+
+// RunFeedRefresher периодически перегенерирует выгрузку площадки и все ранее запрошенные выгрузки продавцов
+func (s *FeedService) RunFeedRefresher(ctx context.Context) {
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		keys := []feedKey{{Format: model.FeedFormatYML}, {Format: model.FeedFormatCSV}}
+		s.mu.Lock()
+		for key := range s.feeds {
+			if key.SellerID != "" {
+				keys = append(keys, key)
+			}
+		}
+		s.mu.Unlock()
+
+		for _, key := range keys {
+			if _, err := s.refreshFeed(key); err != nil {
+				logrus.Errorf("failed to refresh %s feed for seller %q: %s", key.Format, key.SellerID, err.Error())
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *FeedService) refreshFeed(key feedKey) (model.Feed, error) {
+	var shopName string
+	if key.SellerID != "" {
+		seller, err := s.sellerRepo.FindSeller(key.SellerID)
+		if err != nil {
+			return model.Feed{}, err
+		}
+		if seller.ID == "" {
+			return model.Feed{}, ErrFeedSellerNotFound
+		}
+		shopName = seller.ShopName
+	}
+
+	items, err := s.itemRepo.GetItemsForFeed(key.SellerID)
+	if err != nil {
+		return model.Feed{}, err
+	}
+
+	var build func(pictures func(model.Item) []string) ([]byte, error)
+	var contentType string
+	switch key.Format {
+	case model.FeedFormatYML:
+		categories, err := s.categoryRepo.GetCategoryList()
+		if err != nil {
+			return model.Feed{}, err
+		}
+		build = func(pictures func(model.Item) []string) ([]byte, error) {
+			return s.buildYMLShop(shopName, categories, items, pictures)
+		}
+		contentType = "application/xml; charset=utf-8"
+	case model.FeedFormatCSV:
+		build = func(pictures func(model.Item) []string) ([]byte, error) {
+			return s.buildCSVFeed(items, pictures)
+		}
+		contentType = "text/csv; charset=utf-8"
+	default:
+		return model.Feed{}, ErrUnknownFeedFormat
+	}
+
+	body, err := build(s.itemPictures)
+	if err != nil {
+		return model.Feed{}, err
+	}
+	// ETag и Last-Modified меняются только при изменении данных, а не при каждой перегенерации.
+	// Ссылки на изображения в S3 подписываются заново при каждой генерации, поэтому ETag считается
+	// по той же выгрузке, но с ключами хранилища вместо ссылок.
+	fingerprint, err := build(itemPictureKeys)
+	if err != nil {
+		return model.Feed{}, err
+	}
+	sum := sha256.Sum256(fingerprint)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	now := time.Now().UTC().Truncate(time.Second)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	modifiedAt := now
+	if previous, ok := s.feeds[key]; ok && previous.Feed.ETag == etag {
+		modifiedAt = previous.Feed.ModifiedAt
+	}
+
+	data := body
+	if key.Format == model.FeedFormatYML {
+		data = wrapYMLCatalog(body, modifiedAt)
+	}
+
+	feed := model.Feed{Data: data, ContentType: contentType, ETag: etag, ModifiedAt: modifiedAt}
+	s.feeds[key] = cachedFeed{Feed: feed, GeneratedAt: now}
+	return feed, nil
+}
+
+type ymlShop struct {
+	XMLName    xml.Name      `xml:"shop"`
+	Name       string        `xml:"name"`
+	Company    string        `xml:"company"`
+	URL        string        `xml:"url"`
+	Currencies []ymlCurrency `xml:"currencies>currency"`
+	Categories []ymlCategory `xml:"categories>category"`
+	Offers     []ymlOffer    `xml:"offers>offer"`
+}
+
+type ymlCurrency struct {
+	ID   string `xml:"id,attr"`
+	Rate string `xml:"rate,attr"`
+}
+
+type ymlCategory struct {
+	ID   int    `xml:"id,attr"`
+	Name string `xml:",chardata"`
+}
+
+type ymlOffer struct {
+	ID          int      `xml:"id,attr"`
+	Available   bool     `xml:"available,attr"`
+	URL         string   `xml:"url"`
+	Price       string   `xml:"price"`
+	OldPrice    string   `xml:"oldprice,omitempty"`
+	CurrencyID  string   `xml:"currencyId"`
+	CategoryID  int      `xml:"categoryId"`
+	Pictures    []string `xml:"picture"`
+	Name        string   `xml:"name"`
+	Vendor      string   `xml:"vendor,omitempty"`
+	VendorCode  string   `xml:"vendorCode,omitempty"`
+	Description string   `xml:"description,omitempty"`
+	Count       int      `xml:"count"`
+}
+
+// buildYMLShop формирует элемент shop в формате Яндекс YML
+func (s *FeedService) buildYMLShop(shopName string, categories []model.Category, items []model.Item, pictures func(model.Item) []string) ([]byte, error) {
+	shop := ymlShop{
+		Name:    s.config.ShopName,
+		Company: s.config.Company,
+		URL:     s.config.SiteURL,
+	}
+	if shopName != "" {
+		shop.Name = shopName
+	}
+
+	currencies := map[string]bool{}
+	usedCategories := map[int]bool{}
+	for _, item := range items {
+		offer := ymlOffer{
+			ID:          item.ID,
+			Available:   item.Quantity > 0,
+			URL:         s.itemURL(item),
+			Price:       item.Price.String(),
+			CurrencyID:  item.Currency,
+			CategoryID:  item.CategoryID,
+			Name:        item.Name,
+			Vendor:      item.Brand.Name,
+			VendorCode:  item.Article,
+			Description: item.Description,
+			Count:       item.Quantity,
+		}
+		// Цена со скидкой выгружается как price, исходная — как oldprice
+		if item.PriceWithDiscount > 0 && item.PriceWithDiscount < item.Price {
+			offer.Price = item.PriceWithDiscount.String()
+			offer.OldPrice = item.Price.String()
+		}
+		offer.Pictures = pictures(item)
+
+		currencies[item.Currency] = true
+		usedCategories[item.CategoryID] = true
+		shop.Offers = append(shop.Offers, offer)
+	}
+
+	// Порядок должен быть стабильным, иначе ETag будет меняться без изменения данных
+	var currencyIDs []string
+	for currency := range currencies {
+		currencyIDs = append(currencyIDs, currency)
+	}
+	sort.Strings(currencyIDs)
+	for _, currency := range currencyIDs {
+		shop.Currencies = append(shop.Currencies, ymlCurrency{ID: currency, Rate: "1"})
+	}
+	if len(shop.Currencies) == 0 {
+		shop.Currencies = append(shop.Currencies, ymlCurrency{ID: model.DefaultCurrency, Rate: "1"})
+	}
+	for _, category := range categories {
+		if usedCategories[category.ID] {
+			shop.Categories = append(shop.Categories, ymlCategory{ID: category.ID, Name: category.Name})
+		}
+	}
+	sort.Slice(shop.Categories, func(i, j int) bool { return shop.Categories[i].ID < shop.Categories[j].ID })
+
+	return xml.MarshalIndent(shop, "  ", "  ")
+}
+
+func wrapYMLCatalog(shop []byte, date time.Time) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	fmt.Fprintf(&buf, "<yml_catalog date=%q>\n", date.Format(time.RFC3339))
+	buf.Write(shop)
+	buf.WriteString("\n</yml_catalog>\n")
+	return buf.Bytes()
+}
+
+// buildCSVFeed формирует CSV с колонками, совместимыми с импортом каталога
+func (s *FeedService) buildCSVFeed(items []model.Item, pictures func(model.Item) []string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(csvBOM)
+	writer := csv.NewWriter(&buf)
+	writer.Comma = ';'
+	_ = writer.Write([]string{
+		"id",
+		model.ImportFieldArticle,
+		model.ImportFieldName,
+		model.ImportFieldDescription,
+		model.ImportFieldPrice,
+		model.ImportFieldPriceWithDiscount,
+		model.ImportFieldCurrency,
+		model.ImportFieldQuantity,
+		model.ImportFieldUnit,
+		model.ImportFieldCategory,
+		model.ImportFieldBrand,
+		model.ImportFieldMaterial,
+		"url",
+		"images",
+	})
+	for _, item := range items {
+		_ = writer.Write([]string{
+			strconv.Itoa(item.ID),
+			item.Article,
+			item.Name,
+			item.Description,
+			item.Price.String(),
+			item.PriceWithDiscount.String(),
+			item.Currency,
+			strconv.Itoa(item.Quantity),
+			item.Unit,
+			item.Category.Name,
+			item.Brand.Name,
+			item.Material.Name,
+			s.itemURL(item),
+			strings.Join(pictures(item), " "),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *FeedService) itemURL(item model.Item) string {
+	return s.config.SiteURL + model.ItemPath(item)
+}
+
+// itemPictures возвращает абсолютные ссылки на изображения товара, основное — первым
+func (s *FeedService) itemPictures(item model.Item) []string {
+	var pictures []string
+	for _, image := range model.SortImages(item.Images) {
+		url := s.storage.URL(image.StorageKey)
+		if strings.HasPrefix(url, "/") {
+			url = s.config.SiteURL + url
+		}
+		pictures = append(pictures, url)
+	}
+	return pictures
+}
+
+// itemPictureKeys возвращает ключи изображений товара в хранилище в том же порядке, что и itemPictures
+func itemPictureKeys(item model.Item) []string {
+	var keys []string
+	for _, image := range model.SortImages(item.Images) {
+		keys = append(keys, image.StorageKey)
+	}
+	return keys
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+const (
+	maxImageSize       = 10 << 20 // 10 МБ
+	maxImageDimension  = 8000
+	maxImagesPerUpload = 10
+	jpegQuality        = 85
+
+	imageFormatJPEG = "jpeg"
+	imageFormatPNG  = "png"
+	imageFormatWebP = "webp"
+)
+
+// ErrInvalidImage возвращается, если загруженный файл не прошёл проверку
+var ErrInvalidImage = errors.New("invalid image")
+
+var (
+	// ErrImageNotFound возвращается, если изображение не принадлежит товару
+	ErrImageNotFound = errors.New("image not found")
+	// ErrInvalidImageOrder возвращается, если новый порядок не совпадает с набором изображений товара
+	ErrInvalidImageOrder = errors.New("image order must list every image of the item exactly once")
+)
+
+// imageRenditionSizes — размеры генерируемых копий по большей стороне
+var imageRenditionSizes = []struct {
+	Name    string
+	MaxSide int
+}{
+	{"thumbnail", 200},
+	{"medium", 800},
+	{"large", 1600},
+}
+
+type processedRendition struct {
+	Name        string
+	Data        []byte
+	Ext         string
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// processImage проверяет изображение по сигнатуре, размеру и разрешению,
+// поворачивает по EXIF-ориентации и перекодирует в набор копий.
+// Перекодирование удаляет EXIF и прочие метаданные исходного файла.
+func processImage(r io.Reader) ([]processedRendition, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %v", err)
+	}
+	if len(data) > maxImageSize {
+		return nil, fmt.Errorf("%w: file is larger than %d MB", ErrInvalidImage, maxImageSize>>20)
+	}
+
+	format := detectImageFormat(data)
+	if format == "" {
+		return nil, fmt.Errorf("%w: only JPEG, PNG and WebP are supported", ErrInvalidImage)
+	}
+
+	// Проверяем разрешение до полного декодирования, чтобы не распаковывать гигантские файлы
+	config, err := decodeImageConfig(format, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if config.Width > maxImageDimension || config.Height > maxImageDimension {
+		return nil, fmt.Errorf("%w: resolution exceeds %dx%d", ErrInvalidImage, maxImageDimension, maxImageDimension)
+	}
+
+	src, err := decodeImage(format, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if format == imageFormatJPEG {
+		src = applyOrientation(src, jpegOrientation(data))
+	}
+
+	// PNG и изображения с прозрачностью сохраняем в PNG, остальные — в JPEG
+	outputFormat := imageFormatJPEG
+	if opaque, ok := src.(interface{ Opaque() bool }); format == imageFormatPNG || (ok && !opaque.Opaque()) {
+		outputFormat = imageFormatPNG
+	}
+
+	var renditions []processedRendition
+	for _, size := range imageRenditionSizes {
+		resized := resizeToFit(src, size.MaxSide)
+
+		var buf bytes.Buffer
+		ext, contentType := ".jpg", "image/jpeg"
+		if outputFormat == imageFormatPNG {
+			ext, contentType = ".png", "image/png"
+			err = png.Encode(&buf, resized)
+		} else {
+			err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: jpegQuality})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode image: %v", err)
+		}
+
+		renditions = append(renditions, processedRendition{
+			Name:        size.Name,
+			Data:        buf.Bytes(),
+			Ext:         ext,
+			ContentType: contentType,
+			Width:       resized.Bounds().Dx(),
+			Height:      resized.Bounds().Dy(),
+		})
+	}
+
+	return renditions, nil
+}
+
+// detectImageFormat определяет формат по сигнатуре файла, а не по расширению
+func detectImageFormat(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return imageFormatJPEG
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return imageFormatPNG
+	case len(data) >= 12 && bytes.Equal(data[0:4], []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WEBP")):
+		return imageFormatWebP
+	}
+	return ""
+}
+
+func decodeImageConfig(format string, data []byte) (image.Config, error) {
+	switch format {
+	case imageFormatJPEG:
+		return jpeg.DecodeConfig(bytes.NewReader(data))
+	case imageFormatPNG:
+		return png.DecodeConfig(bytes.NewReader(data))
+	default:
+		return webp.DecodeConfig(bytes.NewReader(data))
+	}
+}
+
+func decodeImage(format string, data []byte) (image.Image, error) {
+	switch format {
+	case imageFormatJPEG:
+		return jpeg.Decode(bytes.NewReader(data))
+	case imageFormatPNG:
+		return png.Decode(bytes.NewReader(data))
+	default:
+		return webp.Decode(bytes.NewReader(data))
+	}
+}
+
+// resizeToFit уменьшает изображение так, чтобы большая сторона не превышала maxSide.
+// Маленькие изображения не увеличиваются.
+func resizeToFit(src image.Image, maxSide int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width > maxSide || height > maxSide {
+		if width >= height {
+			height = max(1, height*maxSide/width)
+			width = maxSide
+		} else {
+			width = max(1, width*maxSide/height)
+			height = maxSide
+		}
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+	return dst
+}
+
+// jpegOrientation читает тег Orientation (0x0112) из EXIF-блока JPEG.
+// Возвращает 1, если тег отсутствует или не читается.
+func jpegOrientation(data []byte) int {
+	offset := 2
+	for offset+4 <= len(data) {
+		if data[offset] != 0xFF {
+			return 1
+		}
+		marker := data[offset+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		if length < 2 || offset+2+length > len(data) {
+			return 1
+		}
+		segment := data[offset+4 : offset+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		offset += 2 + length
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[0:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+	return 1
+}
+
+// applyOrientation поворачивает и отражает изображение согласно EXIF-ориентации
+func applyOrientation(src image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = width-1-x, y
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dx, dy = x, height-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = height-1-y, x
+			case 7:
+				dx, dy = height-1-y, width-1-x
+			case 8:
+				dx, dy = y, width-1-x
+			}
+			dst.Set(dx, dy, src.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,511 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+	"time"
+)
+
+const (
+	maxImportFileSize = 20 << 20 // 20 МБ
+	maxImportRows     = 50000
+	// importProgressBatch — через сколько строк сохранять прогресс задачи
+	importProgressBatch = 50
+	// csvBOM нужен, чтобы Excel открыл UTF-8 файл с кириллицей без искажений
+	csvBOM = "\ufeff"
+)
+
+var (
+	// ErrInvalidImport возвращается, если файл импорта не удалось разобрать или сопоставить колонки
+	ErrInvalidImport = errors.New("invalid import file")
+	// ErrImportJobNotFound возвращается, если задача не найдена или принадлежит другому продавцу
+	ErrImportJobNotFound = errors.New("import job not found")
+)
+
+// importDigitSeparators убирает пробелы-разделители разрядов, которые Excel подставляет в числа ("1 200,50")
+var importDigitSeparators = strings.NewReplacer(" ", "", "\u00a0", "")
+
+// importFieldAliases — названия колонок, которые распознаются без явного сопоставления
+var importFieldAliases = map[string][]string{
+	model.ImportFieldArticle:           {"артикул", "sku"},
+	model.ImportFieldName:              {"название", "наименование"},
+	model.ImportFieldDescription:       {"описание"},
+	model.ImportFieldPrice:             {"цена"},
+	model.ImportFieldPriceWithDiscount: {"цена со скидкой"},
+	model.ImportFieldCurrency:          {"валюта"},
+	model.ImportFieldQuantity:          {"количество", "остаток"},
+	model.ImportFieldLength:            {"длина"},
+	model.ImportFieldWidth:             {"ширина"},
+	model.ImportFieldHeight:            {"высота"},
+	model.ImportFieldWeight:            {"вес"},
+	model.ImportFieldUnit:              {"единица измерения", "ед. изм."},
+	model.ImportFieldBaseUnit:          {"базовая единица"},
+	model.ImportFieldUnitRatio:         {"коэффициент"},
+	model.ImportFieldMinQuantity:       {"минимальный заказ"},
+	model.ImportFieldQuantityStep:      {"шаг заказа"},
+	model.ImportFieldCategory:          {"категория"},
+	model.ImportFieldBrand:             {"бренд"},
+	model.ImportFieldMaterial:          {"материал"},
+}
+
+type ImportService struct {
+	repo         repository.Import
+	itemRepo     repository.Item
+	categoryRepo repository.Category
+	brandRepo    repository.Brand
+	materialRepo repository.Material
+	items        Item
+}
+
+func NewImportService(repo repository.Import, itemRepo repository.Item, categoryRepo repository.Category, brandRepo repository.Brand, materialRepo repository.Material, items Item) *ImportService {
+	return &ImportService{repo: repo, itemRepo: itemRepo, categoryRepo: categoryRepo, brandRepo: brandRepo, materialRepo: materialRepo, items: items}
+}
+
+// importRow — строка данных файла с номером строки в исходном файле
+type importRow struct {
+	Number int
+	Cells  []string
+}
+
+// importLookups — справочники для поиска категории, бренда и материала по названию
+type importLookups struct {
+	categories map[string]int
+	brands     map[string]int
+	materials  map[string]int
+}
+
+// StartImport разбирает файл, сопоставляет колонки и запускает обработку строк в фоне.
+// mapping задаёт соответствие поля ItemInput названию колонки и дополняет автоматическое распознавание.
+func (s *ImportService) StartImport(sellerID string, fileHeader *multipart.FileHeader, mapping map[string]string) (int, error) {
+	if fileHeader.Size > maxImportFileSize {
+		return 0, fmt.Errorf("%w: file is larger than %d MB", ErrInvalidImport, maxImportFileSize>>20)
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxImportFileSize+1))
+	if err != nil {
+		return 0, err
+	}
+	if len(data) > maxImportFileSize {
+		return 0, fmt.Errorf("%w: file is larger than %d MB", ErrInvalidImport, maxImportFileSize>>20)
+	}
+
+	records, err := readImportRecords(fileHeader.Filename, data)
+	if err != nil {
+		return 0, err
+	}
+	if len(records) == 0 {
+		return 0, fmt.Errorf("%w: file is empty", ErrInvalidImport)
+	}
+
+	columns, err := resolveImportColumns(records[0], mapping)
+	if err != nil {
+		return 0, err
+	}
+
+	// Пустые строки пропускаем, но сохраняем исходную нумерацию для отчёта об ошибках
+	var rows []importRow
+	for i, cells := range records[1:] {
+		if isEmptyImportRow(cells) {
+			continue
+		}
+		rows = append(rows, importRow{Number: i + 2, Cells: cells})
+	}
+	if len(rows) > maxImportRows {
+		return 0, fmt.Errorf("%w: at most %d rows per file", ErrInvalidImport, maxImportRows)
+	}
+
+	job := model.ImportJob{
+		SellerID:  sellerID,
+		FileName:  fileHeader.Filename,
+		Status:    model.ImportStatusPending,
+		TotalRows: len(rows),
+	}
+	job.ID, err = s.repo.CreateImportJob(job)
+	if err != nil {
+		return 0, err
+	}
+
+	go s.runImport(job, rows, columns)
+
+	return job.ID, nil
+}
+
+func (s *ImportService) GetImportJob(sellerID string, jobID int) (model.ImportJobOutput, error) {
+	job, err := s.getSellerJob(sellerID, jobID)
+	if err != nil {
+		return model.ImportJobOutput{}, err
+	}
+	return model.ConvertImportJobToOutput(job), nil
+}
+
+// GetImportErrorReport формирует CSV-отчёт с ошибками строк задачи импорта
+func (s *ImportService) GetImportErrorReport(sellerID string, jobID int) ([]byte, error) {
+	if _, err := s.getSellerJob(sellerID, jobID); err != nil {
+		return nil, err
+	}
+	rowErrors, err := s.repo.GetImportErrors(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(csvBOM)
+	writer := csv.NewWriter(&buf)
+	writer.Comma = ';'
+	_ = writer.Write([]string{"row", "article", "error"})
+	for _, rowError := range rowErrors {
+		_ = writer.Write([]string{strconv.Itoa(rowError.Row), rowError.Article, rowError.Message})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *ImportService) getSellerJob(sellerID string, jobID int) (model.ImportJob, error) {
+	job, err := s.repo.GetImportJob(jobID)
+	if err != nil || job.SellerID != sellerID {
+		return model.ImportJob{}, ErrImportJobNotFound
+	}
+	return job, nil
+}
+
+// runImport обрабатывает строки по одной, периодически сохраняя прогресс и ошибки
+func (s *ImportService) runImport(job model.ImportJob, rows []importRow, columns map[string]int) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.finishImport(job, nil, fmt.Errorf("import aborted: %v", r))
+		}
+	}()
+
+	job.Status = model.ImportStatusProcessing
+	s.saveImportProgress(job, nil)
+
+	lookups, err := s.loadImportLookups()
+	if err != nil {
+		s.finishImport(job, nil, err)
+		return
+	}
+
+	change := model.StockChange{Type: model.MovementTypeImport, ActorID: job.SellerID, Reason: fmt.Sprintf("import #%d", job.ID)}
+	var pending []model.ImportRowError
+	for i, row := range rows {
+		created, err := s.importRow(job.SellerID, row.Cells, columns, lookups, change)
+		switch {
+		case err != nil:
+			job.FailedCount++
+			pending = append(pending, model.ImportRowError{
+				Row:     row.Number,
+				Article: importCell(row.Cells, columns, model.ImportFieldArticle),
+				Message: err.Error(),
+			})
+		case created:
+			job.CreatedCount++
+		default:
+			job.UpdatedCount++
+		}
+
+		job.ProcessedRows = i + 1
+		if job.ProcessedRows%importProgressBatch == 0 {
+			s.saveImportProgress(job, pending)
+			pending = nil
+		}
+	}
+
+	s.finishImport(job, pending, nil)
+}
+
+func (s *ImportService) finishImport(job model.ImportJob, pending []model.ImportRowError, err error) {
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Status = model.ImportStatusCompleted
+	if err != nil {
+		job.Status = model.ImportStatusFailed
+		job.Error = err.Error()
+	}
+	s.saveImportProgress(job, pending)
+}
+
+func (s *ImportService) saveImportProgress(job model.ImportJob, pending []model.ImportRowError) {
+	if err := s.repo.UpdateImportJobProgress(job, pending); err != nil {
+		logrus.Errorf("failed to save progress of import job %d: %s", job.ID, err.Error())
+	}
+}
+
+// importRow создаёт товар или обновляет существующий товар продавца с тем же артикулом.
+// При обновлении меняются только колонки, заполненные в строке.
+func (s *ImportService) importRow(sellerID string, cells []string, columns map[string]int, lookups importLookups, change model.StockChange) (bool, error) {
+	article := importCell(cells, columns, model.ImportFieldArticle)
+	if article == "" {
+		return false, errors.New("article is required")
+	}
+
+	item, err := s.itemRepo.GetItemByArticle(sellerID, article)
+	if err != nil {
+		return false, err
+	}
+	created := item.ID == 0
+	if created {
+		item = model.Item{SellerID: sellerID, Article: article}
+	}
+
+	if err := applyImportRow(&item, cells, columns, lookups); err != nil {
+		return false, err
+	}
+
+	if !created {
+		return false, s.items.UpdateItem(item, change)
+	}
+
+	switch {
+	case item.Name == "":
+		return false, errors.New("name is required for a new item")
+	case item.CategoryID == 0:
+		return false, errors.New("category is required for a new item")
+	case item.BrandID == 0:
+		return false, errors.New("brand is required for a new item")
+	case item.MaterialID == 0:
+		return false, errors.New("material is required for a new item")
+	}
+	if _, err := s.items.CreateItem(item, change); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *ImportService) loadImportLookups() (importLookups, error) {
+	lookups := importLookups{
+		categories: map[string]int{},
+		brands:     map[string]int{},
+		materials:  map[string]int{},
+	}
+
+	categories, err := s.categoryRepo.GetCategoryList()
+	if err != nil {
+		return lookups, err
+	}
+	for _, category := range categories {
+		lookups.categories[normalizeImportName(category.Name)] = category.ID
+	}
+
+	brands, err := s.brandRepo.GetBrandList()
+	if err != nil {
+		return lookups, err
+	}
+	for _, brand := range brands {
+		lookups.brands[normalizeImportName(brand.Name)] = brand.ID
+	}
+
+	materials, err := s.materialRepo.GetMaterialList()
+	if err != nil {
+		return lookups, err
+	}
+	for _, material := range materials {
+		lookups.materials[normalizeImportName(material.Name)] = material.ID
+	}
+
+	return lookups, nil
+}
+
+// applyImportRow переносит заполненные ячейки строки в поля товара
+func applyImportRow(item *model.Item, cells []string, columns map[string]int, lookups importLookups) error {
+	for field := range columns {
+		value := importCell(cells, columns, field)
+		if value == "" {
+			continue
+		}
+
+		var err error
+		switch field {
+		case model.ImportFieldName:
+			item.Name = value
+		case model.ImportFieldDescription:
+			item.Description = value
+		case model.ImportFieldPrice:
+			item.Price, err = model.ParseMoney(importDigitSeparators.Replace(value))
+		case model.ImportFieldPriceWithDiscount:
+			item.PriceWithDiscount, err = model.ParseMoney(importDigitSeparators.Replace(value))
+		case model.ImportFieldCurrency:
+			item.Currency = strings.ToUpper(value)
+		case model.ImportFieldQuantity:
+			item.Quantity, err = parseImportInt(value)
+		case model.ImportFieldLength:
+			item.Length, err = parseImportInt(value)
+		case model.ImportFieldWidth:
+			item.Width, err = parseImportInt(value)
+		case model.ImportFieldHeight:
+			item.Height, err = parseImportInt(value)
+		case model.ImportFieldWeight:
+			item.Weight, err = parseImportInt(value)
+		case model.ImportFieldUnit:
+			item.Unit = value
+		case model.ImportFieldBaseUnit:
+			item.BaseUnit = value
+		case model.ImportFieldUnitRatio:
+			item.UnitRatio, err = strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+		case model.ImportFieldMinQuantity:
+			item.MinQuantity, err = parseImportInt(value)
+		case model.ImportFieldQuantityStep:
+			item.QuantityStep, err = parseImportInt(value)
+		case model.ImportFieldCategory:
+			item.CategoryID, err = lookupImportName(lookups.categories, "category", value)
+		case model.ImportFieldBrand:
+			item.BrandID, err = lookupImportName(lookups.brands, "brand", value)
+		case model.ImportFieldMaterial:
+			item.MaterialID, err = lookupImportName(lookups.materials, "material", value)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", field, err)
+		}
+	}
+	return nil
+}
+
+// readImportRecords читает все строки первого листа XLSX или CSV-файла
+func readImportRecords(fileName string, data []byte) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return readCSVRecords(data)
+	case ".xlsx":
+		return readXLSXRecords(data)
+	}
+	return nil, fmt.Errorf("%w: only CSV and XLSX files are supported", ErrInvalidImport)
+}
+
+func readCSVRecords(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte(csvBOM))
+
+	// Excel в русской локали сохраняет CSV через точку с запятой, поэтому разделитель определяем по заголовку
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	comma := ','
+	for _, candidate := range []rune{';', '\t'} {
+		if bytes.Count(header, []byte(string(candidate))) > bytes.Count(header, []byte(string(comma))) {
+			comma = candidate
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	return records, nil
+}
+
+func readXLSXRecords(data []byte) ([][]string, error) {
+	file, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	defer file.Close()
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("%w: workbook has no sheets", ErrInvalidImport)
+	}
+	records, err := file.GetRows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	return records, nil
+}
+
+// resolveImportColumns сопоставляет поля товара номерам колонок по заголовку.
+// Явное сопоставление из mapping имеет приоритет над распознаванием по названиям.
+func resolveImportColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	headerIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		name = normalizeImportName(name)
+		if _, ok := headerIndex[name]; !ok && name != "" {
+			headerIndex[name] = i
+		}
+	}
+
+	columns := map[string]int{}
+	for field, aliases := range importFieldAliases {
+		for _, name := range append([]string{field}, aliases...) {
+			if i, ok := headerIndex[name]; ok {
+				columns[field] = i
+				break
+			}
+		}
+	}
+
+	for field, column := range mapping {
+		if _, ok := importFieldAliases[field]; !ok {
+			return nil, fmt.Errorf("%w: unknown field %q in mapping", ErrInvalidImport, field)
+		}
+		i, ok := headerIndex[normalizeImportName(column)]
+		if !ok {
+			return nil, fmt.Errorf("%w: column %q not found", ErrInvalidImport, column)
+		}
+		columns[field] = i
+	}
+
+	if _, ok := columns[model.ImportFieldArticle]; !ok {
+		return nil, fmt.Errorf("%w: article column is required", ErrInvalidImport)
+	}
+	return columns, nil
+}
+
+func importCell(cells []string, columns map[string]int, field string) string {
+	i, ok := columns[field]
+	if !ok || i >= len(cells) {
+		return ""
+	}
+	return strings.TrimSpace(cells[i])
+}
+
+func isEmptyImportRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeImportName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+func lookupImportName(ids map[string]int, kind, name string) (int, error) {
+	id, ok := ids[normalizeImportName(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown %s %q", kind, name)
+	}
+	return id, nil
+}
+
+// parseImportInt разбирает целое число, допуская пробелы-разделители разрядов и нулевую дробную часть ("1 200", "5.0")
+func parseImportInt(value string) (int, error) {
+	value = importDigitSeparators.Replace(value)
+	if number, err := strconv.Atoi(value); err == nil {
+		return number, nil
+	}
+	number, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+	if err != nil || number != float64(int(number)) {
+		return 0, fmt.Errorf("invalid integer %q", value)
+	}
+	return int(number), nil
+}
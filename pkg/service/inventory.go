@@ -0,0 +1,226 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+)
+
+const maxInventoryUpdates = 1000
+
+// ErrTooManyInventoryUpdates возвращается, если в запросе слишком много строк
+var ErrTooManyInventoryUpdates = fmt.Errorf("at most %d rows per request", maxInventoryUpdates)
+
+type InventoryService struct {
+	itemRepo      repository.Item
+	warehouseRepo repository.Warehouse
+	movementRepo  repository.Movement
+}
+
+func NewInventoryService(itemRepo repository.Item, warehouseRepo repository.Warehouse, movementRepo repository.Movement) *InventoryService {
+	return &InventoryService{itemRepo: itemRepo, warehouseRepo: warehouseRepo, movementRepo: movementRepo}
+}
+
+// GetMovements возвращает журнал движений остатков товаров продавца
+func (s *InventoryService) GetMovements(sellerID string, filter model.StockMovementFilter) ([]model.StockMovementOutput, error) {
+	if filter.Limit > maxInventoryUpdates {
+		filter.Limit = maxInventoryUpdates
+	}
+	movements, err := s.movementRepo.GetMovements(sellerID, filter)
+	if err != nil {
+		return nil, err
+	}
+	return model.ConvertMovementsToOutput(movements), nil
+}
+
+// SetLowStockThreshold задаёт порог низкого остатка товара или порог продавца по умолчанию.
+// Нулевой порог товара означает порог продавца, нулевой порог продавца отключает уведомления.
+func (s *InventoryService) SetLowStockThreshold(sellerID string, input model.LowStockThresholdInput) error {
+	if input.Threshold < 0 {
+		return errors.New("threshold must not be negative")
+	}
+	if input.ItemID != nil {
+		item, err := s.itemRepo.GetItemById(*input.ItemID)
+		if err != nil || item.SellerID != sellerID {
+			return ErrItemNotFound
+		}
+	}
+	return s.movementRepo.SetLowStockThreshold(sellerID, input.ItemID, input.Threshold)
+}
+
+// UpdateInventory применяет строки независимо друг от друга и возвращает результат по каждой строке.
+// Ошибка БД в одной строке не прерывает обработку: строка получает статус error, остальные применяются.
+func (s *InventoryService) UpdateInventory(sellerID string, updates []model.InventoryUpdate) ([]model.InventoryUpdateResult, error) {
+	if len(updates) > maxInventoryUpdates {
+		return nil, ErrTooManyInventoryUpdates
+	}
+
+	results := make([]model.InventoryUpdateResult, 0, len(updates))
+	for i, update := range updates {
+		result, err := s.updateInventoryRow(sellerID, update)
+		if err != nil {
+			result.Status = model.InventoryStatusError
+			result.Error = err.Error()
+		}
+		result.Index = i
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (s *InventoryService) updateInventoryRow(sellerID string, update model.InventoryUpdate) (model.InventoryUpdateResult, error) {
+	result := model.InventoryUpdateResult{ItemID: update.ItemID, Article: update.Article}
+
+	item, err := s.findSellerItem(sellerID, update)
+	if err != nil {
+		return result, err
+	}
+	if item.ID == 0 {
+		result.Status = model.InventoryStatusNotFound
+		return result, nil
+	}
+	result.ItemID = item.ID
+	result.Article = item.Article
+
+	fields, err := inventoryFields(item, update)
+	if err != nil {
+		result.Status = model.InventoryStatusInvalid
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	// Остаток товара со складами меняется только на конкретном складе
+	stockQuantity, err := s.warehouseStockUpdate(sellerID, item, update)
+	if err != nil {
+		result.Status = model.InventoryStatusInvalid
+		result.Error = err.Error()
+		return result, nil
+	}
+	if stockQuantity != nil {
+		delete(fields, "quantity")
+	}
+
+	// Версия из запроса защищает от перезаписи чужих изменений, без неё — от гонки между чтением и записью
+	version := item.Version
+	if update.Version != nil {
+		version = *update.Version
+	}
+	if version != item.Version {
+		result.Status = model.InventoryStatusConflict
+		result.Version = item.Version
+		result.Error = fmt.Sprintf("item version is %d, got %d", item.Version, version)
+		return result, nil
+	}
+	change := model.StockChange{Type: model.MovementTypeAdjustment, ActorID: sellerID, Reason: update.Reason}
+	if change.Reason == "" {
+		change.Reason = "bulk inventory update"
+	}
+	if len(fields) > 0 {
+		updated, err := s.itemRepo.UpdateItemInventory(item.ID, version, fields, change)
+		if err != nil {
+			return result, err
+		}
+		if !updated {
+			result.Status = model.InventoryStatusConflict
+			result.Error = "item was modified concurrently"
+			return result, nil
+		}
+		version++
+	}
+	if stockQuantity != nil {
+		if err := s.warehouseRepo.SetStock(item.ID, *update.WarehouseID, *stockQuantity, change); err != nil {
+			return result, err
+		}
+		version++
+	}
+
+	result.Status = model.InventoryStatusUpdated
+	result.Version = version
+	return result, nil
+}
+
+// warehouseStockUpdate возвращает новый остаток на складе, если строка меняет остаток по складу
+func (s *InventoryService) warehouseStockUpdate(sellerID string, item model.Item, update model.InventoryUpdate) (*int, error) {
+	if update.WarehouseID == nil {
+		if update.Quantity == nil {
+			return nil, nil
+		}
+		stocks, err := s.warehouseRepo.GetItemStocks(item.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(stocks) > 0 {
+			return nil, errors.New("item is stocked per warehouse, warehouse_id is required")
+		}
+		return nil, nil
+	}
+
+	if update.Quantity == nil {
+		return nil, errors.New("quantity is required with warehouse_id")
+	}
+	warehouse, err := s.warehouseRepo.GetWarehouseById(*update.WarehouseID)
+	if err != nil || warehouse.SellerID != sellerID {
+		return nil, ErrWarehouseNotFound
+	}
+	return update.Quantity, nil
+}
+
+// findSellerItem ищет товар продавца по ID или артикулу; чужой товар считается ненайденным
+func (s *InventoryService) findSellerItem(sellerID string, update model.InventoryUpdate) (model.Item, error) {
+	if update.ItemID != 0 {
+		item, err := s.itemRepo.FindItemById(update.ItemID)
+		if err != nil {
+			return model.Item{}, err
+		}
+		if item.SellerID != sellerID {
+			return model.Item{}, nil
+		}
+		return item, nil
+	}
+	if update.Article != "" {
+		return s.itemRepo.GetItemByArticle(sellerID, update.Article)
+	}
+	return model.Item{}, nil
+}
+
+// inventoryFields проверяет строку и собирает изменяемые колонки.
+// Цена без скидки хранится как price_with_discount = price, поэтому при смене только цены
+// товар без скидки остаётся без скидки; цена со скидкой не может превышать цену.
+func inventoryFields(item model.Item, update model.InventoryUpdate) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+
+	if update.Quantity != nil {
+		if *update.Quantity < 0 {
+			return nil, errors.New("quantity must not be negative")
+		}
+		fields["quantity"] = *update.Quantity
+	}
+
+	price, priceWithDiscount := item.Price, item.PriceWithDiscount
+	if update.Price != nil {
+		price = *update.Price
+		fields["price"] = price
+	}
+	if update.PriceWithDiscount != nil {
+		priceWithDiscount = *update.PriceWithDiscount
+		fields["price_with_discount"] = priceWithDiscount
+	}
+	if price < 0 || priceWithDiscount < 0 {
+		return nil, errors.New("price must not be negative")
+	}
+	// Как и при сохранении товара, нулевая цена со скидкой означает цену без скидки
+	if update.PriceWithDiscount != nil && priceWithDiscount == 0 {
+		priceWithDiscount = price
+		fields["price_with_discount"] = price
+	}
+	if update.Price != nil && update.PriceWithDiscount == nil && item.PriceWithDiscount == item.Price {
+		priceWithDiscount = price
+		fields["price_with_discount"] = price
+	}
+	if (update.Price != nil || update.PriceWithDiscount != nil) && priceWithDiscount > price {
+		return nil, errors.New("price_with_discount must not exceed price")
+	}
+
+	return fields, nil
+}
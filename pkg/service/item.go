@@ -1,25 +1,89 @@
 package service
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"mime/multipart"
-	"os"
-	"path/filepath"
+	"sort"
+	"strings"
 	"stroycity/pkg/model"
 	"stroycity/pkg/repository"
+	"stroycity/pkg/storage"
 	"time"
 )
 
+// ErrInvalidItemStatus возвращается при недопустимом переходе между статусами товара
+var (
+	ErrInvalidItemStatus = errors.New("invalid item status")
+	ErrInvalidItemSort   = errors.New("invalid sort: expected popular, price_asc, price_desc or newest")
+	// ErrItemReserved возвращается при удалении товара, который зарезервирован под неоплаченные заказы
+	ErrItemReserved = errors.New("item is reserved by unpaid orders, wait for them to be paid or cancelled")
+)
+
+// itemStatusTransitions — из каких статусов продавец может перевести товар в указанный.
+// Опубликованным товар становится только после одобрения модератором.
+var itemStatusTransitions = map[string][]string{
+	model.ItemStatusModeration: {model.ItemStatusDraft, model.ItemStatusArchived},
+	model.ItemStatusDraft:      {model.ItemStatusPublished, model.ItemStatusModeration, model.ItemStatusArchived},
+	model.ItemStatusArchived:   {model.ItemStatusDraft, model.ItemStatusModeration, model.ItemStatusPublished},
+}
+
 type ItemService struct {
-	repo repository.Item
+	repo            repository.Item
+	attributeRepo   repository.Attribute
+	productRepo     repository.Product
+	warehouseRepo   repository.Warehouse
+	reservationRepo repository.Reservation
+	moderationRepo  repository.Moderation
+	slugRepo        repository.Slug
+	storage         storage.BlobStorage
+	seo             SEOConfig
 }
 
-func NewItemService(repo repository.Item) *ItemService {
-	return &ItemService{repo: repo}
+func NewItemService(repo repository.Item, attributeRepo repository.Attribute, productRepo repository.Product, warehouseRepo repository.Warehouse, reservationRepo repository.Reservation, moderationRepo repository.Moderation, slugRepo repository.Slug, storage storage.BlobStorage, seo SEOConfig) *ItemService {
+	return &ItemService{repo: repo, attributeRepo: attributeRepo, productRepo: productRepo, warehouseRepo: warehouseRepo, reservationRepo: reservationRepo, moderationRepo: moderationRepo, slugRepo: slugRepo, storage: storage, seo: seo}
 }
 
-func (s *ItemService) CreateItem(item model.Item) (int, error) {
-	return s.repo.CreateItem(item)
+func (s *ItemService) CreateItem(item model.Item, change model.StockChange) (int, error) {
+	if err := normalizeItem(&item); err != nil {
+		return 0, err
+	}
+	// Новый товар сохраняется черновиком или отправляется на модерацию и публикуется после одобрения
+	switch item.Status {
+	case "", model.ItemStatusPublished, model.ItemStatusModeration:
+		item.Status = model.ItemStatusModeration
+	case model.ItemStatusDraft:
+	default:
+		return 0, fmt.Errorf("%w: new item can only be %s or %s", ErrInvalidItemStatus, model.ItemStatusDraft, model.ItemStatusPublished)
+	}
+	if err := s.validateAttributes(&item); err != nil {
+		return 0, err
+	}
+	if err := s.validateVariant(&item); err != nil {
+		return 0, err
+	}
+	if err := validatePriceTiers(&item); err != nil {
+		return 0, err
+	}
+
+	// Адрес генерируется по названию после создания товара
+	item.Slug = ""
+	itemID, err := s.repo.CreateItem(item, change)
+	if err != nil {
+		return 0, err
+	}
+	if err := assignSlug(s.slugRepo, model.SlugEntityItem, itemID, item.Name); err != nil {
+		return itemID, err
+	}
+	if item.Status == model.ItemStatusModeration {
+		item.ID = itemID
+		if err := s.submitForModeration(model.ModerationKindNew, model.Item{}, item); err != nil {
+			return itemID, err
+		}
+	}
+	return itemID, nil
 }
 
 func (s *ItemService) GetItemById(itemID int) (model.CurrentItemInfo, error) {
@@ -34,34 +98,408 @@ func (s *ItemService) GetItemById(itemID int) (model.CurrentItemInfo, error) {
 	currentItemInfo.Article = item.Article
 	currentItemInfo.Price = item.Price
 	currentItemInfo.PriceWithDiscount = item.PriceWithDiscount
+	currentItemInfo.Currency = item.Currency
 	currentItemInfo.Quantity = item.Quantity
 	currentItemInfo.Length = item.Length
 	currentItemInfo.Width = item.Width
 	currentItemInfo.Height = item.Height
 	currentItemInfo.Weight = item.Weight
+	currentItemInfo.Unit = item.Unit
+	currentItemInfo.BaseUnit = item.BaseUnit
+	currentItemInfo.UnitRatio = item.UnitRatio
+	currentItemInfo.MinQuantity = item.MinQuantity
+	currentItemInfo.QuantityStep = item.QuantityStep
+	if item.BaseUnit != "" {
+		currentItemInfo.PricePerBaseUnit = item.Price.Div(item.UnitRatio)
+		currentItemInfo.PriceWithDiscountPerBaseUnit = item.PriceWithDiscount.Div(item.UnitRatio)
+	}
 	currentItemInfo.Category = item.Category.Name
 	currentItemInfo.Brand = item.Brand.Name
 	currentItemInfo.Seller = item.Seller.Name
 	currentItemInfo.SellerID = item.SellerID
 	currentItemInfo.Material = item.Material.Name
-	for _, image := range item.Images {
-		currentItemInfo.Images = append(currentItemInfo.Images, image.URL)
+	currentItemInfo.Version = item.Version
+	currentItemInfo.Status = item.Status
+	currentItemInfo.Slug = item.Slug
+	currentItemInfo.CanonicalURL = s.seo.canonicalURL(model.ItemPath(item))
+	currentItemInfo.MetaTitle = metaTitle(item.MetaTitle, item.Name)
+	currentItemInfo.Images = model.ConvertImagesToInfo(item.Images, s.storage.URL)
+	currentItemInfo.Attributes = model.ConvertItemAttributesToInfo(item.Attributes)
+	currentItemInfo.PriceTiers = model.ConvertPriceTiersToInfo(item.PriceTiers)
+
+	// Наличие показывается за вычетом товара, зарезервированного под неоплаченные заказы
+	reserved, err := s.reservationRepo.GetReservedStock([]int{item.ID})
+	if err != nil {
+		return currentItemInfo, err
 	}
+	currentItemInfo.Available = model.Available(item.Quantity, reserved[item.ID].Total)
+
+	stocks, err := s.warehouseRepo.GetItemStocks(item.ID)
+	if err != nil {
+		return currentItemInfo, err
+	}
+	stocks = availableStocks(stocks, reserved[item.ID])
+	currentItemInfo.Warehouses = model.ConvertStocksToAvailability(stocks)
+	for _, stock := range stocks {
+		if stock.Quantity > 0 && stock.Warehouse.PickupAvailable {
+			currentItemInfo.PickupAvailable = true
+		}
+	}
+
+	// Для варианта возвращаем матрицу всех SKU карточки товара
+	if item.ProductID != nil {
+		product, err := s.productRepo.GetProductById(*item.ProductID)
+		if err != nil {
+			return currentItemInfo, err
+		}
+		if currentItemInfo.Description == "" {
+			currentItemInfo.Description = product.Description
+		}
+		variantReserved, err := s.reservationRepo.GetReservedStock(productItemIDs(product))
+		if err != nil {
+			return currentItemInfo, err
+		}
+		matrix := model.ConvertProductToVariantMatrix(product, variantReserved, s.storage.URL)
+		currentItemInfo.ProductID = item.ProductID
+		currentItemInfo.Variants = &matrix
+	}
+	currentItemInfo.MetaDescription = metaDescription(item.MetaDescription, currentItemInfo.Description)
 
 	return currentItemInfo, nil
 }
 
-func (s *ItemService) UpdateItem(item model.Item) error {
-	return s.repo.UpdateItem(item)
+// GetItemBySlug возвращает опубликованный товар по текущему или прежнему адресу.
+// Если адрес прежний, Slug результата отличается от запрошенного.
+func (s *ItemService) GetItemBySlug(slug string) (model.CurrentItemInfo, error) {
+	itemID, err := resolveSlug(s.slugRepo, model.SlugEntityItem, slug)
+	if err != nil {
+		return model.CurrentItemInfo{}, err
+	}
+	if itemID == 0 {
+		return model.CurrentItemInfo{}, ErrItemNotFound
+	}
+
+	item, err := s.GetItemById(itemID)
+	if err != nil || item.Status != model.ItemStatusPublished {
+		return model.CurrentItemInfo{}, ErrItemNotFound
+	}
+	return item, nil
+}
+
+func (s *ItemService) UpdateItem(item model.Item, change model.StockChange) error {
+	if err := normalizeItem(&item); err != nil {
+		return err
+	}
+	if err := s.validateAttributes(&item); err != nil {
+		return err
+	}
+	if err := s.validateVariant(&item); err != nil {
+		return err
+	}
+	if err := validatePriceTiers(&item); err != nil {
+		return err
+	}
+
+	// Остаток товара со складскими остатками — всегда их сумма, прямое изменение количества не применяется
+	stocks, err := s.warehouseRepo.GetItemStocks(item.ID)
+	if err != nil {
+		return err
+	}
+	if len(stocks) > 0 {
+		item.Quantity = 0
+		for _, stock := range stocks {
+			item.Quantity += stock.Quantity
+		}
+	}
+
+	before, err := s.repo.GetItemById(item.ID)
+	if err != nil {
+		return err
+	}
+
+	// Изменение описания опубликованного товара снова проходит модерацию; цены и остатки — нет.
+	// Пока правка ждёт проверки, покупатели видят одобренную версию, а правка хранится в заявке.
+	var request *model.ModerationRequest
+	moderated := before.Status == model.ItemStatusPublished || before.Status == model.ItemStatusModeration
+	if moderated && len(moderationDiff(before, item, moderationTriggerFields)) > 0 {
+		kind := model.ModerationKindNew
+		if before.Status == model.ItemStatusPublished {
+			kind = model.ModerationKindEdit
+		}
+		pending, err := s.moderationRequest(kind, before, item)
+		if err != nil {
+			return err
+		}
+		if kind == model.ModerationKindEdit {
+			model.ItemContentOf(before).Apply(&item)
+		}
+		request = &pending
+	}
+
+	if err := s.repo.UpdateItem(item, change, request); err != nil {
+		return err
+	}
+	// При переименовании товар получает новый адрес, старый перенаправляется на него
+	if before.Name != item.Name || before.Slug == "" {
+		return assignSlug(s.slugRepo, model.SlugEntityItem, item.ID, item.Name)
+	}
+	return nil
+}
+
+// SetItemStatus переводит товар в статус: отправляет на публикацию, снимает с публикации в черновики или архивирует.
+// Публикация проходит через модерацию: товар получает статус moderation и заявку в очереди.
+func (s *ItemService) SetItemStatus(itemID int, status string) error {
+	item, err := s.repo.GetItemById(itemID)
+	if err != nil {
+		return ErrItemNotFound
+	}
+	if item.Status == status || status == model.ItemStatusPublished && item.Status == model.ItemStatusModeration {
+		return nil
+	}
+	if status == model.ItemStatusPublished {
+		status = model.ItemStatusModeration
+	}
+
+	allowed, ok := itemStatusTransitions[status]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidItemStatus, status)
+	}
+	for _, from := range allowed {
+		if item.Status != from {
+			continue
+		}
+		if err := s.repo.SetItemStatus(itemID, status); err != nil {
+			return err
+		}
+		if status == model.ItemStatusModeration {
+			return s.submitForModeration(model.ModerationKindNew, model.Item{}, item)
+		}
+		// Снятый с публикации товар теряет и заявку на новую версию, и ожидающую правку
+		return s.withdrawModeration(itemID)
+	}
+	return fmt.Errorf("%w: cannot change %s item to %s", ErrInvalidItemStatus, item.Status, status)
+}
+
+// DeleteItem удаляет товар из каталога; в истории заказов он остаётся
+func (s *ItemService) DeleteItem(itemID int) error {
+	if _, err := s.repo.GetItemById(itemID); err != nil {
+		return ErrItemNotFound
+	}
+	err := s.repo.DeleteItem(itemID)
+	if errors.Is(err, repository.ErrItemReserved) {
+		return ErrItemReserved
+	}
+	if err != nil {
+		return err
+	}
+	return s.withdrawModeration(itemID)
+}
+
+// validateVariant проверяет, что вариант принадлежит карточке продавца,
+// задаёт ровно одно значение по каждой оси и не повторяет уже существующий SKU
+func (s *ItemService) validateVariant(item *model.Item) error {
+	if item.ProductID == nil {
+		if len(item.VariantValues) > 0 {
+			return errors.New("variant values require product_id")
+		}
+		return nil
+	}
+
+	product, err := s.productRepo.GetProductById(*item.ProductID)
+	if err != nil {
+		return fmt.Errorf("product not found: %v", err)
+	}
+	if product.SellerID != item.SellerID {
+		return errors.New("product belongs to another seller")
+	}
+	// При обновлении без значений вариантов сохраняются прежние значения
+	if item.ID != 0 && item.VariantValues == nil {
+		return nil
+	}
+
+	values := make(map[int]string, len(item.VariantValues))
+	for i, value := range item.VariantValues {
+		value.Value = strings.TrimSpace(value.Value)
+		if value.Value == "" {
+			return fmt.Errorf("empty value for axis %d", value.AxisID)
+		}
+		if _, ok := values[value.AxisID]; ok {
+			return fmt.Errorf("axis %d is set more than once", value.AxisID)
+		}
+		values[value.AxisID] = value.Value
+		item.VariantValues[i] = value
+	}
+	for _, axis := range product.Axes {
+		if _, ok := values[axis.ID]; !ok {
+			return fmt.Errorf("value for axis %s is required", axis.Name)
+		}
+	}
+	if len(values) != len(product.Axes) {
+		return errors.New("variant values contain unknown axis")
+	}
+
+	key := variantKey(item.VariantValues)
+	for _, variant := range product.Items {
+		if variant.ID != item.ID && variantKey(variant.VariantValues) == key {
+			return fmt.Errorf("variant %s already exists in product (item %d)", key, variant.ID)
+		}
+	}
+
+	return nil
+}
+
+func variantKey(values []model.VariantValue) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, fmt.Sprintf("%d=%s", value.AxisID, strings.ToLower(value.Value)))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ";")
+}
+
+// normalizeItem подставляет валюту и единицы измерения по умолчанию, проверяет цены и кратность продажи
+func normalizeItem(item *model.Item) error {
+	if item.Currency == "" {
+		item.Currency = model.DefaultCurrency
+	}
+	if item.Price < 0 || item.PriceWithDiscount < 0 {
+		return errors.New("price must not be negative")
+	}
+	if item.PriceWithDiscount == 0 {
+		item.PriceWithDiscount = item.Price
+	}
+
+	if item.Unit == "" {
+		item.Unit = model.UnitPiece
+	}
+	if !model.IsValidUnit(item.Unit) {
+		return fmt.Errorf("unknown unit: %s", item.Unit)
+	}
+	if item.BaseUnit != "" && !model.IsValidUnit(item.BaseUnit) {
+		return fmt.Errorf("unknown base unit: %s", item.BaseUnit)
+	}
+	if item.BaseUnit == item.Unit {
+		item.BaseUnit = ""
+	}
+
+	if item.UnitRatio == 0 {
+		item.UnitRatio = 1
+	}
+	if item.MinQuantity == 0 {
+		item.MinQuantity = 1
+	}
+	if item.QuantityStep == 0 {
+		item.QuantityStep = 1
+	}
+	if item.UnitRatio < 0 || item.MinQuantity < 0 || item.QuantityStep < 0 {
+		return errors.New("unit_ratio, min_quantity and quantity_step must be positive")
+	}
+	if item.MinQuantity%item.QuantityStep != 0 {
+		return errors.New("min_quantity must be a multiple of quantity_step")
+	}
+
+	return nil
+}
+
+// validatePriceTiers проверяет оптовые пороги и сортирует их по возрастанию количества
+func validatePriceTiers(item *model.Item) error {
+	seen := map[int]bool{}
+	for _, tier := range item.PriceTiers {
+		if tier.MinQuantity <= 1 {
+			return errors.New("price tier min_quantity must be greater than 1")
+		}
+		if tier.Price <= 0 {
+			return errors.New("price tier price must be positive")
+		}
+		if seen[tier.MinQuantity] {
+			return fmt.Errorf("duplicate price tier for quantity %d", tier.MinQuantity)
+		}
+		seen[tier.MinQuantity] = true
+	}
+
+	sort.Slice(item.PriceTiers, func(i, j int) bool {
+		return item.PriceTiers[i].MinQuantity < item.PriceTiers[j].MinQuantity
+	})
+	for i := 1; i < len(item.PriceTiers); i++ {
+		if item.PriceTiers[i].Price > item.PriceTiers[i-1].Price {
+			return errors.New("price tier price must not grow with quantity")
+		}
+	}
+
+	return nil
 }
 
-func (s *ItemService) GetItems(brandIDs, sellerIDs, categoryIDs, materialIDs []uint, minPrice, maxPrice float64, query string) ([]model.ItemInfo, error) {
-	items, err := s.repo.GetItems(brandIDs, sellerIDs, categoryIDs, materialIDs, minPrice, maxPrice, query)
+// validateAttributes проверяет, что характеристики товара относятся к его категории
+// и заполнены значением подходящего типа
+func (s *ItemService) validateAttributes(item *model.Item) error {
+	if len(item.Attributes) == 0 {
+		return nil
+	}
+
+	attributes, err := s.attributeRepo.GetAttributesByCategoryID(item.CategoryID)
+	if err != nil {
+		return err
+	}
+	categoryAttributes := make(map[int]model.Attribute, len(attributes))
+	for _, attribute := range attributes {
+		categoryAttributes[attribute.ID] = attribute
+	}
+
+	seen := map[int]bool{}
+	for i, value := range item.Attributes {
+		attribute, ok := categoryAttributes[value.AttributeID]
+		if !ok {
+			return fmt.Errorf("attribute %d does not belong to category %d", value.AttributeID, item.CategoryID)
+		}
+		if seen[value.AttributeID] {
+			return fmt.Errorf("attribute %s is set more than once", attribute.Name)
+		}
+		seen[value.AttributeID] = true
+
+		switch attribute.Type {
+		case model.AttributeTypeNumber:
+			if value.NumberValue == nil {
+				return fmt.Errorf("attribute %s requires a number value", attribute.Name)
+			}
+			value.BoolValue, value.EnumValue = nil, ""
+		case model.AttributeTypeBoolean:
+			if value.BoolValue == nil {
+				return fmt.Errorf("attribute %s requires a boolean value", attribute.Name)
+			}
+			value.NumberValue, value.EnumValue = nil, ""
+		case model.AttributeTypeEnum:
+			valid := false
+			for _, option := range attribute.Options {
+				if option.Value == value.EnumValue {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return fmt.Errorf("invalid value %q for attribute %s", value.EnumValue, attribute.Name)
+			}
+			value.NumberValue, value.BoolValue = nil, nil
+		default:
+			return errors.New("unknown attribute type: " + attribute.Type)
+		}
+		item.Attributes[i] = value
+	}
+
+	return nil
+}
+
+func (s *ItemService) GetItems(brandIDs, sellerIDs, categoryIDs, materialIDs []uint, minPrice, maxPrice model.Money, query string, attributes []model.AttributeFilter, sort string) ([]model.ItemInfo, error) {
+	switch sort {
+	case "", model.ItemSortPopular, model.ItemSortPriceAsc, model.ItemSortPriceDesc, model.ItemSortNewest:
+	default:
+		return nil, ErrInvalidItemSort
+	}
+
+	items, err := s.repo.GetItems(brandIDs, sellerIDs, categoryIDs, materialIDs, minPrice, maxPrice, query, attributes, sort)
 	if err != nil {
 		return nil, err
 	}
-	itemInfos := model.ConvertItemsToItemInfo(items)
-	return itemInfos, nil
+	return s.convertItemsToItemInfo(items)
 }
 
 func (s *ItemService) GetAllItems() ([]model.ItemInfo, error) {
@@ -69,46 +507,221 @@ func (s *ItemService) GetAllItems() ([]model.ItemInfo, error) {
 	if err != nil {
 		return nil, err
 	}
-	itemInfos := model.ConvertItemsToItemInfo(items)
+	return s.convertItemsToItemInfo(items)
+}
+
+func (s *ItemService) convertItemsToItemInfo(items []model.Item) ([]model.ItemInfo, error) {
+	return convertItemsWithAvailability(s.reservationRepo, s.storage, items)
+}
+
+// convertItemsWithAvailability формирует список товаров с доступным количеством за вычетом резервов
+func convertItemsWithAvailability(reservationRepo repository.Reservation, storage storage.BlobStorage, items []model.Item) ([]model.ItemInfo, error) {
+	itemIDs := make([]int, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.ID)
+	}
+	reserved, err := reservationRepo.GetReservedStock(itemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	itemInfos := model.ConvertItemsToItemInfo(items, storage.URL)
+	for i, item := range items {
+		itemInfos[i].Available = model.Available(item.Quantity, reserved[item.ID].Total)
+	}
 	return itemInfos, nil
 }
 
-func (s *ItemService) UploadImage(itemID int, file multipart.File, fileHeader *multipart.FileHeader) (string, error) {
-	uploadDir := "uploads"
+func (s *ItemService) UploadImage(itemID int, file multipart.File, fileHeader *multipart.FileHeader) (model.ImageInfo, error) {
+	if fileHeader.Size > maxImageSize {
+		return model.ImageInfo{}, fmt.Errorf("%w: file is larger than %d MB", ErrInvalidImage, maxImageSize>>20)
+	}
+
+	// Проверка, очистка от метаданных и нарезка копий разных размеров
+	renditions, err := processImage(file)
+	if err != nil {
+		return model.ImageInfo{}, err
+	}
+
+	images, err := s.storeImages(itemID, [][]processedRendition{renditions})
+	if err != nil {
+		return model.ImageInfo{}, err
+	}
+	return images[0], nil
+}
+
+// UploadImages загружает несколько изображений за один запрос.
+// Сначала проверяются все файлы, поэтому при ошибке в любом из них ничего не сохраняется.
+func (s *ItemService) UploadImages(itemID int, fileHeaders []*multipart.FileHeader) ([]model.ImageInfo, error) {
+	if len(fileHeaders) == 0 {
+		return nil, fmt.Errorf("%w: no files uploaded", ErrInvalidImage)
+	}
+	if len(fileHeaders) > maxImagesPerUpload {
+		return nil, fmt.Errorf("%w: at most %d files per upload", ErrInvalidImage, maxImagesPerUpload)
+	}
 
-	if _, err := os.Stat(uploadDir); os.IsNotExist(err) {
-		err = os.MkdirAll(uploadDir, os.ModePerm)
+	var processed [][]processedRendition
+	for _, fileHeader := range fileHeaders {
+		if fileHeader.Size > maxImageSize {
+			return nil, fmt.Errorf("%w: %s: file is larger than %d MB", ErrInvalidImage, fileHeader.Filename, maxImageSize>>20)
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %v", fileHeader.Filename, err)
+		}
+		renditions, err := processImage(file)
+		file.Close()
 		if err != nil {
-			return "", fmt.Errorf("failed to create directory: %v", err)
+			return nil, fmt.Errorf("%s: %w", fileHeader.Filename, err)
 		}
+		processed = append(processed, renditions)
 	}
 
-	fileName := fmt.Sprintf("%d_%d%s", itemID, time.Now().Unix(), filepath.Ext(fileHeader.Filename))
-	filePath := filepath.Join(uploadDir, fileName)
+	return s.storeImages(itemID, processed)
+}
 
-	file, err := fileHeader.Open()
+// storeImages сохраняет копии изображений в хранилище и записывает их в конец списка изображений товара.
+// Первое изображение товара автоматически становится основным. При ошибке уже сохранённые в этом вызове
+// изображения удаляются, поэтому загрузка либо проходит целиком, либо не оставляет следов.
+func (s *ItemService) storeImages(itemID int, processed [][]processedRendition) ([]model.ImageInfo, error) {
+	existing, err := s.repo.GetImagesByItemID(itemID)
 	if err != nil {
-		return "", fmt.Errorf("failed to open file: %v", err)
+		return nil, err
+	}
+	position := 0
+	for _, image := range existing {
+		position = max(position, image.Position+1)
 	}
-	defer file.Close()
 
-	dst, err := os.Create(filePath)
+	ctx := context.Background()
+	var saved []model.Image
+	rollback := func() {
+		for _, image := range saved {
+			_, _ = s.repo.DeleteImage(itemID, image.ID)
+			s.deleteImageFiles(ctx, image)
+		}
+	}
+	for i, renditions := range processed {
+		image := model.Image{
+			ItemID:    itemID,
+			Position:  position + i,
+			IsPrimary: len(existing) == 0 && i == 0,
+		}
+		baseName := fmt.Sprintf("items/%d/%d", itemID, time.Now().UnixNano())
+		for _, rendition := range renditions {
+			key := fmt.Sprintf("%s_%s%s", baseName, rendition.Name, rendition.Ext)
+			if err := s.storage.Put(ctx, key, bytes.NewReader(rendition.Data), int64(len(rendition.Data)), rendition.ContentType); err != nil {
+				s.deleteImageFiles(ctx, image)
+				rollback()
+				return nil, err
+			}
+
+			image.Renditions = append(image.Renditions, model.ImageRendition{
+				Size:       rendition.Name,
+				StorageKey: key,
+				Width:      rendition.Width,
+				Height:     rendition.Height,
+			})
+			// Основным файлом изображения считается самая крупная копия
+			image.StorageKey = key
+			image.Width = rendition.Width
+			image.Height = rendition.Height
+		}
+
+		image, err = s.repo.SaveImage(image)
+		if err != nil {
+			s.deleteImageFiles(ctx, image)
+			rollback()
+			return nil, err
+		}
+		saved = append(saved, image)
+	}
+
+	infos := make([]model.ImageInfo, 0, len(saved))
+	for _, image := range saved {
+		infos = append(infos, model.ConvertImageToInfo(image, s.storage.URL))
+	}
+	return infos, nil
+}
+
+func (s *ItemService) GetItemImages(itemID int) ([]model.ImageInfo, error) {
+	images, err := s.repo.GetImagesByItemID(itemID)
 	if err != nil {
-		return "", fmt.Errorf("failed to create file: %v", err)
+		return nil, err
+	}
+	return model.ConvertImagesToInfo(images, s.storage.URL), nil
+}
+
+// ReorderImages задаёт порядок изображений товара. Список должен содержать все изображения товара ровно по одному разу.
+func (s *ItemService) ReorderImages(itemID int, imageIDs []int) error {
+	images, err := s.repo.GetImagesByItemID(itemID)
+	if err != nil {
+		return err
+	}
+	if len(imageIDs) != len(images) {
+		return ErrInvalidImageOrder
+	}
+
+	known := make(map[int]bool, len(images))
+	for _, image := range images {
+		known[image.ID] = true
+	}
+	for _, imageID := range imageIDs {
+		if !known[imageID] {
+			return ErrInvalidImageOrder
+		}
+		delete(known, imageID)
+	}
+
+	return s.repo.UpdateImagePositions(itemID, imageIDs)
+}
+
+func (s *ItemService) SetPrimaryImage(itemID, imageID int) error {
+	if _, err := s.findItemImage(itemID, imageID); err != nil {
+		return err
 	}
-	defer dst.Close()
+	return s.repo.SetPrimaryImage(itemID, imageID)
+}
 
-	if _, err := dst.ReadFrom(file); err != nil {
-		return "", fmt.Errorf("failed to save file: %v", err)
+// DeleteImage удаляет изображение и его файлы из хранилища.
+// Если удалено основное изображение, основным становится первое из оставшихся.
+func (s *ItemService) DeleteImage(itemID, imageID int) error {
+	if _, err := s.findItemImage(itemID, imageID); err != nil {
+		return err
 	}
 
-	image := model.Image{
-		ItemID: itemID,
-		URL:    filePath,
+	image, err := s.repo.DeleteImage(itemID, imageID)
+	if err != nil {
+		return err
 	}
-	if err := s.repo.SaveImage(image); err != nil {
-		return "", err
+	s.deleteImageFiles(context.Background(), image)
+
+	if !image.IsPrimary {
+		return nil
 	}
+	remaining, err := s.repo.GetImagesByItemID(itemID)
+	if err != nil || len(remaining) == 0 {
+		return err
+	}
+	return s.repo.SetPrimaryImage(itemID, remaining[0].ID)
+}
 
-	return filePath, nil
+func (s *ItemService) findItemImage(itemID, imageID int) (model.Image, error) {
+	images, err := s.repo.GetImagesByItemID(itemID)
+	if err != nil {
+		return model.Image{}, err
+	}
+	for _, image := range images {
+		if image.ID == imageID {
+			return image, nil
+		}
+	}
+	return model.Image{}, ErrImageNotFound
+}
+
+// deleteImageFiles удаляет из хранилища все копии изображения
+func (s *ItemService) deleteImageFiles(ctx context.Context, image model.Image) {
+	for _, rendition := range image.Renditions {
+		_ = s.storage.Delete(ctx, rendition.StorageKey)
+	}
 }
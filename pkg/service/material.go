@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"stroycity/pkg/model"
 	"stroycity/pkg/repository"
 )
@@ -17,10 +18,52 @@ func (s *MaterialService) CreateMaterial(material model.Material) error {
 	return s.repo.CreateMaterial(material)
 }
 
-func (s *MaterialService) DeleteMaterial(id int) error {
+// UpdateMaterial переименовывает материал
+func (s *MaterialService) UpdateMaterial(id int, input model.MaterialInput) error {
+	material, err := s.getMaterial(id)
+	if err != nil {
+		return err
+	}
+	if material.Name, err = dictionaryName(input.Name); err != nil {
+		return err
+	}
+	return s.repo.UpdateMaterial(material)
+}
+
+// DeleteMaterial удаляет материал. Если он используется товарами, удаление возможно только
+// с переносом товаров в материал reassignTo.
+func (s *MaterialService) DeleteMaterial(id, reassignTo int) error {
+	if _, err := s.getMaterial(id); err != nil {
+		return err
+	}
+	if reassignTo != 0 {
+		return s.MergeMaterials(model.MergeInput{TargetID: reassignTo, SourceIDs: []int{id}})
+	}
+
+	count, err := s.repo.CountMaterialItems(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d items", ErrReferencedByItems, count)
+	}
 	return s.repo.DeleteMaterial(id)
 }
 
+// MergeMaterials объединяет дубликаты с каноническим материалом
+func (s *MaterialService) MergeMaterials(input model.MergeInput) error {
+	sourceIDs, err := mergeSources(input)
+	if err != nil {
+		return err
+	}
+	for _, id := range append([]int{input.TargetID}, sourceIDs...) {
+		if _, err := s.getMaterial(id); err != nil {
+			return err
+		}
+	}
+	return s.repo.MergeMaterials(input.TargetID, sourceIDs)
+}
+
 func (s *MaterialService) GetMaterialList() ([]model.MaterialOutput, error) {
 	materials, err := s.repo.GetMaterialList()
 	if err != nil {
@@ -35,3 +78,14 @@ func (s *MaterialService) GetMaterialList() ([]model.MaterialOutput, error) {
 	}
 	return materialsInfo, nil
 }
+
+func (s *MaterialService) getMaterial(id int) (model.Material, error) {
+	material, err := s.repo.GetMaterialById(id)
+	if err != nil {
+		return material, err
+	}
+	if material.ID == 0 {
+		return material, fmt.Errorf("%w: %d", ErrMaterialNotFound, id)
+	}
+	return material, nil
+}
@@ -0,0 +1,430 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+	"time"
+)
+
+const (
+	// Вес замечаний автоматической проверки в приоритете заявки
+	forbiddenWordsPriority = 3
+	priceAnomalyPriority   = 2
+	missingImagesPriority  = 1
+
+	// Цена считается аномальной, если отличается от медианы категории больше чем в priceAnomalyRatio раз,
+	// скидка превышает maxDiscountShare или цена при правке изменилась больше чем на maxPriceChangeShare
+	priceAnomalyRatio   = 10
+	maxDiscountShare    = 0.9
+	maxPriceChangeShare = 0.5
+)
+
+var (
+	// ErrModerationRequestNotFound возвращается, если заявки нет или она принадлежит другому продавцу
+	ErrModerationRequestNotFound = errors.New("moderation request not found")
+	// ErrModerationRequestReviewed возвращается при повторном рассмотрении заявки
+	ErrModerationRequestReviewed = errors.New("moderation request is already reviewed")
+	// ErrRejectReasonRequired возвращается при отклонении без причины
+	ErrRejectReasonRequired = errors.New("reject reason is required")
+)
+
+// moderationFields — поля товара, которые видит модератор; moderationTriggerFields — поля,
+// изменение которых у опубликованного товара отправляет его на повторную модерацию.
+// bundleModerationFields и bundleModerationTriggerFields — то же для комплекта.
+var (
+	moderationFields              = []string{"name", "description", "article", "category_id", "brand_id", "material_id", "price", "price_with_discount", "unit"}
+	moderationTriggerFields       = []string{"name", "description", "article", "category_id", "brand_id", "material_id"}
+	bundleModerationFields        = []string{"name", "description", "category_id", "price"}
+	bundleModerationTriggerFields = []string{"name", "description", "category_id"}
+)
+
+// ModerationConfig — параметры автоматической проверки товаров
+type ModerationConfig struct {
+	ForbiddenWords []string
+}
+
+type ModerationService struct {
+	repo     repository.Moderation
+	slugRepo repository.Slug
+	config   ModerationConfig
+}
+
+func NewModerationService(repo repository.Moderation, slugRepo repository.Slug, config ModerationConfig) *ModerationService {
+	var words []string
+	for _, word := range config.ForbiddenWords {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			words = append(words, word)
+		}
+	}
+	config.ForbiddenWords = words
+	return &ModerationService{repo: repo, slugRepo: slugRepo, config: config}
+}
+
+// GetModerationQueue возвращает заявки в статусе status. Непроверенные заявки упорядочены по приоритету
+// автоматической проверки, а при равном приоритете — по времени поступления.
+func (s *ModerationService) GetModerationQueue(status string) ([]model.ModerationRequestOutput, error) {
+	if status == "" {
+		status = model.ModerationStatusPending
+	}
+	requests, err := s.repo.GetModerationRequests(status)
+	if err != nil {
+		return nil, err
+	}
+
+	medians := map[int]model.Money{}
+	outputs := []model.ModerationRequestOutput{}
+	for _, request := range requests {
+		// Товар или комплект мог быть удалён после подачи заявки
+		if request.Item.ID == 0 && request.Bundle.ID == 0 {
+			continue
+		}
+		output := model.ConvertModerationRequestToOutput(request)
+		if err := s.applyAutoChecks(&output, request, medians); err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+
+	if status == model.ModerationStatusPending {
+		sort.SliceStable(outputs, func(i, j int) bool { return outputs[i].Priority > outputs[j].Priority })
+	}
+	return outputs, nil
+}
+
+// ApproveItem одобряет заявку и публикует товар; одобренная правка заменяет опубликованную версию
+func (s *ModerationService) ApproveItem(adminID string, requestID int) error {
+	request, err := s.getPendingRequest(requestID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	request.Status = model.ModerationStatusApproved
+	request.ReviewerID = adminID
+	request.ReviewedAt = &now
+	subject, ofSubject, name := moderationSubject(request)
+	message := fmt.Sprintf("%s «%s» прошёл модерацию и опубликован", subject, name)
+	if request.IsEdit() {
+		message = fmt.Sprintf("Изменения %s «%s» прошли модерацию и опубликованы", ofSubject, name)
+	}
+	notification := model.Notification{
+		RecipientID: request.SellerID,
+		Type:        model.NotificationTypeModeration,
+		Message:     message,
+		ItemID:      request.ItemID,
+	}
+	if err := s.repo.ReviewModerationRequest(request, model.ItemStatusPublished, notification); err != nil {
+		return err
+	}
+
+	// Адрес товара меняется вместе с названием только после одобрения правки
+	if request.Pending != nil && request.Pending.Name != request.Item.Name {
+		return assignSlug(s.slugRepo, model.SlugEntityItem, *request.ItemID, request.Pending.Name)
+	}
+	return nil
+}
+
+// RejectItem отклоняет заявку: новый товар или комплект возвращается в черновики, а у опубликованного
+// остаётся прежняя версия. Продавец получает причину отказа.
+func (s *ModerationService) RejectItem(adminID string, requestID int, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrRejectReasonRequired
+	}
+	request, err := s.getPendingRequest(requestID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	request.Status = model.ModerationStatusRejected
+	request.Reason = reason
+	request.ReviewerID = adminID
+	request.ReviewedAt = &now
+	subject, ofSubject, name := moderationSubject(request)
+	message, status := fmt.Sprintf("%s «%s» не прошёл модерацию: %s", subject, name, reason), model.ItemStatusDraft
+	if request.IsEdit() {
+		message = fmt.Sprintf("Изменения %s «%s» не прошли модерацию: %s. Покупатели видят прежнюю версию", ofSubject, name, reason)
+		status = model.ItemStatusPublished
+	}
+	notification := model.Notification{
+		RecipientID: request.SellerID,
+		Type:        model.NotificationTypeModeration,
+		Message:     message,
+		ItemID:      request.ItemID,
+	}
+	return s.repo.ReviewModerationRequest(request, status, notification)
+}
+
+// GetSellerModerationRequests возвращает заявки продавца с решениями и причинами отказа
+func (s *ModerationService) GetSellerModerationRequests(sellerID string, itemID int) ([]model.ModerationRequestOutput, error) {
+	requests, err := s.repo.GetSellerModerationRequests(sellerID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := []model.ModerationRequestOutput{}
+	for _, request := range requests {
+		outputs = append(outputs, model.ConvertModerationRequestToOutput(request))
+	}
+	return outputs, nil
+}
+
+// moderationSubject возвращает, что проверяется в заявке, в именительном и родительном падеже, и его название
+func moderationSubject(request model.ModerationRequest) (string, string, string) {
+	if request.BundleID != nil {
+		return "Комплект", "комплекта", request.Bundle.Name
+	}
+	return "Товар", "товара", request.Item.Name
+}
+
+func (s *ModerationService) getPendingRequest(requestID int) (model.ModerationRequest, error) {
+	request, err := s.repo.GetModerationRequest(requestID)
+	if err != nil || request.Item.ID == 0 && request.Bundle.ID == 0 {
+		return request, ErrModerationRequestNotFound
+	}
+	if request.Status != model.ModerationStatusPending {
+		return request, ErrModerationRequestReviewed
+	}
+	return request, nil
+}
+
+// applyAutoChecks отмечает запрещённые слова, отсутствие изображений и подозрительные цены.
+// Проверка выполняется при каждом чтении очереди, чтобы учитывать загруженные после подачи изображения.
+// У комплекта проверяются запрещённые слова и изображения компонентов.
+func (s *ModerationService) applyAutoChecks(output *model.ModerationRequestOutput, request model.ModerationRequest, medians map[int]model.Money) error {
+	if request.BundleID != nil {
+		// Правка проверяется в том виде, в каком она будет опубликована
+		bundle := request.Bundle
+		if request.PendingBundle != nil {
+			request.PendingBundle.Apply(&bundle)
+		}
+		s.checkForbiddenWords(output, bundle.Name+" "+bundle.Description)
+
+		hasImages := false
+		for _, component := range bundle.Components {
+			hasImages = hasImages || len(component.Item.Images) > 0
+		}
+		if !hasImages {
+			output.Flags = append(output.Flags, model.ModerationFlagMissingImages)
+			output.Priority += missingImagesPriority
+		}
+		return nil
+	}
+
+	item := request.Item
+	if request.Pending != nil {
+		request.Pending.Apply(&item)
+	}
+	s.checkForbiddenWords(output, item.Name+" "+item.Description)
+
+	if len(item.Images) == 0 {
+		output.Flags = append(output.Flags, model.ModerationFlagMissingImages)
+		output.Priority += missingImagesPriority
+	}
+
+	median, ok := medians[item.CategoryID]
+	if !ok {
+		var err error
+		if median, err = s.repo.GetCategoryMedianPrice(item.CategoryID); err != nil {
+			return err
+		}
+		medians[item.CategoryID] = median
+	}
+	if priceAnomaly(item, median, request.Changes) {
+		output.Flags = append(output.Flags, model.ModerationFlagPriceAnomaly)
+		output.Priority += priceAnomalyPriority
+	}
+	return nil
+}
+
+// checkForbiddenWords отмечает заявку, если в тексте есть запрещённые слова
+func (s *ModerationService) checkForbiddenWords(output *model.ModerationRequestOutput, text string) {
+	text = strings.ToLower(text)
+	for _, word := range s.config.ForbiddenWords {
+		if strings.Contains(text, word) {
+			output.Flags = append(output.Flags, model.ModerationFlagForbiddenWords)
+			output.Priority += forbiddenWordsPriority
+			return
+		}
+	}
+}
+
+// priceAnomaly сравнивает цену с медианой категории, размером скидки и прежней ценой товара
+func priceAnomaly(item model.Item, median model.Money, changes []model.ModerationChange) bool {
+	price := item.PriceWithDiscount
+	if price <= 0 || item.Price <= 0 {
+		return true
+	}
+	if float64(price) < float64(item.Price)*(1-maxDiscountShare) {
+		return true
+	}
+	if median > 0 && (price > median*priceAnomalyRatio || price*priceAnomalyRatio < median) {
+		return true
+	}
+
+	for _, change := range changes {
+		if change.Field != "price_with_discount" || change.Old == "" {
+			continue
+		}
+		old, err := model.ParseMoney(change.Old)
+		if err != nil || old <= 0 {
+			continue
+		}
+		if diff := float64(price-old) / float64(old); diff > maxPriceChangeShare || diff < -maxPriceChangeShare {
+			return true
+		}
+	}
+	return false
+}
+
+// submitForModeration ставит товар в очередь модерации
+func (s *ItemService) submitForModeration(kind string, before, item model.Item) error {
+	request, err := s.moderationRequest(kind, before, item)
+	if err != nil {
+		return err
+	}
+	_, err = s.moderationRepo.SaveModerationRequest(request)
+	return err
+}
+
+// moderationRequest готовит заявку на проверку товара. Если заявка уже ждёт проверки, она дополняется:
+// старые значения полей сохраняются с первой правки, новые берутся из последней.
+// Для правки опубликованного товара в заявку записываются новые значения проверяемых полей.
+func (s *ItemService) moderationRequest(kind string, before, item model.Item) (model.ModerationRequest, error) {
+	request, err := s.moderationRepo.GetPendingModerationRequest(item.ID)
+	if err != nil {
+		return request, err
+	}
+
+	changes := moderationDiff(before, item, moderationFields)
+	if request.ID == 0 {
+		request = model.ModerationRequest{
+			ItemID:   &item.ID,
+			SellerID: item.SellerID,
+			Kind:     kind,
+			Status:   model.ModerationStatusPending,
+		}
+	} else if request.Kind == model.ModerationKindNew {
+		// Для нового товара модератор видит все поля в актуальном состоянии
+		changes = moderationDiff(model.Item{}, item, moderationFields)
+	} else {
+		changes = mergeModerationChanges(request.Changes, changes)
+	}
+	request.Changes = changes
+
+	if request.Kind == model.ModerationKindEdit {
+		pending := model.ItemContentOf(item)
+		request.Pending = &pending
+	}
+	return request, nil
+}
+
+// withdrawModeration отзывает ожидающую заявку, когда продавец снимает товар с публикации или удаляет его
+func (s *ItemService) withdrawModeration(itemID int) error {
+	request, err := s.moderationRepo.GetPendingModerationRequest(itemID)
+	if err != nil || request.ID == 0 {
+		return err
+	}
+	request.Status = model.ModerationStatusWithdrawn
+	_, err = s.moderationRepo.SaveModerationRequest(request)
+	return err
+}
+
+// moderationDiff возвращает поля из fields, значения которых у before и after различаются
+func moderationDiff(before, after model.Item, fields []string) []model.ModerationChange {
+	return diffModerationValues(moderationValues(before), moderationValues(after), fields)
+}
+
+// bundleModerationDiff — moderationDiff для комплекта
+func bundleModerationDiff(before, after model.Bundle, fields []string) []model.ModerationChange {
+	return diffModerationValues(bundleModerationValues(before), bundleModerationValues(after), fields)
+}
+
+func diffModerationValues(oldValues, newValues map[string]string, fields []string) []model.ModerationChange {
+	var changes []model.ModerationChange
+	for _, field := range fields {
+		if oldValues[field] != newValues[field] {
+			changes = append(changes, model.ModerationChange{Field: field, Old: oldValues[field], New: newValues[field]})
+		}
+	}
+	return changes
+}
+
+func moderationValues(item model.Item) map[string]string {
+	if item.ID == 0 && item.Name == "" {
+		return map[string]string{}
+	}
+	values := map[string]string{
+		"name":                item.Name,
+		"description":         item.Description,
+		"article":             item.Article,
+		"category_id":         strconv.Itoa(item.CategoryID),
+		"brand_id":            strconv.Itoa(item.BrandID),
+		"material_id":         strconv.Itoa(item.MaterialID),
+		"price":               item.Price.String(),
+		"price_with_discount": item.PriceWithDiscount.String(),
+		"unit":                item.Unit,
+	}
+	return values
+}
+
+func bundleModerationValues(bundle model.Bundle) map[string]string {
+	if bundle.ID == 0 && bundle.Name == "" {
+		return map[string]string{}
+	}
+	return map[string]string{
+		"name":        bundle.Name,
+		"description": bundle.Description,
+		"category_id": strconv.Itoa(bundle.CategoryID),
+		"price":       bundle.Price.String(),
+	}
+}
+
+// mergeModerationChanges объединяет правки: для уже изменённых полей сохраняется исходное значение,
+// а поля, вернувшиеся к исходному значению, из заявки убираются. Проверяемые поля до одобрения
+// не меняются у товара, поэтому отсутствие такого поля в changes означает возврат к исходному значению.
+func mergeModerationChanges(pending, changes []model.ModerationChange) []model.ModerationChange {
+	var merged []model.ModerationChange
+	for _, change := range pending {
+		if isModerationTriggerField(change.Field) {
+			change.New = change.Old
+		}
+		merged = append(merged, change)
+	}
+	for _, change := range changes {
+		found := false
+		for i := range merged {
+			if merged[i].Field == change.Field {
+				merged[i].New = change.New
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, change)
+		}
+	}
+
+	result := merged[:0]
+	for _, change := range merged {
+		if change.Old != change.New {
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+func isModerationTriggerField(field string) bool {
+	for _, trigger := range moderationTriggerFields {
+		if field == trigger {
+			return true
+		}
+	}
+	return false
+}
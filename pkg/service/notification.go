@@ -0,0 +1,26 @@
+package service
+
+import (
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+)
+
+type NotificationService struct {
+	repo repository.Notification
+}
+
+func NewNotificationService(repo repository.Notification) *NotificationService {
+	return &NotificationService{repo: repo}
+}
+
+func (s *NotificationService) GetNotifications(recipientID string, unreadOnly bool) ([]model.NotificationOutput, error) {
+	notifications, err := s.repo.GetNotifications(recipientID, unreadOnly)
+	if err != nil {
+		return nil, err
+	}
+	return model.ConvertNotificationsToOutput(notifications), nil
+}
+
+func (s *NotificationService) MarkNotificationsRead(recipientID string, ids []int) error {
+	return s.repo.MarkNotificationsRead(recipientID, ids)
+}
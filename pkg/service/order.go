@@ -1,106 +1,318 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"stroycity/pkg/model"
 	"stroycity/pkg/repository"
+	"time"
 )
 
 type OrderService struct {
-	orderRepo  repository.Order
-	itemRepo   repository.Item
-	sellerRepo repository.Seller
-	cartRepo   repository.Cart
+	orderRepo       repository.Order
+	itemRepo        repository.Item
+	cartRepo        repository.Cart
+	bundleRepo      repository.Bundle
+	warehouseRepo   repository.Warehouse
+	reservationRepo repository.Reservation
+	reservation     ReservationConfig
 }
 
-func NewOrderService(orderRepo repository.Order, itemRepo repository.Item, sellerRepo repository.Seller, cartRepo repository.Cart) *OrderService {
+func NewOrderService(orderRepo repository.Order, itemRepo repository.Item, cartRepo repository.Cart, bundleRepo repository.Bundle, warehouseRepo repository.Warehouse, reservationRepo repository.Reservation, reservation ReservationConfig) *OrderService {
+	if reservation.TTL <= 0 {
+		reservation.TTL = defaultReservationTTL
+	}
+	if reservation.SweepInterval <= 0 {
+		reservation.SweepInterval = defaultReservationSweepInterval
+	}
 	return &OrderService{
-		orderRepo:  orderRepo,
-		itemRepo:   itemRepo,
-		sellerRepo: sellerRepo,
-		cartRepo:   cartRepo,
+		orderRepo:       orderRepo,
+		itemRepo:        itemRepo,
+		cartRepo:        cartRepo,
+		bundleRepo:      bundleRepo,
+		warehouseRepo:   warehouseRepo,
+		reservationRepo: reservationRepo,
+		reservation:     reservation,
 	}
 }
 
-func (s *OrderService) CreateOrder(buyerID string) error {
-	// Получаем товары из корзины покупателя
+// CreateOrder оформляет заказ из корзины: товары резервируются на время оплаты, а списываются только в PayOrder.
+// Комплекты раскладываются на позиции-компоненты, поэтому резерв, оплата и отмена работают с ними как с обычными товарами.
+func (s *OrderService) CreateOrder(buyerID string, input model.CheckoutInput) (int, error) {
+	// Получаем товары и комплекты из корзины покупателя
 	cartItems, err := s.cartRepo.GetCartItemsByBuyerID(buyerID)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	cartBundles, err := s.cartRepo.GetCartBundles(buyerID)
+	if err != nil {
+		return 0, err
 	}
 
-	if len(cartItems) == 0 {
-		return fmt.Errorf("cart is empty")
+	if len(cartItems) == 0 && len(cartBundles) == 0 {
+		return 0, fmt.Errorf("cart is empty")
 	}
 
-	total := 0.0
-	orderItems := []model.OrderItem{}
+	// Часть остатка может быть зарезервирована под чужие неоплаченные заказы
+	itemIDs := make([]int, 0, len(cartItems))
+	for _, cartItem := range cartItems {
+		itemIDs = append(itemIDs, cartItem.ItemID)
+	}
+	for _, cartBundle := range cartBundles {
+		for _, component := range cartBundle.Bundle.Components {
+			itemIDs = append(itemIDs, component.ItemID)
+		}
+	}
+	reserved, err := s.reservationRepo.GetReservedStock(itemIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	// Для самовывоза склад обязателен и должен его поддерживать
+	var pickupWarehouse model.Warehouse
+	if input.Pickup {
+		if input.WarehouseID == nil {
+			return 0, fmt.Errorf("warehouse_id is required for pickup")
+		}
+		pickupWarehouse, err = s.warehouseRepo.GetWarehouseById(*input.WarehouseID)
+		if err != nil {
+			return 0, fmt.Errorf("warehouse not found")
+		}
+		if !pickupWarehouse.PickupAvailable {
+			return 0, fmt.Errorf("pickup is not available at warehouse %s", pickupWarehouse.Name)
+		}
+	}
+
+	checkout := orderCheckout{input: input, pickupWarehouse: pickupWarehouse, reserved: reserved}
 
 	// Проверяем наличие товаров на складе
 	for _, cartItem := range cartItems {
 		item, err := s.itemRepo.GetItemById(cartItem.ItemID)
 		if err != nil {
-			return err
+			return 0, err
 		}
 
-		if item.Quantity < cartItem.Quantity {
-			return fmt.Errorf("not enough stock for item: %s", item.Name)
+		// Добавляем в список товаров для заказа по цене с учётом оптовых порогов
+		unitPrice := model.UnitPriceForQuantity(item, cartItem.Quantity)
+		if err := s.addOrderItem(&checkout, item, cartItem.Quantity, unitPrice.Mul(cartItem.Quantity), nil); err != nil {
+			return 0, err
+		}
+	}
+
+	// Цена комплекта делится между компонентами пропорционально их стоимости по отдельности
+	for _, cartBundle := range cartBundles {
+		bundle := cartBundle.Bundle
+		if bundle.ID == 0 || bundle.Status != model.ItemStatusPublished {
+			return 0, fmt.Errorf("bundle %d is no longer on sale", cartBundle.BundleID)
 		}
 
-		// Добавляем в список товаров для заказа
-		orderItem := model.OrderItem{
-			ItemID:    cartItem.ItemID,
-			Quantity:  cartItem.Quantity,
-			UnitPrice: item.PriceWithDiscount,
-			Total:     item.Price * float64(cartItem.Quantity),
-			SellerId:  item.SellerID,
+		items := make([]model.Item, 0, len(bundle.Components))
+		weights := make([]model.Money, 0, len(bundle.Components))
+		for _, component := range bundle.Components {
+			item, err := s.itemRepo.GetItemById(component.ItemID)
+			if err != nil {
+				return 0, fmt.Errorf("bundle %s is no longer on sale", bundle.Name)
+			}
+			items = append(items, item)
+			weights = append(weights, item.PriceWithDiscount.Mul(component.Quantity))
+		}
+
+		shares := splitBundlePrice(bundle.Price, weights)
+		for i, component := range bundle.Components {
+			quantity := component.Quantity * cartBundle.Quantity
+			if err := s.addOrderItem(&checkout, items[i], quantity, shares[i].Mul(cartBundle.Quantity), &bundle.ID); err != nil {
+				return 0, fmt.Errorf("bundle %s: %v", bundle.Name, err)
+			}
 		}
-		orderItems = append(orderItems, orderItem)
-		total += orderItem.Total
 	}
 
 	// Создаем заказ
 	order := model.Order{
 		BuyerID:    buyerID,
-		OrderItems: orderItems,
-		Total:      total,
+		OrderItems: checkout.orderItems,
+		Total:      checkout.total,
+		Currency:   checkout.currency,
+	}
+	if input.Pickup {
+		order.PickupWarehouseID = input.WarehouseID
 	}
 
-	// Сохраняем заказ
-	if err := s.orderRepo.CreateOrder(order); err != nil {
-		return err
+	reservedUntil := time.Now().Add(s.reservation.TTL)
+	order.ReservedUntil = &reservedUntil
+
+	// Сохраняем заказ вместе с резервом
+	orderID, err := s.orderRepo.CreateOrder(order)
+	if errors.Is(err, repository.ErrNotEnoughStock) {
+		return 0, fmt.Errorf("not enough stock, items were reserved by other buyers")
+	}
+	if err != nil {
+		return 0, err
 	}
 
-	// Обновляем количество товара и баланс продавца
-	for _, orderItem := range order.OrderItems {
-		item, err := s.itemRepo.GetItemById(orderItem.ItemID)
-		if err != nil {
-			return err
-		}
+	// Очищаем корзину
+	if err := s.cartRepo.ClearCart(buyerID); err != nil {
+		return 0, err
+	}
 
-		item.Quantity -= orderItem.Quantity
-		if err := s.itemRepo.UpdateItem(item); err != nil {
-			return err
-		}
+	return orderID, nil
+}
+
+// orderCheckout накапливает позиции оформляемого заказа. reserved дополняется после каждой позиции,
+// чтобы один товар — отдельно и в составе комплектов — не продавался сверх остатка.
+type orderCheckout struct {
+	input           model.CheckoutInput
+	pickupWarehouse model.Warehouse
+	reserved        map[int]model.ReservedStock
+
+	total      model.Money
+	currency   string
+	orderItems []model.OrderItem
+}
+
+// addOrderItem проверяет, что товар продаётся и его хватает, распределяет количество по складам и добавляет позицию заказа
+func (s *OrderService) addOrderItem(checkout *orderCheckout, item model.Item, quantity int, total model.Money, bundleID *int) error {
+	if item.Status != model.ItemStatusPublished {
+		return fmt.Errorf("item %s is no longer on sale", item.Name)
+	}
 
-		seller, err := s.sellerRepo.GetSeller(item.SellerID)
+	reserved := checkout.reserved[item.ID]
+	if model.Available(item.Quantity, reserved.Total) < quantity {
+		return fmt.Errorf("not enough stock for item: %s", item.Name)
+	}
+
+	// Распределяем количество по складам продавца: выбранный склад или ближайшие к покупателю
+	stocks, err := s.warehouseRepo.GetItemStocks(item.ID)
+	if err != nil {
+		return err
+	}
+	// При самовывозе всё количество берётся только с выбранного склада
+	if checkout.input.Pickup && (item.SellerID != checkout.pickupWarehouse.SellerID || !hasWarehouseStock(stocks, checkout.pickupWarehouse.ID)) {
+		return fmt.Errorf("item %s cannot be picked up at warehouse %s", item.Name, checkout.pickupWarehouse.Name)
+	}
+	var allocations []model.OrderItemAllocation
+	if len(stocks) > 0 {
+		allocations, err = allocateStock(availableStocks(stocks, reserved), quantity, checkout.input.WarehouseID, checkout.input.Latitude, checkout.input.Longitude)
 		if err != nil {
-			return err
+			return fmt.Errorf("item %s: %v", item.Name, err)
 		}
-		seller.Balance += orderItem.Total
-		if err := s.sellerRepo.UpdateSeller(seller); err != nil {
-			return err
+	}
+
+	// Все позиции заказа должны быть в одной валюте
+	if checkout.currency == "" {
+		checkout.currency = item.Currency
+	} else if item.Currency != checkout.currency {
+		return fmt.Errorf("item %s has currency %s, order currency is %s", item.Name, item.Currency, checkout.currency)
+	}
+
+	// Учитываем взятое количество для следующих позиций с тем же товаром
+	byWarehouse := map[int]int{}
+	for warehouseID, quantity := range reserved.ByWarehouse {
+		byWarehouse[warehouseID] = quantity
+	}
+	for _, allocation := range allocations {
+		byWarehouse[allocation.WarehouseID] += allocation.Quantity
+	}
+	checkout.reserved[item.ID] = model.ReservedStock{Total: reserved.Total + quantity, ByWarehouse: byWarehouse}
+
+	orderItem := model.OrderItem{
+		ItemID:      item.ID,
+		Quantity:    quantity,
+		Total:       total,
+		SellerId:    item.SellerID,
+		Allocations: allocations,
+		BundleID:    bundleID,
+	}
+	checkout.orderItems = append(checkout.orderItems, priceOrderItem(orderItem)...)
+	checkout.total += orderItem.Total
+	return nil
+}
+
+// priceOrderItem задаёт цену за единицу позиции по её сумме Total. Если сумма не делится на количество
+// (доля цены комплекта), остаток в копейках получает одна единица, выделенная в отдельную позицию
+// со своим складом, так что у каждой позиции цена за единицу, умноженная на количество, равна её сумме.
+func priceOrderItem(orderItem model.OrderItem) []model.OrderItem {
+	unitPrice := orderItem.Total / model.Money(orderItem.Quantity)
+	remainder := orderItem.Total - unitPrice.Mul(orderItem.Quantity)
+	if remainder == 0 {
+		orderItem.UnitPrice = unitPrice
+		return []model.OrderItem{orderItem}
+	}
+
+	rest, unit := orderItem, orderItem
+	rest.Quantity--
+	rest.UnitPrice = unitPrice
+	rest.Total = unitPrice.Mul(rest.Quantity)
+	unit.Quantity = 1
+	unit.UnitPrice = unitPrice + remainder
+	unit.Total = unit.UnitPrice
+
+	if last := len(orderItem.Allocations) - 1; last >= 0 {
+		rest.Allocations = append([]model.OrderItemAllocation(nil), orderItem.Allocations...)
+		unit.Allocations = []model.OrderItemAllocation{{WarehouseID: rest.Allocations[last].WarehouseID, Quantity: 1}}
+		if rest.Allocations[last].Quantity--; rest.Allocations[last].Quantity == 0 {
+			rest.Allocations = rest.Allocations[:last]
 		}
 	}
+	return []model.OrderItem{rest, unit}
+}
 
-	// Очищаем корзину
-	if err := s.cartRepo.ClearCart(buyerID); err != nil {
+// PayOrder подтверждает оплату заказа: резерв превращается в списание остатков, продавцы получают оплату.
+// Списание и зачисление на балансы продавцов выполняются в одной транзакции репозитория.
+func (s *OrderService) PayOrder(buyerID string, orderID int) error {
+	order, err := s.orderRepo.GetOrderById(orderID)
+	if err != nil || order.BuyerID != buyerID {
+		return ErrOrderNotFound
+	}
+
+	err = s.orderRepo.PayOrder(orderID)
+	switch {
+	case errors.Is(err, repository.ErrReservationExpired):
+		return ErrReservationExpired
+	case errors.Is(err, repository.ErrOrderAlreadyPaid):
+		return ErrOrderAlreadyPaid
+	case errors.Is(err, repository.ErrNotEnoughStock):
+		return fmt.Errorf("not enough stock to complete the order")
+	case err != nil:
 		return err
 	}
 
 	return nil
 }
 
+// CancelOrder отменяет неоплаченный заказ покупателя со снятием резерва.
+// Оплаченный заказ покупатель отменить не может — его возвращает администратор через RefundOrder.
+func (s *OrderService) CancelOrder(buyerID string, orderID int) error {
+	order, err := s.orderRepo.GetOrderById(orderID)
+	if err != nil || order.BuyerID != buyerID {
+		return ErrOrderNotFound
+	}
+
+	change := model.StockChange{Type: model.MovementTypeCancellation, ActorID: buyerID, Reason: "cancelled by buyer"}
+	return s.cancelOrder(orderID, change, false)
+}
+
+// RefundOrder отменяет заказ по решению администратора. Оплаченный заказ возвращается:
+// товар — на склады, оплата — с балансов продавцов; всё в одной транзакции.
+func (s *OrderService) RefundOrder(adminID string, orderID int, reason string) error {
+	if _, err := s.orderRepo.GetOrderById(orderID); err != nil {
+		return ErrOrderNotFound
+	}
+
+	if reason == "" {
+		reason = "refunded by admin"
+	}
+	change := model.StockChange{Type: model.MovementTypeCancellation, ActorID: adminID, Reason: reason}
+	return s.cancelOrder(orderID, change, true)
+}
+
+func (s *OrderService) cancelOrder(orderID int, change model.StockChange, refund bool) error {
+	err := s.orderRepo.CancelOrder(orderID, change, refund)
+	if errors.Is(err, repository.ErrOrderNotCancellable) {
+		return ErrOrderNotCancellable
+	}
+	return err
+}
+
 func (s *OrderService) GetOrderById(orderID int) (model.OrderOutput, error) {
 	order, err := s.orderRepo.GetOrderById(orderID)
 	if err != nil {
@@ -118,15 +330,23 @@ func (s *OrderService) ClearCart(buyerID string) error {
 func (s *OrderService) orderToOrderOutput(order model.Order) model.OrderOutput {
 	orderOutput := model.OrderOutput{}
 	orderOutput.Total = order.Total
+	orderOutput.Currency = order.Currency
 	orderOutput.Status = order.Status
+	orderOutput.BuyerID = order.BuyerID
+	orderOutput.PickupWarehouseID = order.PickupWarehouseID
+	if order.Status == model.OrderStatusAwaitingPayment {
+		orderOutput.ReservedUntil = order.ReservedUntil
+	}
 	for _, orderItem := range order.OrderItems {
-		itemInfo, _ := s.itemRepo.GetItemById(orderItem.ID)
+		itemInfo, _ := s.itemRepo.GetItemByIdUnscoped(orderItem.ItemID)
 
 		orderOutput.Items = append(orderOutput.Items, model.OrderItemInfo{
-			ID:       orderItem.ID,
+			ID:       orderItem.ItemID,
 			Name:     itemInfo.Name,
-			Price:    itemInfo.Price,
-			Quantity: itemInfo.Quantity,
+			Price:    orderItem.UnitPrice,
+			Quantity: orderItem.Quantity,
+			Total:    orderItem.Total,
+			BundleID: orderItem.BundleID,
 		})
 	}
 	return orderOutput
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"reflect"
+	"stroycity/pkg/model"
+	"testing"
+)
+
+func TestPriceOrderItem(t *testing.T) {
+	allocations := func(quantities ...int) []model.OrderItemAllocation {
+		result := make([]model.OrderItemAllocation, 0, len(quantities))
+		for i, quantity := range quantities {
+			result = append(result, model.OrderItemAllocation{WarehouseID: i + 1, Quantity: quantity})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name  string
+		input model.OrderItem
+		want  []model.OrderItem
+	}{
+		{
+			name:  "total divides evenly",
+			input: model.OrderItem{Quantity: 3, Total: 300, Allocations: allocations(2, 1)},
+			want:  []model.OrderItem{{Quantity: 3, UnitPrice: 100, Total: 300, Allocations: allocations(2, 1)}},
+		},
+		{
+			name:  "remainder goes to one unit",
+			input: model.OrderItem{Quantity: 3, Total: 1000, Allocations: allocations(3)},
+			want: []model.OrderItem{
+				{Quantity: 2, UnitPrice: 333, Total: 666, Allocations: allocations(2)},
+				{Quantity: 1, UnitPrice: 334, Total: 334, Allocations: allocations(1)},
+			},
+		},
+		{
+			name:  "remainder unit takes the last warehouse",
+			input: model.OrderItem{Quantity: 3, Total: 101, Allocations: allocations(2, 1)},
+			want: []model.OrderItem{
+				{Quantity: 2, UnitPrice: 33, Total: 66, Allocations: allocations(2)},
+				{Quantity: 1, UnitPrice: 35, Total: 35, Allocations: []model.OrderItemAllocation{{WarehouseID: 2, Quantity: 1}}},
+			},
+		},
+		{
+			name:  "item without warehouses",
+			input: model.OrderItem{Quantity: 2, Total: 5},
+			want:  []model.OrderItem{{Quantity: 1, UnitPrice: 2, Total: 2}, {Quantity: 1, UnitPrice: 3, Total: 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := priceOrderItem(tt.input)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+			var total model.Money
+			for _, orderItem := range got {
+				if orderItem.UnitPrice.Mul(orderItem.Quantity) != orderItem.Total {
+					t.Errorf("unit price %v × %d != total %v", orderItem.UnitPrice, orderItem.Quantity, orderItem.Total)
+				}
+				total += orderItem.Total
+			}
+			if total != tt.input.Total {
+				t.Errorf("lines sum to %v, want %v", total, tt.input.Total)
+			}
+		})
+	}
+}
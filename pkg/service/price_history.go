@@ -0,0 +1,57 @@
+package service
+
+import (
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+	"time"
+)
+
+type PriceHistoryService struct {
+	repo     repository.PriceHistory
+	itemRepo repository.Item
+}
+
+func NewPriceHistoryService(repo repository.PriceHistory, itemRepo repository.Item) *PriceHistoryService {
+	return &PriceHistoryService{repo: repo, itemRepo: itemRepo}
+}
+
+// GetPriceHistory возвращает точки графика цены товара за период.
+// Если задано начало периода, первой идёт цена, действовавшая на этот момент.
+// История неопубликованного товара не показывается, как и сам товар.
+func (s *PriceHistoryService) GetPriceHistory(itemID int, from, to *time.Time) (model.PriceHistoryOutput, error) {
+	item, err := s.itemRepo.GetItemById(itemID)
+	if err != nil || item.Status != model.ItemStatusPublished {
+		return model.PriceHistoryOutput{}, ErrItemNotFound
+	}
+
+	changes, err := s.repo.GetPriceHistory(itemID, from, to)
+	if err != nil {
+		return model.PriceHistoryOutput{}, err
+	}
+	if from != nil {
+		initial, err := s.repo.GetPriceAt(itemID, *from)
+		if err != nil {
+			return model.PriceHistoryOutput{}, err
+		}
+		if initial.ID != 0 {
+			initial.CreatedAt = *from
+			changes = append([]model.PriceChange{initial}, changes...)
+		}
+	}
+
+	output := model.PriceHistoryOutput{ItemID: item.ID, Currency: item.Currency, Points: []model.PricePoint{}}
+	for i, change := range changes {
+		output.Points = append(output.Points, model.PricePoint{
+			Date:              change.CreatedAt,
+			Price:             change.Price,
+			PriceWithDiscount: change.PriceWithDiscount,
+		})
+		if i == 0 || change.PriceWithDiscount < output.MinPrice {
+			output.MinPrice = change.PriceWithDiscount
+		}
+		if change.PriceWithDiscount > output.MaxPrice {
+			output.MaxPrice = change.PriceWithDiscount
+		}
+	}
+	return output, nil
+}
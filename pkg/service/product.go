@@ -0,0 +1,68 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+	"stroycity/pkg/storage"
+)
+
+type ProductService struct {
+	repo            repository.Product
+	reservationRepo repository.Reservation
+	storage         storage.BlobStorage
+}
+
+func NewProductService(repo repository.Product, reservationRepo repository.Reservation, storage storage.BlobStorage) *ProductService {
+	return &ProductService{repo: repo, reservationRepo: reservationRepo, storage: storage}
+}
+
+func (s *ProductService) CreateProduct(sellerID string, input model.ProductInput) (int, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return 0, errors.New("product name is required")
+	}
+
+	product := model.Product{
+		SellerID:    sellerID,
+		Name:        name,
+		Description: input.Description,
+	}
+
+	seen := map[string]bool{}
+	for _, axis := range input.Axes {
+		axis = strings.TrimSpace(axis)
+		if axis == "" || seen[strings.ToLower(axis)] {
+			continue
+		}
+		seen[strings.ToLower(axis)] = true
+		product.Axes = append(product.Axes, model.VariantAxis{Name: axis, Position: len(product.Axes)})
+	}
+	if len(product.Axes) == 0 {
+		return 0, errors.New("product must have at least one variant axis")
+	}
+
+	return s.repo.CreateProduct(product)
+}
+
+func (s *ProductService) GetProductById(productID int) (model.VariantMatrix, error) {
+	product, err := s.repo.GetProductById(productID)
+	if err != nil {
+		return model.VariantMatrix{}, err
+	}
+	reserved, err := s.reservationRepo.GetReservedStock(productItemIDs(product))
+	if err != nil {
+		return model.VariantMatrix{}, err
+	}
+	return model.ConvertProductToVariantMatrix(product, reserved, s.storage.URL), nil
+}
+
+// productItemIDs возвращает ID всех SKU карточки товара
+func productItemIDs(product model.Product) []int {
+	itemIDs := make([]int, 0, len(product.Items))
+	for _, item := range product.Items {
+		itemIDs = append(itemIDs, item.ID)
+	}
+	return itemIDs
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrTooManyRequests возвращается, если клиент превысил допустимое число запросов
+var ErrTooManyRequests = errors.New("too many requests, try again later")
+
+// rateLimiter ограничивает число событий по ключу (адресу клиента, электронной почте) в скользящем окне.
+// Состояние хранится в памяти процесса; устаревшие ключи удаляются при очередной проверке.
+type rateLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	hits   map[string][]time.Time
+	swept  time.Time
+}
+
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{limit: limit, window: window, hits: map[string][]time.Time{}}
+}
+
+// Allow учитывает событие и возвращает false, если за окно по ключу их уже было limit
+func (l *rateLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	since := now.Add(-l.window)
+	if now.Sub(l.swept) > l.window {
+		for k, hits := range l.hits {
+			if len(hits) == 0 || !hits[len(hits)-1].After(since) {
+				delete(l.hits, k)
+			}
+		}
+		l.swept = now
+	}
+
+	hits := l.hits[key]
+	for len(hits) > 0 && !hits[0].After(since) {
+		hits = hits[1:]
+	}
+	if len(hits) >= l.limit {
+		l.hits[key] = hits
+		return false
+	}
+	l.hits[key] = append(hits, now)
+	return true
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(2, 50*time.Millisecond)
+
+	if !limiter.Allow("a") || !limiter.Allow("a") {
+		t.Fatal("expected the first two events to be allowed")
+	}
+	if limiter.Allow("a") {
+		t.Error("expected the third event to be limited")
+	}
+	if !limiter.Allow("b") {
+		t.Error("expected another key to be allowed")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if !limiter.Allow("a") {
+		t.Error("expected the event to be allowed after the window")
+	}
+}
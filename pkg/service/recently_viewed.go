@@ -0,0 +1,84 @@
+package service
+
+import (
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+	"stroycity/pkg/storage"
+	"time"
+)
+
+const (
+	// viewCountInterval — повторные просмотры товара одним посетителем чаще этого интервала не увеличивают популярность
+	viewCountInterval = 30 * time.Minute
+
+	// Токен устройства гость выбирает сам, поэтому просмотры гостей с одного адреса клиента
+	// учитываются в популярности не больше guestViewLimit раз за guestViewLimitWindow
+	guestViewLimit       = 100
+	guestViewLimitWindow = time.Hour
+)
+
+type RecentlyViewedService struct {
+	repo            repository.RecentlyViewed
+	reservationRepo repository.Reservation
+	storage         storage.BlobStorage
+	guestViewLimit  *rateLimiter
+}
+
+func NewRecentlyViewedService(repo repository.RecentlyViewed, reservationRepo repository.Reservation, storage storage.BlobStorage) *RecentlyViewedService {
+	return &RecentlyViewedService{
+		repo:            repo,
+		reservationRepo: reservationRepo,
+		storage:         storage,
+		guestViewLimit:  newRateLimiter(guestViewLimit, guestViewLimitWindow),
+	}
+}
+
+// RecordView учитывает просмотр карточки товара: добавляет его в историю покупателя или гостя
+// и в счётчик популярности. Анонимный просмотр без токена устройства не учитывается,
+// а просмотры гостей сверх лимита для адреса клиента clientIP попадают только в историю.
+func (s *RecentlyViewedService) RecordView(buyerID, deviceToken, clientIP string, itemID int) error {
+	if buyerID != "" {
+		deviceToken = ""
+	}
+	if buyerID == "" && deviceToken == "" {
+		return nil
+	}
+	countable := buyerID != "" || s.guestViewLimit.Allow(clientIP)
+	return s.repo.RecordView(buyerID, deviceToken, itemID, countable, time.Now().Add(-viewCountInterval))
+}
+
+// GetRecentlyViewed возвращает недавно просмотренные товары, начиная с последнего
+func (s *RecentlyViewedService) GetRecentlyViewed(buyerID, deviceToken string) ([]model.RecentlyViewedOutput, error) {
+	buyerID, deviceToken, err := visitor(buyerID, deviceToken)
+	if err != nil {
+		return nil, err
+	}
+
+	views, err := s.repo.GetRecentlyViewed(buyerID, deviceToken)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]model.Item, 0, len(views))
+	for _, view := range views {
+		items = append(items, view.Item)
+	}
+	infos, err := convertItemsWithAvailability(s.reservationRepo, s.storage, items)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := []model.RecentlyViewedOutput{}
+	for i, view := range views {
+		outputs = append(outputs, model.RecentlyViewedOutput{Item: infos[i], ViewedAt: view.ViewedAt})
+	}
+	return outputs, nil
+}
+
+func (s *RecentlyViewedService) ClearRecentlyViewed(buyerID, deviceToken string) error {
+	buyerID, deviceToken, err := visitor(buyerID, deviceToken)
+	if err != nil {
+		return err
+	}
+	return s.repo.ClearRecentlyViewed(buyerID, deviceToken)
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+	"stroycity/pkg/storage"
+	"time"
+)
+
+const (
+	defaultRecommendationRefreshInterval = time.Hour
+	defaultRecommendationMinOrders       = 2
+	defaultRecommendationPriceBand       = 0.3
+	defaultRecommendationLimit           = 8
+)
+
+// RecommendationConfig — параметры подбора рекомендаций
+type RecommendationConfig struct {
+	RefreshInterval time.Duration
+	// MinOrders — сколько заказов должны содержать оба товара, чтобы считать их покупаемыми вместе
+	MinOrders int
+	// PriceBand — допустимое отклонение цены похожего товара, доля от цены (0.3 — ±30%)
+	PriceBand float64
+	Limit     int
+}
+
+type RecommendationService struct {
+	repo            repository.Recommendation
+	itemRepo        repository.Item
+	cartRepo        repository.Cart
+	reservationRepo repository.Reservation
+	storage         storage.BlobStorage
+	config          RecommendationConfig
+}
+
+func NewRecommendationService(repo repository.Recommendation, itemRepo repository.Item, cartRepo repository.Cart, reservationRepo repository.Reservation, storage storage.BlobStorage, config RecommendationConfig) *RecommendationService {
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = defaultRecommendationRefreshInterval
+	}
+	if config.MinOrders <= 0 {
+		config.MinOrders = defaultRecommendationMinOrders
+	}
+	if config.PriceBand <= 0 {
+		config.PriceBand = defaultRecommendationPriceBand
+	}
+	if config.Limit <= 0 {
+		config.Limit = defaultRecommendationLimit
+	}
+	return &RecommendationService{
+		repo:            repo,
+		itemRepo:        itemRepo,
+		cartRepo:        cartRepo,
+		reservationRepo: reservationRepo,
+		storage:         storage,
+		config:          config,
+	}
+}
+
+// GetItemRecommendations возвращает к опубликованному товару покупаемые вместе, похожие
+// и сопутствующие товары. Рекомендуются только товары в наличии.
+func (s *RecommendationService) GetItemRecommendations(itemID int) (model.RecommendationsOutput, error) {
+	item, err := s.itemRepo.GetItemById(itemID)
+	if err != nil || item.Status != model.ItemStatusPublished {
+		return model.RecommendationsOutput{}, ErrItemNotFound
+	}
+
+	boughtTogether, err := s.repo.GetBoughtTogether([]int{item.ID}, s.config.Limit)
+	if err != nil {
+		return model.RecommendationsOutput{}, err
+	}
+	similar, err := s.repo.GetSimilarItems(item, s.config.PriceBand, s.config.Limit)
+	if err != nil {
+		return model.RecommendationsOutput{}, err
+	}
+	complementary, err := s.repo.GetComplementaryItems([]int{item.ID}, s.config.Limit)
+	if err != nil {
+		return model.RecommendationsOutput{}, err
+	}
+
+	return s.convertRecommendations(boughtTogether, similar, complementary)
+}
+
+// GetCartRecommendations подбирает к корзине покупателя товары, которые берут вместе с её содержимым,
+// и сопутствующие товары из других категорий — «для вашего проекта»
+func (s *RecommendationService) GetCartRecommendations(buyerID string) (model.RecommendationsOutput, error) {
+	cartItems, err := s.cartRepo.GetCartItemsByBuyerID(buyerID)
+	if err != nil {
+		return model.RecommendationsOutput{}, err
+	}
+	if len(cartItems) == 0 {
+		return s.convertRecommendations(nil, nil, nil)
+	}
+
+	itemIDs := make([]int, 0, len(cartItems))
+	for _, cartItem := range cartItems {
+		itemIDs = append(itemIDs, cartItem.ItemID)
+	}
+
+	boughtTogether, err := s.repo.GetBoughtTogether(itemIDs, s.config.Limit)
+	if err != nil {
+		return model.RecommendationsOutput{}, err
+	}
+	complementary, err := s.repo.GetComplementaryItems(itemIDs, s.config.Limit)
+	if err != nil {
+		return model.RecommendationsOutput{}, err
+	}
+
+	return s.convertRecommendations(boughtTogether, nil, complementary)
+}
+
+func (s *RecommendationService) convertRecommendations(boughtTogether, similar, complementary []model.Item) (model.RecommendationsOutput, error) {
+	output := model.RecommendationsOutput{}
+	for _, selection := range []struct {
+		items  []model.Item
+		output *[]model.ItemInfo
+	}{
+		{boughtTogether, &output.BoughtTogether},
+		{similar, &output.Similar},
+		{complementary, &output.CompleteProject},
+	} {
+		*selection.output = []model.ItemInfo{}
+		if len(selection.items) == 0 {
+			continue
+		}
+		infos, err := convertItemsWithAvailability(s.reservationRepo, s.storage, selection.items)
+		if err != nil {
+			return model.RecommendationsOutput{}, err
+		}
+		*selection.output = infos
+	}
+	return output, nil
+}
+
+// RunRecommendationRefresher пересчитывает совместные покупки при запуске и затем периодически
+func (s *RecommendationService) RunRecommendationRefresher(ctx context.Context) {
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.repo.RefreshCoPurchases(s.config.MinOrders); err != nil {
+			logrus.Errorf("failed to refresh co-purchases: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"stroycity/pkg/model"
+	"time"
+)
+
+const (
+	defaultReservationTTL           = 15 * time.Minute
+	defaultReservationSweepInterval = time.Minute
+)
+
+var (
+	// ErrOrderNotFound возвращается, если заказа нет или он принадлежит другому покупателю
+	ErrOrderNotFound = errors.New("order not found")
+	// ErrReservationExpired возвращается при оплате заказа после истечения резерва
+	ErrReservationExpired = errors.New("reservation expired, order was cancelled")
+	// ErrOrderAlreadyPaid возвращается при повторной оплате заказа
+	ErrOrderAlreadyPaid = errors.New("order already paid")
+	// ErrOrderNotCancellable возвращается при отмене уже отменённого заказа или отмене оплаченного заказа покупателем
+	ErrOrderNotCancellable = errors.New("order cannot be cancelled: it is already cancelled or paid")
+)
+
+// ReservationConfig — параметры резервирования товара на время оплаты.
+// TTL — сколько товар удерживается за неоплаченным заказом, SweepInterval — как часто снимаются просроченные резервы.
+type ReservationConfig struct {
+	TTL           time.Duration
+	SweepInterval time.Duration
+}
+
+// RunReservationSweeper периодически снимает просроченные резервы и отменяет неоплаченные заказы
+func (s *OrderService) RunReservationSweeper(ctx context.Context) {
+	ticker := time.NewTicker(s.reservation.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cancelled, err := s.reservationRepo.ExpireReservations(time.Now())
+		if err != nil {
+			logrus.Errorf("failed to expire reservations: %s", err.Error())
+			continue
+		}
+		if cancelled > 0 {
+			logrus.Infof("cancelled %d unpaid orders with expired reservations", cancelled)
+		}
+	}
+}
+
+// availableStocks уменьшает складские остатки на действующие резервы
+func availableStocks(stocks []model.WarehouseStock, reserved model.ReservedStock) []model.WarehouseStock {
+	available := make([]model.WarehouseStock, 0, len(stocks))
+	for _, stock := range stocks {
+		stock.Quantity = model.Available(stock.Quantity, reserved.ByWarehouse[stock.WarehouseID])
+		available = append(available, stock)
+	}
+	return available
+}
@@ -4,14 +4,16 @@ import (
 	"github.com/gofrs/uuid"
 	"stroycity/pkg/model"
 	"stroycity/pkg/repository"
+	"stroycity/pkg/storage"
 )
 
 type SellerService struct {
-	repo repository.Seller
+	repo    repository.Seller
+	storage storage.BlobStorage
 }
 
-func NewSellerService(repo repository.Seller) *SellerService {
-	return &SellerService{repo: repo}
+func NewSellerService(repo repository.Seller, storage storage.BlobStorage) *SellerService {
+	return &SellerService{repo: repo, storage: storage}
 }
 
 func (s *SellerService) SellerSignUp(seller model.Seller) error {
@@ -27,12 +29,14 @@ func (s *SellerService) GetSeller(id string) (model.SellerOutput, error) {
 		return model.SellerOutput{}, err
 	}
 
-	items := model.ConvertItemsToItemInfo(seller.Items)
+	items := model.ConvertItemsToItemInfo(seller.Items, s.storage.URL)
 	sellerOutput := model.SellerOutput{
 		ID:       seller.ID,
 		Name:     seller.Name,
 		Email:    seller.Email,
 		ShopName: seller.ShopName,
+		Balance:  seller.Balance,
+		Currency: seller.Currency,
 		Items:    items,
 	}
 	return sellerOutput, nil
@@ -55,7 +59,7 @@ func (s *SellerService) SellerSignIn(mail, password string) (model.SellerSignInR
 
 	token := CreateToken(seller.ID, "seller")
 
-	items := model.ConvertItemsToItemInfo(seller.Items)
+	items := model.ConvertItemsToItemInfo(seller.Items, s.storage.URL)
 
 	signInResponse.Token = token
 	signInResponse.Seller = model.SellerOutput{
@@ -63,12 +67,14 @@ func (s *SellerService) SellerSignIn(mail, password string) (model.SellerSignInR
 		Name:     seller.Name,
 		Email:    seller.Email,
 		ShopName: seller.ShopName,
+		Balance:  seller.Balance,
+		Currency: seller.Currency,
 		Items:    items,
 	}
 
 	return signInResponse, err
 }
 
-func (s *SellerService) GetSellerEarnings(sellerID string) (float64, float64, error) {
+func (s *SellerService) GetSellerEarnings(sellerID string) (model.Money, model.Money, error) {
 	return s.repo.GetSellerEarnings(sellerID)
 }
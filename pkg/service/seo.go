@@ -0,0 +1,81 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+	"unicode/utf8"
+)
+
+// maxMetaDescriptionLength — длина описания для поисковиков, которое формируется из текста страницы
+const maxMetaDescriptionLength = 160
+
+// SEOConfig — параметры публичных адресов каталога
+type SEOConfig struct {
+	SiteURL string
+}
+
+// canonicalURL возвращает абсолютный адрес страницы сайта
+func (c SEOConfig) canonicalURL(path string) string {
+	return strings.TrimRight(c.SiteURL, "/") + path
+}
+
+// assignSlug генерирует по названию уникальный адрес сущности и сохраняет его.
+// Прежний адрес остаётся перенаправлением на сущность. Если адрес успели занять
+// между проверкой и сохранением, пробуется следующий вариант.
+func assignSlug(repo repository.Slug, entityType string, entityID int, name string) error {
+	base := model.SlugBase(entityType, name)
+	for n := 1; ; n++ {
+		slug := model.SlugCandidate(base, n)
+		taken, err := repo.IsSlugTaken(entityType, slug, entityID)
+		if err != nil {
+			return err
+		}
+		if taken {
+			continue
+		}
+		if err := repo.SetSlug(entityType, entityID, slug); !errors.Is(err, repository.ErrSlugTaken) {
+			return err
+		}
+	}
+}
+
+// resolveSlug возвращает ID сущности по текущему или прежнему адресу; 0, если адрес неизвестен
+func resolveSlug(repo repository.Slug, entityType, slug string) (int, error) {
+	id, err := repo.GetEntityIDBySlug(entityType, slug)
+	if err != nil || id != 0 {
+		return id, err
+	}
+	redirect, err := repo.GetSlugRedirect(entityType, slug)
+	if err != nil {
+		return 0, err
+	}
+	return redirect.EntityID, nil
+}
+
+// metaTitle возвращает заголовок для поисковиков; если он не задан — название
+func metaTitle(title, name string) string {
+	if title = strings.TrimSpace(title); title != "" {
+		return title
+	}
+	return name
+}
+
+// metaDescription возвращает описание для поисковиков; если оно не задано — начало текста страницы по границе слова
+func metaDescription(description, text string) string {
+	if description = strings.TrimSpace(description); description != "" {
+		return description
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= maxMetaDescriptionLength {
+		return text
+	}
+
+	runes := []rune(text)[:maxMetaDescriptionLength-1]
+	cut := string(runes)
+	if i := strings.LastIndexByte(cut, ' '); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:-") + "…"
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMetaDescription(t *testing.T) {
+	words := strings.Repeat("цемент ", 40)
+
+	tests := []struct {
+		name        string
+		description string
+		text        string
+		want        string
+	}{
+		{name: "explicit description wins", description: "  Описание  ", text: "Текст страницы", want: "Описание"},
+		{name: "short text is used as is", text: "Сухая  смесь\nдля стяжки", want: "Сухая смесь для стяжки"},
+		{name: "empty text", text: "  ", want: ""},
+		{name: "long text is cut at a word boundary", text: words, want: strings.TrimSpace(strings.Repeat("цемент ", 22)) + "…"},
+		{name: "punctuation before the cut is trimmed", text: strings.Repeat("а", 150) + ", " + strings.Repeat("б", 20), want: strings.Repeat("а", 150) + "…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := metaDescription(tt.description, tt.text)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if n := utf8.RuneCountInString(got); tt.description == "" && n > maxMetaDescriptionLength {
+				t.Errorf("description is longer than %d runes: %d", maxMetaDescriptionLength, n)
+			}
+		})
+	}
+}
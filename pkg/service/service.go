@@ -1,9 +1,14 @@
 package service
 
 import (
+	"context"
+	"io"
 	"mime/multipart"
+	"stroycity/pkg/mail"
 	"stroycity/pkg/model"
 	"stroycity/pkg/repository"
+	"stroycity/pkg/storage"
+	"time"
 )
 
 type Service struct {
@@ -17,38 +22,95 @@ type Service struct {
 	Admin
 	Cart
 	Review
+	Attribute
+	Product
+	Import
+	Feed
+	CommerceML
+	Inventory
+	Warehouse
+	Notification
+	Moderation
+	Sitemap
+	PriceHistory
+	StockSubscription
+	Comparison
+	Recommendation
+	RecentlyViewed
+	Bundle
+
+	Storage storage.BlobStorage
+}
+
+// Config — настройки сервисов, которые задаются окружением
+type Config struct {
+	Feed              FeedConfig
+	CommerceML        CommerceMLConfig
+	Reservation       ReservationConfig
+	Moderation        ModerationConfig
+	SEO               SEOConfig
+	StockSubscription StockSubscriptionConfig
+	Recommendation    RecommendationConfig
 }
 
-func NewService(repos *repository.Repository) *Service {
+func NewService(repos *repository.Repository, blobStorage storage.BlobStorage, mailer mail.Sender, cfg Config) *Service {
+	itemService := NewItemService(repos.Item, repos.Attribute, repos.Product, repos.Warehouse, repos.Reservation, repos.Moderation, repos.Slug, blobStorage, cfg.SEO)
+
 	return &Service{
-		Category: NewCategoryService(repos.Category),
-		Brand:    NewBrandService(repos.Brand),
-		Material: NewMaterialService(repos.Material),
-		Seller:   NewSellerService(repos.Seller),
-		Item:     NewItemService(repos.Item),
-		Buyer:    NewBuyerService(repos.Buyer, repos.Item),
-		Order:    NewOrderService(repos.Order, repos.Item, repos.Seller, repos.Cart),
-		Admin:    NewAdminService(repos.Admin),
-		Cart:     NewCartService(repos.Cart, repos.Item),
-		Review:   NewReviewService(repos.Review),
+		Category:          NewCategoryService(repos.Category, repos.Slug, cfg.SEO),
+		Brand:             NewBrandService(repos.Brand, repos.Slug, blobStorage, cfg.SEO),
+		Material:          NewMaterialService(repos.Material),
+		Seller:            NewSellerService(repos.Seller, blobStorage),
+		Item:              itemService,
+		Buyer:             NewBuyerService(repos.Buyer, repos.Item, blobStorage),
+		Order:             NewOrderService(repos.Order, repos.Item, repos.Cart, repos.Bundle, repos.Warehouse, repos.Reservation, cfg.Reservation),
+		Admin:             NewAdminService(repos.Admin),
+		Cart:              NewCartService(repos.Cart, repos.Item, repos.Bundle),
+		Review:            NewReviewService(repos.Review),
+		Attribute:         NewAttributeService(repos.Attribute),
+		Product:           NewProductService(repos.Product, repos.Reservation, blobStorage),
+		Import:            NewImportService(repos.Import, repos.Item, repos.Category, repos.Brand, repos.Material, itemService),
+		Feed:              NewFeedService(repos.Item, repos.Category, repos.Seller, blobStorage, cfg.Feed),
+		CommerceML:        NewCommerceMLService(repos.Item, itemService, cfg.CommerceML),
+		Inventory:         NewInventoryService(repos.Item, repos.Warehouse, repos.Movement),
+		Warehouse:         NewWarehouseService(repos.Warehouse, repos.Item),
+		Notification:      NewNotificationService(repos.Notification),
+		Moderation:        NewModerationService(repos.Moderation, repos.Slug, cfg.Moderation),
+		Sitemap:           NewSitemapService(repos.Item, repos.Category, cfg.SEO),
+		PriceHistory:      NewPriceHistoryService(repos.PriceHistory, repos.Item),
+		StockSubscription: NewStockSubscriptionService(repos.StockSubscription, repos.Item, repos.Reservation, mailer, blobStorage, cfg.SEO, cfg.StockSubscription),
+		Comparison:        NewComparisonService(repos.Comparison, repos.Item, itemService, blobStorage),
+		Recommendation:    NewRecommendationService(repos.Recommendation, repos.Item, repos.Cart, repos.Reservation, blobStorage, cfg.Recommendation),
+		RecentlyViewed:    NewRecentlyViewedService(repos.RecentlyViewed, repos.Reservation, blobStorage),
+		Bundle:            NewBundleService(repos.Bundle, repos.Item, repos.Category, repos.Reservation, repos.Moderation, blobStorage),
+		Storage:           blobStorage,
 	}
 }
 
 type Category interface {
 	CreateCategory(category model.Category) error
-	DeleteCategory(id int) error
+	UpdateCategory(id int, input model.CategoryInput) error
+	DeleteCategory(id, reassignTo int) error
+	MergeCategories(input model.MergeInput) error
 	GetCategoryList() ([]model.CategoryOutput, error)
+	GetCategoryBySlug(slug string) (model.CategoryOutput, error)
 }
 
 type Brand interface {
 	CreateBrand(Brand model.Brand) error
-	DeleteBrand(id int) error
+	UpdateBrand(id int, input model.BrandInput) error
+	UploadBrandLogo(id int, fileHeader *multipart.FileHeader) (string, error)
+	DeleteBrand(id, reassignTo int) error
+	MergeBrands(input model.MergeInput) error
 	GetBrandList() ([]model.BrandOutput, error)
+	GetBrandBySlug(slug string) (model.BrandOutput, error)
 }
 
 type Material interface {
 	CreateMaterial(material model.Material) error
-	DeleteMaterial(id int) error
+	UpdateMaterial(id int, input model.MaterialInput) error
+	DeleteMaterial(id, reassignTo int) error
+	MergeMaterials(input model.MergeInput) error
 	GetMaterialList() ([]model.MaterialOutput, error)
 }
 
@@ -57,16 +119,24 @@ type Seller interface {
 	GetSeller(id string) (model.SellerOutput, error)
 	UpdateSeller(id string, seller model.Seller) error
 	SellerSignIn(mail, password string) (model.SellerSignInResponse, error)
-	GetSellerEarnings(sellerID string) (float64, float64, error)
+	GetSellerEarnings(sellerID string) (model.Money, model.Money, error)
 }
 
 type Item interface {
-	CreateItem(item model.Item) (int, error)
+	CreateItem(item model.Item, change model.StockChange) (int, error)
 	GetItemById(itemID int) (model.CurrentItemInfo, error)
-	UpdateItem(item model.Item) error
-	GetItems(brandIDs, sellerIDs, categoryIDs, materialIDs []uint, minPrice, maxPrice float64, query string) ([]model.ItemInfo, error)
+	GetItemBySlug(slug string) (model.CurrentItemInfo, error)
+	UpdateItem(item model.Item, change model.StockChange) error
+	SetItemStatus(itemID int, status string) error
+	DeleteItem(itemID int) error
+	GetItems(brandIDs, sellerIDs, categoryIDs, materialIDs []uint, minPrice, maxPrice model.Money, query string, attributes []model.AttributeFilter, sort string) ([]model.ItemInfo, error)
 	GetAllItems() ([]model.ItemInfo, error)
-	UploadImage(itemID int, file multipart.File, fileHeader *multipart.FileHeader) (string, error)
+	UploadImage(itemID int, file multipart.File, fileHeader *multipart.FileHeader) (model.ImageInfo, error)
+	UploadImages(itemID int, fileHeaders []*multipart.FileHeader) ([]model.ImageInfo, error)
+	GetItemImages(itemID int) ([]model.ImageInfo, error)
+	ReorderImages(itemID int, imageIDs []int) error
+	SetPrimaryImage(itemID, imageID int) error
+	DeleteImage(itemID, imageID int) error
 }
 
 type Buyer interface {
@@ -79,9 +149,13 @@ type Buyer interface {
 }
 
 type Order interface {
-	CreateOrder(buyerID string) error
+	CreateOrder(buyerID string, input model.CheckoutInput) (int, error)
+	PayOrder(buyerID string, orderID int) error
+	CancelOrder(buyerID string, orderID int) error
+	RefundOrder(adminID string, orderID int, reason string) error
 	GetOrderById(orderID int) (model.OrderOutput, error)
 	ClearCart(buyerID string) error
+	RunReservationSweeper(ctx context.Context)
 }
 
 type Admin interface {
@@ -94,9 +168,112 @@ type Cart interface {
 	GetCart(buyerID string) (model.CartOutput, error)
 	UpdateCartItem(cartItemID int, quantity int) error
 	RemoveFromCart(userID string, itemID int) error
+	AddBundleToCart(buyerID string, bundleID int, quantity int) error
+	RemoveBundleFromCart(buyerID string, bundleID int) error
 }
 
 type Review interface {
 	CreateReview(review model.Review) error
 	GetReviewsByItemID(itemID int) ([]model.Review, error)
 }
+
+type Attribute interface {
+	CreateAttribute(input model.AttributeInput) (int, error)
+	DeleteAttribute(id int) error
+	GetAttributesByCategoryID(categoryID int) ([]model.AttributeOutput, error)
+}
+
+type Product interface {
+	CreateProduct(sellerID string, input model.ProductInput) (int, error)
+	GetProductById(productID int) (model.VariantMatrix, error)
+}
+
+type Import interface {
+	StartImport(sellerID string, fileHeader *multipart.FileHeader, mapping map[string]string) (int, error)
+	GetImportJob(sellerID string, jobID int) (model.ImportJobOutput, error)
+	GetImportErrorReport(sellerID string, jobID int) ([]byte, error)
+}
+
+type Feed interface {
+	GetFeed(format, sellerID string) (model.Feed, error)
+	RunFeedRefresher(ctx context.Context)
+}
+
+type CommerceML interface {
+	CommerceMLInit(sellerID string) error
+	CommerceMLSaveFile(sellerID, filename string, data io.Reader) error
+	CommerceMLImport(sellerID, filename string) (model.CommerceMLResult, error)
+}
+
+type Inventory interface {
+	UpdateInventory(sellerID string, updates []model.InventoryUpdate) ([]model.InventoryUpdateResult, error)
+	GetMovements(sellerID string, filter model.StockMovementFilter) ([]model.StockMovementOutput, error)
+	SetLowStockThreshold(sellerID string, input model.LowStockThresholdInput) error
+}
+
+type Warehouse interface {
+	CreateWarehouse(sellerID string, input model.WarehouseInput) (int, error)
+	UpdateWarehouse(sellerID string, id int, input model.WarehouseInput) error
+	DeleteWarehouse(sellerID string, id int) error
+	GetWarehouses(sellerID string) ([]model.WarehouseOutput, error)
+	SetWarehouseStock(sellerID string, input model.WarehouseStockInput) error
+}
+
+type Notification interface {
+	GetNotifications(recipientID string, unreadOnly bool) ([]model.NotificationOutput, error)
+	MarkNotificationsRead(recipientID string, ids []int) error
+}
+
+type Moderation interface {
+	GetModerationQueue(status string) ([]model.ModerationRequestOutput, error)
+	ApproveItem(adminID string, requestID int) error
+	RejectItem(adminID string, requestID int, reason string) error
+	GetSellerModerationRequests(sellerID string, itemID int) ([]model.ModerationRequestOutput, error)
+}
+
+type Sitemap interface {
+	GetSitemap(page int) ([]byte, error)
+}
+
+type PriceHistory interface {
+	GetPriceHistory(itemID int, from, to *time.Time) (model.PriceHistoryOutput, error)
+}
+
+type StockSubscription interface {
+	SubscribeBuyer(buyerID string, itemID int) error
+	SubscribeGuest(itemID int, email, clientIP string) error
+	GetBuyerSubscriptions(buyerID string) ([]model.StockSubscriptionOutput, error)
+	UnsubscribeBuyer(buyerID string, itemID int) error
+	GetSubscriptionByToken(token string) (model.GuestSubscriptionOutput, error)
+	Unsubscribe(token string) error
+	RunStockSubscriptionMailer(ctx context.Context)
+}
+
+type Comparison interface {
+	AddToComparison(buyerID, deviceToken string, itemID int) error
+	RemoveFromComparison(buyerID, deviceToken string, itemID int) error
+	ClearComparison(buyerID, deviceToken string, categoryID int) error
+	GetComparison(buyerID, deviceToken string) ([]model.ComparisonCategory, error)
+	GetComparisonTable(buyerID, deviceToken string, categoryID int, onlyDifferences bool) (model.ComparisonTable, error)
+}
+
+type Recommendation interface {
+	GetItemRecommendations(itemID int) (model.RecommendationsOutput, error)
+	GetCartRecommendations(buyerID string) (model.RecommendationsOutput, error)
+	RunRecommendationRefresher(ctx context.Context)
+}
+
+type RecentlyViewed interface {
+	RecordView(buyerID, deviceToken, clientIP string, itemID int) error
+	GetRecentlyViewed(buyerID, deviceToken string) ([]model.RecentlyViewedOutput, error)
+	ClearRecentlyViewed(buyerID, deviceToken string) error
+}
+
+type Bundle interface {
+	CreateBundle(sellerID string, input model.BundleInput) (int, error)
+	UpdateBundle(sellerID string, id int, input model.BundleInput) error
+	DeleteBundle(sellerID string, id int) error
+	GetSellerBundles(sellerID string) ([]model.BundleInfo, error)
+	GetBundles(categoryID int) ([]model.BundleInfo, error)
+	GetBundleById(id int) (model.BundleInfo, error)
+}
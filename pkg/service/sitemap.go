@@ -0,0 +1,109 @@
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+)
+
+const (
+	// sitemapMaxURLs — ограничение протокола Sitemaps на число адресов в одном файле
+	sitemapMaxURLs = 50000
+	sitemapXMLNS   = "http://www.sitemaps.org/schemas/sitemap/0.9"
+)
+
+// ErrSitemapPageNotFound возвращается для несуществующей части карты сайта
+var ErrSitemapPageNotFound = errors.New("sitemap page not found")
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+	Priority   string `xml:"priority,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapRef `xml:"sitemap"`
+}
+
+type sitemapRef struct {
+	Loc string `xml:"loc"`
+}
+
+type SitemapService struct {
+	itemRepo     repository.Item
+	categoryRepo repository.Category
+	config       SEOConfig
+}
+
+func NewSitemapService(itemRepo repository.Item, categoryRepo repository.Category, config SEOConfig) *SitemapService {
+	return &SitemapService{itemRepo: itemRepo, categoryRepo: categoryRepo, config: config}
+}
+
+// GetSitemap возвращает карту сайта с главной страницей, категориями и опубликованными товарами.
+// Страница 0 — корневой файл: если адресов больше sitemapMaxURLs, это индекс, ссылающийся на части 1…N.
+func (s *SitemapService) GetSitemap(page int) ([]byte, error) {
+	urls, err := s.sitemapURLs()
+	if err != nil {
+		return nil, err
+	}
+	pages := (len(urls) + sitemapMaxURLs - 1) / sitemapMaxURLs
+
+	if page == 0 {
+		if pages <= 1 {
+			return encodeSitemap(sitemapURLSet{XMLNS: sitemapXMLNS, URLs: urls})
+		}
+		index := sitemapIndex{XMLNS: sitemapXMLNS}
+		for i := 1; i <= pages; i++ {
+			index.Sitemaps = append(index.Sitemaps, sitemapRef{Loc: s.config.canonicalURL(fmt.Sprintf("/sitemap.xml?page=%d", i))})
+		}
+		return encodeSitemap(index)
+	}
+
+	if page < 1 || page > pages {
+		return nil, ErrSitemapPageNotFound
+	}
+	end := min(page*sitemapMaxURLs, len(urls))
+	return encodeSitemap(sitemapURLSet{XMLNS: sitemapXMLNS, URLs: urls[(page-1)*sitemapMaxURLs : end]})
+}
+
+func (s *SitemapService) sitemapURLs() ([]sitemapURL, error) {
+	categories, err := s.categoryRepo.GetCategoryList()
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.itemRepo.GetItemsForSitemap()
+	if err != nil {
+		return nil, err
+	}
+
+	urls := []sitemapURL{{Loc: s.config.canonicalURL("/"), ChangeFreq: "daily", Priority: "1.0"}}
+	for _, category := range categories {
+		urls = append(urls, sitemapURL{Loc: s.config.canonicalURL(model.CategoryPath(category)), ChangeFreq: "daily", Priority: "0.8"})
+	}
+	for _, item := range items {
+		urls = append(urls, sitemapURL{Loc: s.config.canonicalURL(model.ItemPath(item)), ChangeFreq: "weekly", Priority: "0.6"})
+	}
+	return urls, nil
+}
+
+func encodeSitemap(sitemap interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(sitemap); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	netmail "net/mail"
+	"net/url"
+	"strings"
+	"stroycity/pkg/mail"
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+	"stroycity/pkg/storage"
+	"time"
+)
+
+// stockMailBatchSize — сколько писем о поступлении отправляется за один проход рассылки
+const stockMailBatchSize = 100
+
+var (
+	// ErrItemInStock возвращается при подписке на товар, который уже есть в наличии
+	ErrItemInStock          = errors.New("item is in stock")
+	ErrInvalidEmail         = errors.New("invalid email address")
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+)
+
+// StockSubscriptionConfig — параметры рассылки писем о поступлении товара гостям.
+// GuestLimit ограничивает число гостевых подписок за GuestLimitWindow с одного адреса клиента
+// и писем-подтверждений на один адрес электронной почты.
+type StockSubscriptionConfig struct {
+	MailInterval     time.Duration
+	GuestLimit       int
+	GuestLimitWindow time.Duration
+}
+
+type StockSubscriptionService struct {
+	repo            repository.StockSubscription
+	itemRepo        repository.Item
+	reservationRepo repository.Reservation
+	mailer          mail.Sender
+	storage         storage.BlobStorage
+	seo             SEOConfig
+	config          StockSubscriptionConfig
+	clientLimit     *rateLimiter
+	emailLimit      *rateLimiter
+}
+
+func NewStockSubscriptionService(repo repository.StockSubscription, itemRepo repository.Item, reservationRepo repository.Reservation, mailer mail.Sender, storage storage.BlobStorage, seo SEOConfig, config StockSubscriptionConfig) *StockSubscriptionService {
+	if config.MailInterval <= 0 {
+		config.MailInterval = time.Minute
+	}
+	if config.GuestLimit <= 0 {
+		config.GuestLimit = 10
+	}
+	if config.GuestLimitWindow <= 0 {
+		config.GuestLimitWindow = time.Hour
+	}
+	return &StockSubscriptionService{
+		repo:            repo,
+		itemRepo:        itemRepo,
+		reservationRepo: reservationRepo,
+		mailer:          mailer,
+		storage:         storage,
+		seo:             seo,
+		config:          config,
+		clientLimit:     newRateLimiter(config.GuestLimit, config.GuestLimitWindow),
+		emailLimit:      newRateLimiter(config.GuestLimit, config.GuestLimitWindow),
+	}
+}
+
+// SubscribeBuyer подписывает покупателя на поступление товара; уведомление придёт в личный кабинет
+func (s *StockSubscriptionService) SubscribeBuyer(buyerID string, itemID int) error {
+	if _, err := s.subscribe(model.StockSubscription{ItemID: itemID, BuyerID: buyerID}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SubscribeGuest подписывает гостя на поступление товара по адресу электронной почты
+// и отправляет письмо-подтверждение со ссылкой отписки. Повторная подписка, которая ещё ждёт
+// поступления, ничего не меняет и письмо не отправляет. Число подписок с адреса клиента clientIP
+// и писем на один адрес электронной почты ограничено, чтобы форму нельзя было использовать для рассылки.
+func (s *StockSubscriptionService) SubscribeGuest(itemID int, email, clientIP string) error {
+	address, err := netmail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return ErrInvalidEmail
+	}
+	email = strings.ToLower(address.Address)
+	if !s.clientLimit.Allow(clientIP) {
+		return ErrTooManyRequests
+	}
+
+	existing, err := s.repo.GetGuestSubscription(itemID, email)
+	if err != nil {
+		return err
+	}
+	if existing.ID != 0 && existing.NotifiedAt == nil {
+		return nil
+	}
+	if !s.emailLimit.Allow(email) {
+		return ErrTooManyRequests
+	}
+
+	subscription, err := s.subscribe(model.StockSubscription{ItemID: itemID, Email: email})
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("Подписка на поступление «%s»", subscription.Item.Name)
+	body := fmt.Sprintf("Мы сообщим на этот адрес, когда товар «%s» снова появится в наличии.\n%s\n\nОтменить подписку: %s\n",
+		subscription.Item.Name, s.seo.canonicalURL(model.ItemPath(subscription.Item)), s.unsubscribeURL(subscription.Token))
+	// Подписка уже сохранена, поэтому сбой отправки подтверждения не отменяет её
+	if err := s.mailer.Send(context.Background(), subscription.Email, subject, body); err != nil {
+		logrus.Errorf("failed to send stock subscription confirmation to %s: %s", subscription.Email, err.Error())
+	}
+	return nil
+}
+
+// subscribe сохраняет подписку на опубликованный товар, которого нет в наличии.
+// В наличии считается только свободный остаток: товар, целиком ушедший в резервы, купить нельзя.
+func (s *StockSubscriptionService) subscribe(subscription model.StockSubscription) (model.StockSubscription, error) {
+	item, err := s.itemRepo.GetItemById(subscription.ItemID)
+	if err != nil || item.Status != model.ItemStatusPublished {
+		return subscription, ErrItemNotFound
+	}
+	reserved, err := s.reservationRepo.GetReservedStock([]int{item.ID})
+	if err != nil {
+		return subscription, err
+	}
+	if model.Available(item.Quantity, reserved[item.ID].Total) > 0 {
+		return subscription, ErrItemInStock
+	}
+
+	subscription.Token, err = newSubscriptionToken()
+	if err != nil {
+		return subscription, err
+	}
+	saved, err := s.repo.Subscribe(subscription)
+	if err != nil {
+		return subscription, err
+	}
+	saved.Item = item
+	return saved, nil
+}
+
+func (s *StockSubscriptionService) GetBuyerSubscriptions(buyerID string) ([]model.StockSubscriptionOutput, error) {
+	subscriptions, err := s.repo.GetBuyerSubscriptions(buyerID)
+	if err != nil {
+		return nil, err
+	}
+
+	itemIDs := make([]int, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		itemIDs = append(itemIDs, subscription.ItemID)
+	}
+	reserved, err := s.reservationRepo.GetReservedStock(itemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := []model.StockSubscriptionOutput{}
+	for _, subscription := range subscriptions {
+		outputs = append(outputs, model.StockSubscriptionOutput{
+			ID:         subscription.ID,
+			Item:       model.ConvertItemsToItemInfo([]model.Item{subscription.Item}, s.storage.URL)[0],
+			InStock:    model.Available(subscription.Item.Quantity, reserved[subscription.ItemID].Total) > 0,
+			CreatedAt:  subscription.CreatedAt,
+			NotifiedAt: subscription.NotifiedAt,
+		})
+	}
+	return outputs, nil
+}
+
+func (s *StockSubscriptionService) UnsubscribeBuyer(buyerID string, itemID int) error {
+	deleted, err := s.repo.DeleteBuyerSubscription(buyerID, itemID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+// GetSubscriptionByToken возвращает гостевую подписку по токену из ссылки отписки,
+// чтобы показать гостю, от чего он отписывается
+func (s *StockSubscriptionService) GetSubscriptionByToken(token string) (model.GuestSubscriptionOutput, error) {
+	if token == "" {
+		return model.GuestSubscriptionOutput{}, ErrSubscriptionNotFound
+	}
+	subscription, err := s.repo.GetSubscriptionByToken(token)
+	if err != nil {
+		return model.GuestSubscriptionOutput{}, err
+	}
+	if subscription.ID == 0 {
+		return model.GuestSubscriptionOutput{}, ErrSubscriptionNotFound
+	}
+	return model.GuestSubscriptionOutput{
+		Email: subscription.Email,
+		Item:  model.ConvertItemsToItemInfo([]model.Item{subscription.Item}, s.storage.URL)[0],
+	}, nil
+}
+
+// Unsubscribe отменяет подписку по ссылке из письма
+func (s *StockSubscriptionService) Unsubscribe(token string) error {
+	if token == "" {
+		return ErrSubscriptionNotFound
+	}
+	deleted, err := s.repo.DeleteSubscriptionByToken(token)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+// RunStockSubscriptionMailer периодически отправляет гостям письма о поступлении товаров.
+// Уведомления покупателям создаются сразу при изменении остатков, а письма отправляются отдельно,
+// чтобы медленный почтовый сервер не задерживал транзакцию изменения остатков.
+func (s *StockSubscriptionService) RunStockSubscriptionMailer(ctx context.Context) {
+	ticker := time.NewTicker(s.config.MailInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		subscriptions, err := s.repo.GetUnsentEmails(stockMailBatchSize)
+		if err != nil {
+			logrus.Errorf("failed to get back-in-stock emails: %s", err.Error())
+			continue
+		}
+		for _, subscription := range subscriptions {
+			if err := s.sendBackInStock(ctx, subscription); err != nil {
+				logrus.Errorf("failed to send back-in-stock email to %s: %s", subscription.Email, err.Error())
+			}
+		}
+	}
+}
+
+// sendBackInStock отправляет письмо о поступлении и отмечает его отправленным.
+// Подписка остаётся: при следующей пропаже товара гость может подписаться снова тем же адресом.
+func (s *StockSubscriptionService) sendBackInStock(ctx context.Context, subscription model.StockSubscription) error {
+	subject := fmt.Sprintf("«%s» снова в наличии", subscription.Item.Name)
+	body := fmt.Sprintf("Товар «%s», на поступление которого вы подписались, снова в наличии.\n%s\n\nОтменить подписку: %s\n",
+		subscription.Item.Name, s.seo.canonicalURL(model.ItemPath(subscription.Item)), s.unsubscribeURL(subscription.Token))
+	if err := s.mailer.Send(ctx, subscription.Email, subject, body); err != nil {
+		return err
+	}
+	return s.repo.MarkEmailed(subscription.ID)
+}
+
+func (s *StockSubscriptionService) unsubscribeURL(token string) string {
+	return s.seo.canonicalURL("/stock-subscription/unsubscribe?token=" + url.QueryEscape(token))
+}
+
+func newSubscriptionToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
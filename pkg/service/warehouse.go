@@ -0,0 +1,169 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"stroycity/pkg/model"
+	"stroycity/pkg/repository"
+)
+
+var (
+	// ErrWarehouseNotFound возвращается, если склад не найден или принадлежит другому продавцу
+	ErrWarehouseNotFound = errors.New("warehouse not found")
+	// ErrItemNotFound возвращается, если товар не найден или принадлежит другому продавцу
+	ErrItemNotFound = errors.New("item not found")
+)
+
+type WarehouseService struct {
+	repo     repository.Warehouse
+	itemRepo repository.Item
+}
+
+func NewWarehouseService(repo repository.Warehouse, itemRepo repository.Item) *WarehouseService {
+	return &WarehouseService{repo: repo, itemRepo: itemRepo}
+}
+
+func (s *WarehouseService) CreateWarehouse(sellerID string, input model.WarehouseInput) (int, error) {
+	warehouse := model.Warehouse{SellerID: sellerID, PickupAvailable: true}
+	if err := applyWarehouseInput(&warehouse, input); err != nil {
+		return 0, err
+	}
+	return s.repo.CreateWarehouse(warehouse)
+}
+
+func (s *WarehouseService) UpdateWarehouse(sellerID string, id int, input model.WarehouseInput) error {
+	warehouse, err := s.getSellerWarehouse(sellerID, id)
+	if err != nil {
+		return err
+	}
+	if err := applyWarehouseInput(&warehouse, input); err != nil {
+		return err
+	}
+	return s.repo.UpdateWarehouse(warehouse)
+}
+
+func (s *WarehouseService) DeleteWarehouse(sellerID string, id int) error {
+	if _, err := s.getSellerWarehouse(sellerID, id); err != nil {
+		return err
+	}
+	change := model.StockChange{Type: model.MovementTypeAdjustment, ActorID: sellerID, Reason: fmt.Sprintf("warehouse #%d deleted", id)}
+	return s.repo.DeleteWarehouse(id, change)
+}
+
+func (s *WarehouseService) GetWarehouses(sellerID string) ([]model.WarehouseOutput, error) {
+	warehouses, err := s.repo.GetWarehouses(sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var outputs []model.WarehouseOutput
+	for _, warehouse := range warehouses {
+		outputs = append(outputs, model.ConvertWarehouseToOutput(warehouse))
+	}
+	return outputs, nil
+}
+
+// SetWarehouseStock задаёт остаток товара продавца на его складе
+func (s *WarehouseService) SetWarehouseStock(sellerID string, input model.WarehouseStockInput) error {
+	if input.Quantity < 0 {
+		return errors.New("quantity must not be negative")
+	}
+	if _, err := s.getSellerWarehouse(sellerID, input.WarehouseID); err != nil {
+		return err
+	}
+	item, err := s.itemRepo.GetItemById(input.ItemID)
+	if err != nil || item.SellerID != sellerID {
+		return ErrItemNotFound
+	}
+	change := model.StockChange{Type: model.MovementTypeAdjustment, ActorID: sellerID, Reason: input.Reason}
+	return s.repo.SetStock(input.ItemID, input.WarehouseID, input.Quantity, change)
+}
+
+func (s *WarehouseService) getSellerWarehouse(sellerID string, id int) (model.Warehouse, error) {
+	warehouse, err := s.repo.GetWarehouseById(id)
+	if err != nil || warehouse.SellerID != sellerID {
+		return model.Warehouse{}, ErrWarehouseNotFound
+	}
+	return warehouse, nil
+}
+
+func applyWarehouseInput(warehouse *model.Warehouse, input model.WarehouseInput) error {
+	if input.Name == "" || input.Address == "" {
+		return errors.New("warehouse name and address are required")
+	}
+	if input.Latitude < -90 || input.Latitude > 90 || input.Longitude < -180 || input.Longitude > 180 {
+		return errors.New("invalid warehouse coordinates")
+	}
+
+	warehouse.Name = input.Name
+	warehouse.Address = input.Address
+	warehouse.Latitude = input.Latitude
+	warehouse.Longitude = input.Longitude
+	if input.PickupAvailable != nil {
+		warehouse.PickupAvailable = *input.PickupAvailable
+	}
+	return nil
+}
+
+// hasWarehouseStock сообщает, есть ли у товара строка остатка на складе
+func hasWarehouseStock(stocks []model.WarehouseStock, warehouseID int) bool {
+	for _, stock := range stocks {
+		if stock.WarehouseID == warehouseID {
+			return true
+		}
+	}
+	return false
+}
+
+// allocateStock распределяет количество по складам товара.
+// Выбранный склад должен покрыть всё количество сам; иначе берётся ближайший склад с достаточным остатком,
+// а если такого нет — остатки собираются с нескольких складов, начиная с ближайших.
+// Без координат покупателя склады перебираются по убыванию остатка.
+func allocateStock(stocks []model.WarehouseStock, quantity int, warehouseID *int, latitude, longitude *float64) ([]model.OrderItemAllocation, error) {
+	if warehouseID != nil {
+		for _, stock := range stocks {
+			if stock.WarehouseID != *warehouseID {
+				continue
+			}
+			if stock.Quantity < quantity {
+				return nil, fmt.Errorf("only %d left at warehouse %s", stock.Quantity, stock.Warehouse.Name)
+			}
+			return []model.OrderItemAllocation{{WarehouseID: stock.WarehouseID, Quantity: quantity}}, nil
+		}
+	}
+
+	sorted := append([]model.WarehouseStock(nil), stocks...)
+	if latitude != nil && longitude != nil {
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return model.DistanceKm(*latitude, *longitude, sorted[i].Warehouse.Latitude, sorted[i].Warehouse.Longitude) <
+				model.DistanceKm(*latitude, *longitude, sorted[j].Warehouse.Latitude, sorted[j].Warehouse.Longitude)
+		})
+	} else {
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Quantity > sorted[j].Quantity })
+	}
+
+	for _, stock := range sorted {
+		if stock.Quantity >= quantity {
+			return []model.OrderItemAllocation{{WarehouseID: stock.WarehouseID, Quantity: quantity}}, nil
+		}
+	}
+
+	var allocations []model.OrderItemAllocation
+	remaining := quantity
+	for _, stock := range sorted {
+		if remaining == 0 {
+			break
+		}
+		if stock.Quantity <= 0 {
+			continue
+		}
+		take := min(stock.Quantity, remaining)
+		allocations = append(allocations, model.OrderItemAllocation{WarehouseID: stock.WarehouseID, Quantity: take})
+		remaining -= take
+	}
+	if remaining > 0 {
+		return nil, errors.New("not enough stock")
+	}
+	return allocations, nil
+}
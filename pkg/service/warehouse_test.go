@@ -0,0 +1,107 @@
+package service
+
+import (
+	"reflect"
+	"stroycity/pkg/model"
+	"testing"
+)
+
+func TestAllocateStock(t *testing.T) {
+	warehouse := func(id, quantity int, latitude, longitude float64) model.WarehouseStock {
+		return model.WarehouseStock{
+			WarehouseID: id,
+			Quantity:    quantity,
+			Warehouse:   model.Warehouse{ID: id, Name: "warehouse", Latitude: latitude, Longitude: longitude},
+		}
+	}
+	// Склад 1 — в Москве, склад 2 — в Санкт-Петербурге, склад 3 — в Казани
+	stocks := []model.WarehouseStock{
+		warehouse(1, 5, 55.75, 37.62),
+		warehouse(2, 20, 59.94, 30.31),
+		warehouse(3, 8, 55.79, 49.12),
+	}
+	intPtr := func(v int) *int { return &v }
+	floatPtr := func(v float64) *float64 { return &v }
+	spbLatitude, spbLongitude := floatPtr(59.9), floatPtr(30.3)
+	mskLatitude, mskLongitude := floatPtr(55.7), floatPtr(37.6)
+
+	tests := []struct {
+		name        string
+		quantity    int
+		warehouseID *int
+		latitude    *float64
+		longitude   *float64
+		want        []model.OrderItemAllocation
+		wantErr     bool
+	}{
+		{
+			name:     "without coordinates the fullest warehouse is used",
+			quantity: 4,
+			want:     []model.OrderItemAllocation{{WarehouseID: 2, Quantity: 4}},
+		},
+		{
+			name:     "nearest warehouse with enough stock",
+			quantity: 4,
+			latitude: mskLatitude, longitude: mskLongitude,
+			want: []model.OrderItemAllocation{{WarehouseID: 1, Quantity: 4}},
+		},
+		{
+			name:     "nearest warehouse is skipped when it cannot cover the quantity",
+			quantity: 7,
+			latitude: mskLatitude, longitude: mskLongitude,
+			want: []model.OrderItemAllocation{{WarehouseID: 2, Quantity: 7}},
+		},
+		{
+			name:     "split across warehouses starting from the nearest",
+			quantity: 30,
+			latitude: spbLatitude, longitude: spbLongitude,
+			want: []model.OrderItemAllocation{{WarehouseID: 2, Quantity: 20}, {WarehouseID: 1, Quantity: 5}, {WarehouseID: 3, Quantity: 5}},
+		},
+		{
+			name:        "selected warehouse covers the quantity",
+			quantity:    3,
+			warehouseID: intPtr(3),
+			latitude:    spbLatitude, longitude: spbLongitude,
+			want: []model.OrderItemAllocation{{WarehouseID: 3, Quantity: 3}},
+		},
+		{
+			name:        "selected warehouse without enough stock",
+			quantity:    6,
+			warehouseID: intPtr(1),
+			wantErr:     true,
+		},
+		{
+			name:     "not enough stock in total",
+			quantity: 34,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := allocateStock(stocks, tt.quantity, tt.warehouseID, tt.latitude, tt.longitude)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasWarehouseStock(t *testing.T) {
+	stocks := []model.WarehouseStock{{WarehouseID: 1}, {WarehouseID: 4}}
+	if !hasWarehouseStock(stocks, 4) {
+		t.Error("expected stock at warehouse 4")
+	}
+	if hasWarehouseStock(stocks, 2) {
+		t.Error("unexpected stock at warehouse 2")
+	}
+}
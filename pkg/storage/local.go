@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage хранит файлы в каталоге на диске; каталог раздаётся роутером по PublicURL
+type LocalStorage struct {
+	dir       string
+	publicURL string
+}
+
+func NewLocalStorage(dir, publicURL string) *LocalStorage {
+	if dir == "" {
+		dir = "uploads"
+	}
+	if publicURL == "" {
+		publicURL = "/uploads"
+	}
+	return &LocalStorage{dir: dir, publicURL: strings.TrimRight(publicURL, "/")}
+}
+
+func (s *LocalStorage) Dir() string {
+	return s.dir
+}
+
+func (s *LocalStorage) PublicURL() string {
+	return s.publicURL
+}
+
+func (s *LocalStorage) Put(_ context.Context, key string, data io.Reader, _ int64, _ string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directory: %v", err)
+	}
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, data); err != nil {
+		return fmt.Errorf("failed to save file: %v", err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStorage) URL(key string) string {
+	return s.publicURL + "/" + strings.TrimLeft(key, "/")
+}
+
+// path переводит ключ в путь внутри каталога, не позволяя выйти за его пределы
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if clean == "/" {
+		return "", errors.New("empty storage key")
+	}
+	return filepath.Join(s.dir, clean), nil
+}
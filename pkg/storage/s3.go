@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"io"
+	"strings"
+	"time"
+)
+
+// S3Storage хранит файлы в S3-совместимом бакете. Если задан PublicURL (публичный бакет или CDN),
+// ссылки строятся от него, иначе выдаются подписанные ссылки со сроком действия URLExpiry.
+type S3Storage struct {
+	client    *minio.Client
+	bucket    string
+	publicURL string
+	urlExpiry time.Duration
+}
+
+func NewS3Storage(cfg Config) (*S3Storage, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	exists, err := client.BucketExists(ctx, cfg.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check bucket %s: %v", cfg.Bucket, err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{Region: cfg.Region}); err != nil {
+			return nil, fmt.Errorf("failed to create bucket %s: %v", cfg.Bucket, err)
+		}
+	}
+
+	urlExpiry := cfg.URLExpiry
+	if urlExpiry <= 0 {
+		urlExpiry = 24 * time.Hour
+	}
+
+	return &S3Storage{
+		client:    client,
+		bucket:    cfg.Bucket,
+		publicURL: strings.TrimRight(cfg.PublicURL, "/"),
+		urlExpiry: urlExpiry,
+	}, nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, data io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, data, size, minio.PutObjectOptions{ContentType: contentType})
+	if err != nil {
+		return fmt.Errorf("failed to upload object %s: %v", key, err)
+	}
+	return nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *S3Storage) URL(key string) string {
+	if s.publicURL != "" {
+		return s.publicURL + "/" + strings.TrimLeft(key, "/")
+	}
+
+	signed, err := s.client.PresignedGetObject(context.Background(), s.bucket, key, s.urlExpiry, nil)
+	if err != nil {
+		return ""
+	}
+	return signed.String()
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// BlobStorage — хранилище загружаемых файлов. В БД сохраняется только ключ объекта,
+// а ссылка для клиента строится методом URL, поэтому хранилище можно сменить без миграции данных.
+type BlobStorage interface {
+	Put(ctx context.Context, key string, data io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+type Config struct {
+	Driver string
+
+	// Локальный диск
+	LocalDir       string
+	LocalPublicURL string
+
+	// S3-совместимое хранилище (AWS S3, MinIO, Yandex Object Storage)
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	Region    string
+	UseSSL    bool
+	PublicURL string
+	URLExpiry time.Duration
+}
+
+func NewBlobStorage(cfg Config) (BlobStorage, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalStorage(cfg.LocalDir, cfg.LocalPublicURL), nil
+	case "s3":
+		return NewS3Storage(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", cfg.Driver)
+	}
+}